@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"rest-api/internal/config"
+	"rest-api/internal/event"
+	"rest-api/internal/graph"
 	"rest-api/internal/handler"
 	"rest-api/internal/middleware"
 	"rest-api/internal/repository"
 	"rest-api/internal/route"
+	"rest-api/internal/rpc"
 	"rest-api/internal/service"
+	"rest-api/internal/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,32 +36,124 @@ func main() {
 	// Layer 1: Initialize Repositories (Data Access Layer)
 	userRepository := repository.NewUserRepository(db)
 	todoRepository := repository.NewTodoRepository(db)
+	idempotencyRepository := repository.NewIdempotencyRepository(db)
+	importJobRepository := repository.NewImportJobRepository(db)
+	calendarFeedRepository := repository.NewCalendarFeedRepository(db)
+	workflowRepository := repository.NewWorkflowRepository(db)
+	customFieldRepository := repository.NewCustomFieldRepository(db)
+	dependencyRepository := repository.NewDependencyRepository(db)
+	timeEntryRepository := repository.NewTimeEntryRepository(db)
+	savedViewRepository := repository.NewSavedViewRepository(db)
+	templateRepository := repository.NewTemplateRepository(db)
+	webhookRepository := repository.NewWebhookRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
 	log.Println("Repositories initialized")
 
+	// Todo changes fan out to every replica through Postgres LISTEN/NOTIFY
+	eventBus := event.NewBus(cfg.EventReplaySize)
+	eventBus.UseTransport(event.NewPostgresTransport(db))
+	go event.Listen(context.Background(), db, eventBus)
+
+	// Emails and todo events are written to the outbox with the changes
+	// they belong to and relayed once those commit
+	outboxRelay := service.NewOutboxRelay(outboxRepository)
+
 	// Layer 2: Initialize Services (Business Logic Layer)
-	authService := service.NewAuthService(userRepository)
-	todoService := service.NewTodoService(todoRepository)
+	authService := service.NewAuthService(userRepository, outboxRelay)
+	todoService := service.NewTodoService(todoRepository, userRepository, workflowRepository, customFieldRepository, dependencyRepository, outboxRelay)
+	importService := service.NewImportService(todoService, importJobRepository)
+	calendarService := service.NewCalendarService(calendarFeedRepository, todoRepository, workflowRepository)
+	workflowService := service.NewWorkflowService(workflowRepository, todoRepository)
+	customFieldService := service.NewCustomFieldService(customFieldRepository)
+	dependencyService := service.NewDependencyService(dependencyRepository, todoService)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepository, todoService)
+	savedViewService := service.NewSavedViewService(savedViewRepository, todoService)
+	templateService := service.NewTemplateService(templateRepository, todoService)
+	webhookService := service.NewWebhookService(webhookRepository, todoService, cfg.WebhookAllowPrivate)
 	log.Println("Services initialized")
 
+	// Background imports do not survive a restart; the jobs they leave
+	// behind are failed once they stop saving progress
+	go importService.Run(context.Background())
+
+	// Todo events are queued for webhooks before they are streamed;
+	// deliveries are attempted by the workers of all replicas
+	outboxRelay.Handle(service.TopicTodoEvent, service.RelayTodoEvents(webhookService, eventBus))
+	outboxRelay.Handle(service.TopicPasswordResetEmail, service.RelayPasswordResetEmails(utils.SendResetEmail))
+	go outboxRelay.Run(context.Background())
+	go webhookService.Run(context.Background())
+
+	// GraphQL schema resolving through the same services
+	schema, err := graph.NewSchema(todoService, authService, dependencyService, graph.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create GraphQL schema: %v", err)
+	}
+
 	// Layer 3: Initialize Handlers (HTTP Layer)
 	userHandler := handler.NewUserHandler(authService)
 	todoHandler := handler.NewTodoHandler(todoService)
+	importHandler := handler.NewImportHandler(importService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	dependencyHandler := handler.NewDependencyHandler(dependencyService, todoService)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService)
+	savedViewHandler := handler.NewSavedViewHandler(savedViewService, todoService)
+	templateHandler := handler.NewTemplateHandler(templateService, todoService)
+	graphqlHandler := handler.NewGraphQLHandler(schema)
+	eventHandler := handler.NewEventHandler(eventBus, todoService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
 	healthHandler := handler.NewHealthHandler(db)
 	log.Println("Handlers initialized")
 
 	// Initialize Gin router
 	router := gin.Default()
 
+	// The docs handler documents the routes of the router it serves
+	docsHandler := handler.NewDocsHandler(router)
+
 	// Apply global middleware
 	router.Use(middleware.LoggerMiddleware())
 	router.Use(middleware.CORSMiddleware())
 	router.Use(middleware.ErrorHandler())
+	router.Use(middleware.LocaleMiddleware(userRepository))
+	router.Use(middleware.NegotiationMiddleware(router))
+	router.Use(middleware.ValidationMiddleware(router, gin.Mode() == gin.TestMode))
+	router.Use(middleware.IdempotencyMiddleware(idempotencyRepository, cfg.IdempotencyTTL))
 	log.Println("Middleware applied")
 
+	// Purge expired idempotency keys and published outbox messages in the background
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := idempotencyRepository.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+			}
+			if _, err := outboxRelay.Purge(time.Now().Add(-cfg.OutboxRetention)); err != nil {
+				log.Printf("Failed to purge outbox messages: %v", err)
+			}
+		}
+	}()
+
 	// Setup routes
-	route.SetupRoutes(router, userHandler, healthHandler, todoHandler)
+	route.SetupRoutes(router, userHandler, healthHandler, todoHandler, importHandler, calendarHandler, workflowHandler, customFieldHandler, dependencyHandler, timeEntryHandler, savedViewHandler, templateHandler, graphqlHandler, eventHandler, webhookHandler, docsHandler)
 	log.Println("Routes configured")
 
+	// Start the gRPC server next to the REST API
+	grpcServer := rpc.NewServer(todoService, authService)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	go func() {
+		log.Printf("gRPC server starting on :%s", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	// Start server
 	serverAddr := ":" + cfg.ServerPort
 	log.Printf("Server starting on %s", serverAddr)
@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 type Config struct {
 	DBHost     string
@@ -10,7 +14,20 @@ type Config struct {
 	DBName     string
 	JWTSecret  string
 	ServerPort string
+	GRPCPort   string
 	GinMode    string
+	// IdempotencyTTL is how long responses stored for Idempotency-Key are replayed
+	IdempotencyTTL time.Duration
+	// GraphQLMaxDepth and GraphQLMaxComplexity bound the queries /graphql runs
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+	// EventReplaySize is the number of todo events kept to resume streams
+	EventReplaySize int
+	// WebhookAllowPrivate lets webhooks reach loopback and private addresses
+	WebhookAllowPrivate bool
+	// OutboxRetention is how long published outbox messages are kept, and
+	// with them their dedup keys
+	OutboxRetention time.Duration
 }
 
 func LoadConfig() *Config {
@@ -22,7 +39,19 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "todolist_db"),
 		JWTSecret:  getEnv("JWT_SECRET", "your_super_secret_key"),
 		ServerPort: getEnv("SERVER_PORT", "8080"),
+		GRPCPort:   getEnv("GRPC_PORT", "9090"),
 		GinMode:    getEnv("GIN_MODE", "debug"),
+
+		IdempotencyTTL: getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+
+		GraphQLMaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+		GraphQLMaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 2500),
+
+		EventReplaySize: getEnvInt("EVENT_REPLAY_SIZE", 1000),
+
+		WebhookAllowPrivate: getEnvBool("WEBHOOK_ALLOW_PRIVATE", false),
+
+		OutboxRetention: getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
 	}
 }
 
@@ -33,3 +62,27 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
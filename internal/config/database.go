@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"rest-api/internal/model"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -20,12 +21,16 @@ type DatabaseConfig struct {
 
 func NewDatabase(cfg *Config) (*gorm.DB, error) {
 	// inisiasi koneksi
-	dsn := fmt.Sprintf("host = %s user = %s password = %s dbname = %s port = %s sslmode = disable, TimeZone = Asia/Jakarta",
+	// semua waktu disimpan dalam UTC, time zone user dipakai saat render
+	dsn := fmt.Sprintf("host = %s user = %s password = %s dbname = %s port = %s sslmode = disable TimeZone = UTC",
 		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
 
 	// koneksi database
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
 	})
 
 	if err != nil {
@@ -35,7 +40,7 @@ func NewDatabase(cfg *Config) (*gorm.DB, error) {
 	log.Println("Succesfully connected")
 
 	// auto migrate model later
-	if err := db.AutoMigrate(&model.User{}, &model.Todo{}); err != nil {
+	if err := db.AutoMigrate(&model.User{}, &model.Tag{}, &model.Todo{}, &model.IdempotencyKey{}, &model.ImportJob{}, &model.CalendarFeed{}, &model.Workflow{}, &model.CustomField{}, &model.TodoFieldValue{}, &model.TodoDependency{}, &model.TimeEntry{}, &model.SavedView{}, &model.TodoTemplate{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.OutboxMessage{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate the database: %w", err)
 	}
 
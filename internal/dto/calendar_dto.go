@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// CalendarFeedResponse untuk URL feed iCalendar milik user
+type CalendarFeedResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// ============================================
+// CUSTOM FIELD DTOs
+// ============================================
+
+// CreateCustomFieldRequest untuk membuat custom field
+type CreateCustomFieldRequest struct {
+	Key      string   `json:"key" binding:"required,max=30"` // dipakai di custom_fields dan filter cf.<key>
+	Name     string   `json:"name" binding:"required,max=50"`
+	Type     string   `json:"type" binding:"required,oneof=text number date select multi_select url user"`
+	Options  []string `json:"options"` // wajib untuk select dan multi_select
+	Required bool     `json:"required"`
+	Position int      `json:"position"`
+}
+
+// UpdateCustomFieldRequest untuk update custom field, key dan type tidak bisa diubah
+type UpdateCustomFieldRequest struct {
+	Name     *string   `json:"name" binding:"omitempty,max=50"`
+	Options  *[]string `json:"options"`
+	Required *bool     `json:"required"`
+	Position *int      `json:"position"`
+}
+
+// CustomFieldResponse untuk response custom field
+type CustomFieldResponse struct {
+	ID        uint      `json:"id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	Required  bool      `json:"required"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+// ============================================
+// DEPENDENCY DTOs
+// ============================================
+
+// AddDependencyRequest untuk menambah blocker ke todo
+type AddDependencyRequest struct {
+	BlockedByID uint `json:"blocked_by_id" binding:"required"` // todo yang harus selesai lebih dulu
+}
+
+// TodoRef untuk ringkasan todo di daftar dependency
+type TodoRef struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Done   bool   `json:"done"`
+}
+
+// DependenciesResponse untuk response dependency sebuah todo
+type DependenciesResponse struct {
+	TodoID    uint      `json:"todo_id"`
+	Blocked   bool      `json:"blocked"`
+	BlockedBy []TodoRef `json:"blocked_by"` // todo yang memblokir todo ini
+	Blocks    []TodoRef `json:"blocks"`     // todo yang diblokir todo ini
+}
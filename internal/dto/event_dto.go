@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// ============================================
+// EVENT DTOs
+// ============================================
+
+// TodoEvent untuk satu perubahan todo di stream events
+type TodoEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type" example:"todo.updated"` // todo.created, todo.updated, todo.deleted atau stream.reset
+	TodoID     uint      `json:"todo_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Todo berisi keadaan todo saat event dikirim, null untuk todo yang sudah dihapus
+	Todo *TodoResponse `json:"todo,omitempty"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+// ============================================
+// GRAPHQL DTOs
+// ============================================
+
+// GraphQLRequest untuk request GraphQL
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"` // wajib jika query berisi lebih dari satu operasi
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQLResponse untuk response GraphQL, data null jika query ditolak
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GraphQLError untuk satu error GraphQL
+type GraphQLError struct {
+	Message   string            `json:"message"`
+	Locations []GraphQLLocation `json:"locations,omitempty"`
+	Path      []interface{}     `json:"path,omitempty"`
+	// Extensions berisi code yang sama dengan problem response dan errors per field input
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLLocation untuk posisi error di dalam query
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
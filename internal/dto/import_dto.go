@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// ============================================
+// IMPORT REQUEST DTOs
+// ============================================
+
+// ImportTodoQuery untuk parameter import todo
+type ImportTodoQuery struct {
+	Format  string `form:"format" binding:"omitempty,oneof=csv json todoist trello"` // default dari Content-Type
+	Mapping string `form:"mapping"`                                                  // JSON: {"title":"Kolom CSV", ...}
+	DryRun  bool   `form:"dry_run"`
+	Async   bool   `form:"async"` // paksa jalan sebagai background job
+}
+
+// ============================================
+// IMPORT RESPONSE DTOs
+// ============================================
+
+// ImportRowError untuk error per baris import
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportResultResponse untuk hasil import yang dijalankan langsung
+type ImportResultResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Valid   int                 `json:"valid"`
+	Failed  int                 `json:"failed"`
+	Errors  []ImportRowError    `json:"errors"`
+	Preview []CreateTodoRequest `json:"preview,omitempty"` // hanya untuk dry run
+}
+
+// ImportJobResponse untuk status background job import
+type ImportJobResponse struct {
+	ID         uint             `json:"id"`
+	Format     string           `json:"format"`
+	Status     string           `json:"status"`
+	Total      int              `json:"total"`
+	Processed  int              `json:"processed"`
+	Created    int              `json:"created"`
+	Failed     int              `json:"failed"`
+	Progress   float64          `json:"progress"` // 0 - 100
+	Errors     []ImportRowError `json:"errors"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
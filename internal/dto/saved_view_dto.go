@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// ============================================
+// SAVED VIEW DTOs
+// ============================================
+
+// CreateSavedViewRequest untuk menyimpan filter list todos dengan nama
+type CreateSavedViewRequest struct {
+	Name   string        `json:"name" binding:"required,max=100"`
+	Filter TodoListQuery `json:"filter"`
+}
+
+// UpdateSavedViewRequest untuk update saved view
+type UpdateSavedViewRequest struct {
+	Name   *string        `json:"name" binding:"omitempty,max=100"`
+	Filter *TodoListQuery `json:"filter"`
+}
+
+// SavedViewResponse untuk response saved view atau view bawaan
+type SavedViewResponse struct {
+	ID        uint          `json:"id,omitempty"`
+	Key       string        `json:"key,omitempty"` // view bawaan: today, upcoming, overdue
+	Name      string        `json:"name"`
+	BuiltIn   bool          `json:"built_in"`
+	Filter    TodoListQuery `json:"filter"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	UpdatedAt *time.Time    `json:"updated_at,omitempty"`
+}
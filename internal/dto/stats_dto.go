@@ -0,0 +1,52 @@
+package dto
+
+// ============================================
+// STATS DTOs
+// ============================================
+
+// CompletionResponse untuk tingkat penyelesaian todo yang dibuat dalam rentang
+type CompletionResponse struct {
+	Created   int64   `json:"created"`
+	Completed int64   `json:"completed"` // dari todo yang dibuat, sudah selesai
+	Rate      float64 `json:"rate"`      // 0 sampai 1
+}
+
+// CycleTimeResponse untuk lama pengerjaan todo yang selesai dalam rentang
+type CycleTimeResponse struct {
+	Count          int64   `json:"count"`
+	AverageSeconds float64 `json:"average_seconds"`
+	MedianSeconds  float64 `json:"median_seconds"`
+}
+
+// DayCountResponse untuk jumlah todo selesai per hari
+type DayCountResponse struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+}
+
+// StreakResponse untuk rangkaian hari berturut-turut dengan todo selesai
+type StreakResponse struct {
+	Days     int64  `json:"days"`
+	StartDay string `json:"start_day,omitempty"`
+	EndDay   string `json:"end_day,omitempty"`
+}
+
+// StreaksResponse untuk streak saat ini dan terpanjang
+type StreaksResponse struct {
+	Current StreakResponse `json:"current"`
+	Longest StreakResponse `json:"longest"`
+}
+
+// StatsResponse untuk ringkasan produktivitas dalam rentang tanggal
+type StatsResponse struct {
+	From            string             `json:"from"`
+	To              string             `json:"to"`
+	Total           int64              `json:"total"`
+	ByStatus        map[string]int64   `json:"by_status"`
+	ByPriority      map[string]int64   `json:"by_priority"`
+	Overdue         int64              `json:"overdue"`
+	Completion      CompletionResponse `json:"completion"`
+	CycleTime       CycleTimeResponse  `json:"cycle_time"`
+	CompletedPerDay []DayCountResponse `json:"completed_per_day"`
+	Streaks         StreaksResponse    `json:"streaks"`
+}
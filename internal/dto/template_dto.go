@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// ============================================
+// TEMPLATE DTOs
+// ============================================
+
+// TemplateItem satu todo dalam template. Title dan description boleh berisi
+// placeholder {{nama}}, {{date}} diisi dengan base date.
+type TemplateItem struct {
+	Title           string   `json:"title" binding:"required,max=200"`
+	Description     string   `json:"description,omitempty"`
+	Priority        string   `json:"priority,omitempty" binding:"omitempty,oneof=low medium high"` // default: medium
+	Tags            []string `json:"tags,omitempty" binding:"omitempty,max=20"`
+	DueOffsetDays   *int     `json:"due_offset_days,omitempty" binding:"omitempty,min=-3650,max=3650"` // hari relatif terhadap base date
+	DueTime         string   `json:"due_time,omitempty"`                                               // HH:MM di time zone user, tanpa ini due date all day
+	Recurrence      string   `json:"recurrence,omitempty"`
+	EstimateMinutes *int     `json:"estimate_minutes,omitempty" binding:"omitempty,min=1,max=100000"`
+}
+
+// CreateTemplateRequest untuk membuat template, dari items dan/atau todo yang sudah ada
+type CreateTemplateRequest struct {
+	Name        string         `json:"name" binding:"required,max=100"`
+	Description string         `json:"description"`
+	TodoID      *uint          `json:"todo_id"` // todo yang disalin sebagai item pertama
+	Items       []TemplateItem `json:"items" binding:"max=100,dive"`
+}
+
+// UpdateTemplateRequest untuk update template
+type UpdateTemplateRequest struct {
+	Name        *string         `json:"name" binding:"omitempty,max=100"`
+	Description *string         `json:"description"`
+	Items       *[]TemplateItem `json:"items" binding:"omitempty,min=1,max=100,dive"`
+}
+
+// InstantiateTemplateRequest untuk membuat todo dari template
+type InstantiateTemplateRequest struct {
+	BaseDate string            `json:"base_date"` // YYYY-MM-DD, default: hari ini di time zone user
+	Values   map[string]string `json:"values"`    // nilai placeholder, contoh: {"version": "1.4"}
+}
+
+// TemplateResponse untuk response template
+type TemplateResponse struct {
+	ID           uint           `json:"id"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	Items        []TemplateItem `json:"items"`
+	Placeholders []string       `json:"placeholders"` // placeholder yang harus diisi saat instantiate
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// ============================================
+// TIME TRACKING DTOs
+// ============================================
+
+// StartTimerRequest untuk memulai timer pada todo
+type StartTimerRequest struct {
+	Note string `json:"note" binding:"max=200"`
+}
+
+// CreateTimeEntryRequest untuk mencatat waktu secara manual
+type CreateTimeEntryRequest struct {
+	StartedAt       string `json:"started_at"` // RFC 3339, default: berakhir sekarang
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1,max=1440"`
+	Note            string `json:"note" binding:"max=200"`
+}
+
+// TimeEntryResponse untuk response time entry
+type TimeEntryResponse struct {
+	ID              uint       `json:"id"`
+	TodoID          uint       `json:"todo_id"`
+	TodoTitle       string     `json:"todo_title"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	Running         bool       `json:"running"`
+	DurationSeconds int64      `json:"duration_seconds"` // untuk timer yang berjalan: waktu sejauh ini
+	Day             string     `json:"day"`              // tanggal mulai di time zone user
+	Note            string     `json:"note,omitempty"`
+}
+
+// TodoTimeResponse untuk total waktu per todo
+type TodoTimeResponse struct {
+	TodoID          uint   `json:"todo_id"`
+	Title           string `json:"title"`
+	Seconds         int64  `json:"seconds"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+}
+
+// DayTimeResponse untuk total waktu per hari
+type DayTimeResponse struct {
+	Day     string `json:"day"`
+	Seconds int64  `json:"seconds"`
+}
+
+// TimeSummaryResponse untuk ringkasan waktu dalam rentang tanggal
+type TimeSummaryResponse struct {
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	TotalSeconds int64              `json:"total_seconds"`
+	ByTodo       []TodoTimeResponse `json:"by_todo"`
+	ByDay        []DayTimeResponse  `json:"by_day"`
+}
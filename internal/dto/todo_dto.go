@@ -10,20 +10,30 @@ import (
 
 // CreateTodoRequest untuk membuat todo baru
 type CreateTodoRequest struct {
-	Title       string `json:"title" binding:"required,max=200"`
-	Description string `json:"description"`
-	Status      string `json:"status" binding:"required,oneof=pending in_progress completed"`
-	Priority    string `json:"priority" binding:"required,oneof=low medium high"`
-	DueDate     string `json:"due_date" binding:"omitempty"` // Format: YYYY-MM-DD
+	Title           string   `json:"title" binding:"required,max=200"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status" binding:"omitempty,max=20"` // status dari workflow, default: status awal workflow
+	Priority        string   `json:"priority" binding:"required,oneof=low medium high"`
+	DueDate         string   `json:"due_date" binding:"omitempty"` // Format: YYYY-MM-DD (all day) or RFC 3339 / YYYY-MM-DDTHH:MM in user time zone
+	Tags            []string `json:"tags" binding:"omitempty,max=20"`
+	Recurrence      string   `json:"recurrence"` // RRULE, contoh: FREQ=WEEKLY;BYDAY=MO
+	EstimateMinutes *int     `json:"estimate_minutes" binding:"omitempty,min=1,max=100000"`
+	// CustomFields berisi nilai custom field per key, contoh: {"estimate": 3}
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 // UpdateTodoRequest untuk update todo
 type UpdateTodoRequest struct {
-	Title       *string `json:"title" binding:"omitempty,max=200"`
-	Description *string `json:"description"`
-	Status      *string `json:"status" binding:"omitempty,oneof=pending in_progress completed"`
-	Priority    *string `json:"priority" binding:"omitempty,oneof=low medium high"`
-	DueDate     *string `json:"due_date"` // Format: YYYY-MM-DD or empty string to clear
+	Title           *string   `json:"title" binding:"omitempty,max=200"`
+	Description     *string   `json:"description"`
+	Status          *string   `json:"status" binding:"omitempty,max=20"` // harus transisi yang diizinkan workflow
+	Priority        *string   `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueDate         *string   `json:"due_date"` // Format: same as CreateTodoRequest or empty string to clear
+	Tags            *[]string `json:"tags" binding:"omitempty,max=20"`
+	Recurrence      *string   `json:"recurrence"`                                            // empty string untuk menghapus recurrence
+	EstimateMinutes *int      `json:"estimate_minutes" binding:"omitempty,min=0,max=100000"` // 0 untuk menghapus estimasi
+	// CustomFields hanya mengubah key yang dikirim, null untuk menghapus nilai
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 // TodoCreateRequest untuk backward compatibility (alias)
@@ -34,27 +44,58 @@ type TodoUpdateRequest = UpdateTodoRequest
 
 // TodoQueryParams untuk filter dan pagination
 type TodoQueryParams struct {
-	Status   string `form:"status" binding:"omitempty,oneof=pending in_progress completed"`
+	Status   string `form:"status" binding:"omitempty,max=20"`
 	Priority int    `form:"priority" binding:"omitempty,min=0,max=5"`
 	Page     int    `form:"page" binding:"omitempty,min=1"`
 	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
+// MoveTodoRequest untuk memindahkan todo di board, tanpa before_id/after_id todo pindah ke akhir
+type MoveTodoRequest struct {
+	BeforeID *uint   `json:"before_id"`                         // taruh tepat sebelum todo ini
+	AfterID  *uint   `json:"after_id"`                          // taruh tepat setelah todo ini
+	Status   *string `json:"status" binding:"omitempty,max=20"` // pindah kolom status, mengikuti aturan workflow
+}
+
+// TodoListQuery untuk filter dan sort list todos, juga definisi saved view
+type TodoListQuery struct {
+	Status   string            `json:"status,omitempty"`
+	Priority string            `json:"priority,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`                // todo harus memiliki semua tag
+	Due      string            `json:"due,omitempty"`                 // overdue, today, tomorrow, this_week, next_7_days, next_30_days atau none
+	Query    string            `json:"q,omitempty" binding:"max=200"` // teks pada judul atau deskripsi
+	Open     bool              `json:"open,omitempty"`                // hanya todo yang belum selesai
+	Sort     string            `json:"sort,omitempty"`                // kolom todo atau cf.<key>, prefix - untuk descending, default position
+	Fields   map[string]string `json:"custom_fields,omitempty"`       // filter custom field dari query cf.<key>=value
+}
+
 // ============================================
 // TODO RESPONSE DTOs
 // ============================================
 
 // TodoResponse untuk response todo
 type TodoResponse struct {
-	ID          uint       `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      string     `json:"status"`
-	Priority    string     `json:"priority"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	UserID      uint       `json:"user_id"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID              uint       `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`
+	Priority        string     `json:"priority"`
+	DueDate         *time.Time `json:"due_date,omitempty"` // dalam time zone user
+	DueAllDay       bool       `json:"due_all_day"`
+	DueToday        bool       `json:"due_today"`
+	Overdue         bool       `json:"overdue"`
+	Blocked         bool       `json:"blocked"` // masih ada blocker yang belum selesai
+	Tags            []string   `json:"tags"`
+	Recurrence      string     `json:"recurrence,omitempty"`
+	EstimateMinutes *int       `json:"estimate_minutes,omitempty"`
+	Position        string     `json:"position"` // urutan manual, dibandingkan sebagai string
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	// CustomFields berisi nilai custom field per key
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+	UserID       uint                   `json:"user_id"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
 }
 
 // TodoListResponse untuk response list todos dengan pagination
@@ -64,3 +105,96 @@ type TodoListResponse struct {
 	Page       int            `json:"page"`
 	Limit      int            `json:"limit"`
 }
+
+// ============================================
+// BULK TODO DTOs
+// ============================================
+
+// BulkTodoFilter untuk memilih todo pada operasi update_where / delete_where
+type BulkTodoFilter struct {
+	Status   string `json:"status"`
+	Priority string `json:"priority"`
+	Overdue  bool   `json:"overdue"` // due_date sudah lewat dan status belum completed
+}
+
+// BulkTodoOperation adalah satu operasi di dalam request bulk
+type BulkTodoOperation struct {
+	Op     string             `json:"op"` // create, update, delete, update_where, delete_where
+	ID     uint               `json:"id"`
+	Create *CreateTodoRequest `json:"create"`
+	Update *UpdateTodoRequest `json:"update"`
+	Filter *BulkTodoFilter    `json:"filter"`
+}
+
+// BulkTodoRequest untuk menjalankan banyak operasi todo dalam satu request
+type BulkTodoRequest struct {
+	Mode       string              `json:"mode" binding:"omitempty,oneof=transaction best_effort"` // default: transaction
+	Operations []BulkTodoOperation `json:"operations" binding:"required,min=1,max=1000"`
+}
+
+// BulkTodoResult untuk hasil per operasi bulk
+type BulkTodoResult struct {
+	Index    int           `json:"index"`
+	Op       string        `json:"op"`
+	Status   int           `json:"status"`
+	Affected int64         `json:"affected,omitempty"`
+	Data     *TodoResponse `json:"data,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Code     string        `json:"code,omitempty"` // kode error yang sama dengan problem response
+}
+
+// BulkTodoResponse untuk response operasi bulk
+type BulkTodoResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkTodoResult `json:"results"`
+}
+
+// ============================================
+// QUICK-ADD TODO DTOs
+// ============================================
+
+// QuickAddTodoRequest untuk membuat todo dari satu baris teks
+type QuickAddTodoRequest struct {
+	Text string `json:"text" binding:"required,max=500"` // contoh: "Send invoice tomorrow 5pm !high #finance every month"
+}
+
+// QuickAddTodoQuery untuk query parameter quick-add
+type QuickAddTodoQuery struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// QuickAddParsed untuk hasil parsing teks quick-add
+type QuickAddParsed struct {
+	Title      string     `json:"title"`
+	DueDate    *time.Time `json:"due_date,omitempty"` // dalam time zone user
+	DueAllDay  bool       `json:"due_all_day"`
+	Priority   string     `json:"priority"`
+	Tags       []string   `json:"tags"`
+	Recurrence string     `json:"recurrence,omitempty"`
+}
+
+// QuickAddTodoResponse untuk response quick-add
+type QuickAddTodoResponse struct {
+	Parsed QuickAddParsed `json:"parsed"`
+	Todo   *TodoResponse  `json:"todo,omitempty"` // kosong saat dry_run
+}
+
+// ============================================
+// BOARD DTOs
+// ============================================
+
+// BoardColumnResponse untuk satu kolom status di board
+type BoardColumnResponse struct {
+	Key      string         `json:"key"`
+	Name     string         `json:"name"`
+	Category string         `json:"category"`
+	Count    int            `json:"count"`
+	Todos    []TodoResponse `json:"todos"` // urut berdasarkan position
+}
+
+// BoardResponse untuk response board kanban
+type BoardResponse struct {
+	Columns []BoardColumnResponse `json:"columns"`
+}
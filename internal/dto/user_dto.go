@@ -9,6 +9,8 @@ type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email,max=100"`
 	Password string `json:"password" binding:"required,min=6"`
 	FullName string `json:"fullname" binding:"required,max=100"`
+	TimeZone string `json:"time_zone" binding:"omitempty,max=64"`   // IANA, default UTC
+	Locale   string `json:"locale" binding:"omitempty,oneof=en id"` // bahasa pesan, default dari Accept-Language
 }
 
 // Login
@@ -32,6 +34,8 @@ type ResetPasswordConfirmRequest struct {
 type UserUpdateRequest struct {
 	Email    string `json:"email" binding:"omitempty,email,max=100"`
 	FullName string `json:"full_name" binding:"omitempty,max=100"`
+	TimeZone string `json:"time_zone" binding:"omitempty,max=64"`   // IANA, e.g. Asia/Jakarta
+	Locale   string `json:"locale" binding:"omitempty,oneof=en id"` // bahasa pesan: en atau id
 }
 
 // DTO RESPONSE
@@ -41,6 +45,8 @@ type UserResponse struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	FullName  string    `json:"string"`
+	TimeZone  string    `json:"time_zone"`
+	Locale    string    `json:"locale"` // kosong berarti mengikuti Accept-Language
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -54,13 +60,27 @@ type LoginResponse struct {
 // Success Reponse
 type SuccessResponse struct {
 	Success bool        `json:"success"`
-	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"` // kode stabil, contoh: todo_created
+	Message string      `json:"message"`        // pesan dalam bahasa request
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// Error Reponse
-type ErrorResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   string `json:"error,omitempty"`
+// Problem Response (RFC 7807, dikirim sebagai application/problem+json)
+type Problem struct {
+	Type     string       `json:"type"`               // about:blank, jenis error dibedakan oleh code
+	Title    string       `json:"title"`              // teks status HTTP
+	Status   int          `json:"status"`             // status HTTP
+	Detail   string       `json:"detail,omitempty"`   // penjelasan dalam bahasa request
+	Instance string       `json:"instance,omitempty"` // path request
+	Code     string       `json:"code"`               // kode stabil untuk klien, contoh: todo_not_found
+	Errors   []FieldError `json:"errors,omitempty"`   // kesalahan per field dari validasi request
+}
+
+// Field Error
+type FieldError struct {
+	In      string                 `json:"in"`               // path, query, header, body atau argument (GraphQL)
+	Field   string                 `json:"field,omitempty"`  // nama JSON, contoh: items[0].title
+	Code    string                 `json:"code"`             // kode stabil, contoh: required, too_long
+	Params  map[string]interface{} `json:"params,omitempty"` // nilai dalam pesan, contoh: {"max": 200}
+	Message string                 `json:"message"`          // pesan dalam bahasa request
 }
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// ============================================
+// WEBHOOK DTOs
+// ============================================
+
+// CreateWebhookRequest untuk mendaftarkan webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048" example:"https://ci.example.com/hooks/todos"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=todo.created todo.updated todo.deleted"`
+	// Secret untuk tanda tangan HMAC, dibuat otomatis jika kosong
+	Secret string `json:"secret" binding:"omitempty,min=16,max=100"`
+	Active *bool  `json:"active"` // default true
+}
+
+// UpdateWebhookRequest untuk update webhook
+type UpdateWebhookRequest struct {
+	URL    *string   `json:"url" binding:"omitempty,url,max=2048"`
+	Events *[]string `json:"events" binding:"omitempty,min=1,dive,oneof=todo.created todo.updated todo.deleted"`
+	Secret *string   `json:"secret" binding:"omitempty,min=16,max=100"`
+	Active *bool     `json:"active"`
+}
+
+// WebhookResponse untuk response webhook
+type WebhookResponse struct {
+	ID     uint     `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	// Secret hanya dikirim saat webhook dibuat atau secret diganti
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse untuk satu pengiriman di log webhook
+type WebhookDeliveryResponse struct {
+	ID             uint       `json:"id"`
+	EventID        string     `json:"event_id"`
+	Event          string     `json:"event" example:"todo.created"`
+	Status         string     `json:"status" example:"pending"` // pending, succeeded atau dead
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	ResponseBody   string     `json:"response_body,omitempty"` // maksimal 1 KB pertama
+	Error          string     `json:"error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebhookPayload untuk body request yang dikirim ke webhook
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type" example:"todo.created"` // todo.created, todo.updated, todo.deleted atau ping
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookTodoData untuk data payload event todo
+type WebhookTodoData struct {
+	TodoID uint `json:"todo_id"`
+	// Todo berisi keadaan todo setelah perubahan, null untuk todo yang dihapus
+	Todo *TodoResponse `json:"todo"`
+}
+
+// WebhookPingData untuk data payload ping
+type WebhookPingData struct {
+	WebhookID uint `json:"webhook_id"`
+}
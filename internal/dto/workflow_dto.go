@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// ============================================
+// WORKFLOW DTOs
+// ============================================
+
+// WorkflowStatus untuk satu status di workflow
+type WorkflowStatus struct {
+	Key      string `json:"key" binding:"required,max=20"`
+	Name     string `json:"name" binding:"required,max=50"`
+	Category string `json:"category" binding:"required,oneof=todo in_progress done"`
+}
+
+// WorkflowRequest untuk mengganti workflow status
+type WorkflowRequest struct {
+	Statuses            []WorkflowStatus    `json:"statuses" binding:"required,min=1,max=20,dive"`
+	Transitions         map[string][]string `json:"transitions"` // status asal -> status tujuan yang diizinkan
+	Initial             string              `json:"initial" binding:"required"`
+	EnforceDependencies bool                `json:"enforce_dependencies"` // tolak status in_progress selama blocker belum selesai
+}
+
+// WorkflowResponse untuk response workflow status
+type WorkflowResponse struct {
+	Statuses            []WorkflowStatus    `json:"statuses"`
+	Transitions         map[string][]string `json:"transitions"`
+	Initial             string              `json:"initial"`
+	EnforceDependencies bool                `json:"enforce_dependencies"`
+	IsDefault           bool                `json:"is_default"`
+	UpdatedAt           *time.Time          `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,169 @@
+package event
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer is the number of events a subscriber may fall behind
+// before it is dropped and has to resume from the replay buffer
+const subscriptionBuffer = 64
+
+// Transport sends published events to every replica, including this one,
+// which hands them to Deliver
+type Transport interface {
+	Send(e Event) error
+}
+
+// Bus fans out todo change events to the subscriptions of their user and
+// keeps the latest events in a bounded replay buffer
+type Bus struct {
+	mu            sync.Mutex
+	replay        []Event
+	replayed      map[string]bool // IDs of the events in replay
+	next          int
+	full          bool
+	subscriptions map[*Subscription]struct{}
+	transport     Transport
+}
+
+// NewBus creates a bus replaying up to replaySize events
+func NewBus(replaySize int) *Bus {
+	if replaySize < 1 {
+		replaySize = 1
+	}
+	return &Bus{
+		replay:        make([]Event, replaySize),
+		replayed:      make(map[string]bool, replaySize),
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// UseTransport makes Publish send events through a transport instead of
+// delivering them in this process only
+func (b *Bus) UseTransport(t Transport) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.transport = t
+}
+
+// Publish sends an event to the subscribers, giving it an ID and time
+// unless it has them. When the transport fails the event is still
+// delivered locally.
+func (b *Bus) Publish(e Event) {
+	if e.ID == "" {
+		e.At = time.Now().UTC()
+		e.ID = NewID(e.At)
+	}
+
+	b.mu.Lock()
+	transport := b.transport
+	b.mu.Unlock()
+
+	if transport != nil {
+		err := transport.Send(e)
+		if err == nil {
+			return
+		}
+		log.Printf("Failed to send event %s to other replicas: %v", e.ID, err)
+	}
+	b.Deliver(e)
+}
+
+// Deliver adds an event to the replay buffer and passes it to the
+// subscriptions of its user. Events still in the replay buffer, published
+// again by the outbox relay, are dropped. Subscriptions that fell too far
+// behind are closed; their clients resume from the replay buffer.
+func (b *Bus) Deliver(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.replayed[e.ID] {
+		return
+	}
+	if b.full {
+		delete(b.replayed, b.replay[b.next].ID)
+	}
+	b.replay[b.next] = e
+	b.replayed[e.ID] = true
+	b.next = (b.next + 1) % len(b.replay)
+	if b.next == 0 {
+		b.full = true
+	}
+
+	for sub := range b.subscriptions {
+		if sub.userID != e.UserID {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe starts a subscription to the events of a user. With the ID of
+// the last event a client saw, the events of the user that followed it are
+// returned for replay; resumed is false when that event is no longer in the
+// replay buffer and the client has to reload instead.
+func (b *Bus) Subscribe(userID uint, lastEventID string) (sub *Subscription, replay []Event, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	resumed = lastEventID == ""
+	if !resumed {
+		for _, e := range b.buffered() {
+			if resumed && e.UserID == userID {
+				replay = append(replay, e)
+			}
+			if e.ID == lastEventID {
+				resumed = true
+			}
+		}
+	}
+	if !resumed {
+		replay = nil
+	}
+
+	sub = &Subscription{bus: b, userID: userID, events: make(chan Event, subscriptionBuffer)}
+	b.subscriptions[sub] = struct{}{}
+	return sub, replay, resumed
+}
+
+// buffered returns the replay buffer from the oldest to the newest event
+func (b *Bus) buffered() []Event {
+	if !b.full {
+		return b.replay[:b.next]
+	}
+	return append(append([]Event{}, b.replay[b.next:]...), b.replay[:b.next]...)
+}
+
+// remove ends a subscription, the caller holds the lock
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subscriptions[sub]; ok {
+		delete(b.subscriptions, sub)
+		close(sub.events)
+	}
+}
+
+// Subscription receives the events of one user
+type Subscription struct {
+	bus    *Bus
+	userID uint
+	events chan Event
+}
+
+// Events returns the events of the subscription. The channel is closed when
+// the subscription ends, also when it fell too far behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
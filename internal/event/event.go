@@ -0,0 +1,42 @@
+// Package event carries todo change events relayed from the outbox to the
+// clients streaming them. The bus keeps the latest events to replay after a
+// reconnect and, with several replicas, fans out through Postgres
+// LISTEN/NOTIFY so every replica sees the changes made on the others.
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Todo change event types
+const (
+	TodoCreated = "todo.created"
+	TodoUpdated = "todo.updated"
+	TodoDeleted = "todo.deleted"
+)
+
+// Event is a change of a todo. It only identifies the todo, so it fits in a
+// notification; the streams load the current todo when sending it.
+type Event struct {
+	ID     string    `json:"id"`
+	Type   string    `json:"type"`
+	UserID uint      `json:"user_id"`
+	TodoID uint      `json:"todo_id"`
+	At     time.Time `json:"at"`
+}
+
+// Publisher receives the change events relayed from the outbox
+type Publisher interface {
+	Publish(e Event)
+}
+
+// NewID returns an event ID that is unique across replicas, the time of the
+// event followed by random bytes
+func NewID(at time.Time) string {
+	random := make([]byte, 6)
+	rand.Read(random)
+	return strconv.FormatInt(at.UnixMilli(), 36) + "-" + hex.EncodeToString(random)
+}
@@ -0,0 +1,111 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// Channel is the Postgres notification channel of todo change events
+const Channel = "todo_events"
+
+// Waits between attempts to listen again: minListenBackoff after a lost
+// connection, doubling while listening keeps failing up to maxListenBackoff
+const (
+	minListenBackoff = time.Second
+	maxListenBackoff = 30 * time.Second
+)
+
+// PostgresTransport sends events to every replica with NOTIFY
+type PostgresTransport struct {
+	db *gorm.DB
+}
+
+// NewPostgresTransport creates a transport notifying through db
+func NewPostgresTransport(db *gorm.DB) *PostgresTransport {
+	return &PostgresTransport{db: db}
+}
+
+// Send notifies the listeners of all replicas of an event
+func (t *PostgresTransport) Send(e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return t.db.Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error
+}
+
+// Listen delivers the events notified on Channel to bus until ctx is done.
+// It holds one connection of the pool and listens again after the
+// connection is lost; events notified in between are not delivered, their
+// clients catch up once they reload.
+func Listen(ctx context.Context, db *gorm.DB, bus *Bus) {
+	backoff := minListenBackoff
+	for {
+		listened, err := listen(ctx, db, bus)
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection lost after listening is a new outage
+		if listened {
+			backoff = minListenBackoff
+		}
+		log.Printf("Listening for events failed, retrying in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxListenBackoff {
+			backoff = maxListenBackoff
+		}
+	}
+}
+
+// listen delivers notifications on one connection until it fails. It
+// reports whether LISTEN succeeded before the failure.
+func listen(ctx context.Context, db *gorm.DB, bus *Bus) (bool, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	listened := false
+	err = conn.Raw(func(driverConn interface{}) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("database driver does not support LISTEN")
+		}
+		pgConn := stdlibConn.Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+Channel); err != nil {
+			return err
+		}
+		listened = true
+		log.Printf("Listening for events on %s", Channel)
+
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			var e Event
+			if err := json.Unmarshal([]byte(notification.Payload), &e); err != nil {
+				log.Printf("Ignoring event notification %q: %v", notification.Payload, err)
+				continue
+			}
+			bus.Deliver(e)
+		}
+	})
+	return listened, err
+}
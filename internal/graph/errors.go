@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"log"
+	"strings"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+)
+
+// Codes of errors that only GraphQL requests return
+const (
+	CodeQueryTooDeep    = "query_too_deep"
+	CodeQueryTooComplex = "query_too_complex"
+)
+
+// Codes returns the codes of the GraphQL only errors, for catalog checks
+func Codes() []string {
+	return []string{CodeQueryTooDeep, CodeQueryTooComplex}
+}
+
+// Error is an error with the stable code of the matching REST problem in its
+// extensions, and the invalid input fields for validation errors
+type Error struct {
+	Code    string
+	Message string
+	Errors  []dto.FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions are added to the error in the response
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	if len(e.Errors) > 0 {
+		extensions["errors"] = e.Errors
+	}
+	return extensions
+}
+
+// newError creates an error with the message of code in the request locale
+func newError(r *request, code string, params map[string]interface{}) *Error {
+	return &Error{Code: code, Message: i18n.T(r.locale, "error."+code, params)}
+}
+
+// serviceError converts an error of a service. Unknown errors are logged and
+// described generically, like in REST responses.
+func serviceError(r *request, err error) error {
+	_, code := problem.Lookup(err)
+	if code == problem.CodeInternal {
+		log.Printf("GraphQL: %v", err)
+	}
+	return &Error{Code: code, Message: problem.DetailIn(r.locale, err)}
+}
+
+// inputError converts the validation error of an input argument, naming the
+// fields as in the schema
+func inputError(r *request, argument string, err error) error {
+	errs := problem.FieldErrors("argument", err)
+	for i := range errs {
+		field := camelCase(errs[i].Field)
+		errs[i].Field = argument
+		if field != "" {
+			errs[i].Field += "." + field
+		}
+	}
+	return validationError(r, errs)
+}
+
+// validationError creates a validation_failed error listing errs
+func validationError(r *request, errs []dto.FieldError) error {
+	for i := range errs {
+		errs[i].Message = problem.Message(r.locale, errs[i])
+	}
+	gqlErr := newError(r, problem.CodeValidationFailed, nil)
+	gqlErr.Errors = errs
+	return gqlErr
+}
+
+// camelCase converts a JSON field path such as items[0].due_date to the
+// names of the schema, items[0].dueDate
+func camelCase(path string) string {
+	parts := strings.Split(path, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,141 @@
+// Package graph serves the todo and user services over GraphQL. Resolvers
+// call the same services as the REST handlers so business rules stay in one
+// place. Related users and dependencies are fetched through per request
+// loaders that batch the lookups of a whole list, and queries deeper or more
+// complex than the configured limits are rejected before they run.
+package graph
+
+import (
+	"context"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Limits bound the queries the schema runs
+type Limits struct {
+	// MaxDepth is how deeply fields may be nested
+	MaxDepth int
+	// MaxComplexity is the highest estimated cost of a query: one per field,
+	// times the number of items of the lists it is selected in
+	MaxComplexity int
+}
+
+// Schema is the GraphQL schema of the API
+type Schema struct {
+	schema            graphql.Schema
+	limits            Limits
+	todoService       *service.TodoService
+	authService       *service.AuthService
+	dependencyService *service.DependencyService
+}
+
+// NewSchema creates the schema, resolving fields through the services
+func NewSchema(todoService *service.TodoService, authService *service.AuthService, dependencyService *service.DependencyService, limits Limits) (*Schema, error) {
+	s := &Schema{
+		limits:            limits,
+		todoService:       todoService,
+		authService:       authService,
+		dependencyService: dependencyService,
+	}
+
+	types := s.newTypes()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    s.queryType(types),
+		Mutation: s.mutationType(types),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// request is the state of one GraphQL request shared by its resolvers
+type request struct {
+	userID uint // 0 when the request is not authenticated
+	locale i18n.Locale
+	loc    *time.Location
+	users  *Loader[uint, *dto.UserResponse]
+	deps   *Loader[uint, dependencies]
+}
+
+// dependencies are the todos blocking a todo and the todos it blocks
+type dependencies struct {
+	blockers []model.Todo
+	blocked  []model.Todo
+}
+
+type requestKey struct{}
+
+// requestFrom returns the state of the request a resolver runs in
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// Execute parses, validates and runs a GraphQL request for userID, 0 when
+// the request is not authenticated. Messages are written in locale.
+func (s *Schema) Execute(ctx context.Context, userID uint, locale i18n.Locale, req dto.GraphQLRequest) dto.GraphQLResponse {
+	r := &request{userID: userID, locale: locale, loc: time.UTC}
+	if userID != 0 {
+		r.loc = s.todoService.UserLocation(userID)
+	}
+	r.users = NewLoader(s.authService.GetProfiles)
+	r.deps = NewLoader(func(todoIDs []uint) (map[uint]dependencies, error) {
+		blockers, blocked, err := s.dependencyService.GetDependenciesOf(todoIDs, userID)
+		if err != nil {
+			return nil, err
+		}
+		deps := make(map[uint]dependencies, len(todoIDs))
+		for _, id := range todoIDs {
+			deps[id] = dependencies{blockers: blockers[id], blocked: blocked[id]}
+		}
+		return deps, nil
+	})
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return response(nil, gqlerrors.FormatErrors(err))
+	}
+
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return response(nil, validation.Errors)
+	}
+
+	if err := checkLimits(r, doc, req.OperationName, req.Variables, s.limits); err != nil {
+		// Located errors keep the extensions of the error
+		return response(nil, gqlerrors.FormatErrors(graphql.NewLocatedError(err, nil)))
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, requestKey{}, r),
+	})
+	return response(result.Data, result.Errors)
+}
+
+// response converts the result of a request to its JSON body
+func response(data interface{}, errs []gqlerrors.FormattedError) dto.GraphQLResponse {
+	body := dto.GraphQLResponse{Data: data}
+	for _, err := range errs {
+		gqlErr := dto.GraphQLError{Message: err.Message, Path: err.Path, Extensions: err.Extensions}
+		for _, loc := range err.Locations {
+			gqlErr.Locations = append(gqlErr.Locations, dto.GraphQLLocation{Line: loc.Line, Column: loc.Column})
+		}
+		body.Errors = append(body.Errors, gqlErr)
+	}
+	return body
+}
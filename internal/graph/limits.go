@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// assumedListSizes are the item counts of the lists that have no limit
+// argument, used to estimate the complexity of a query
+var assumedListSizes = map[string]int{
+	"todos":     defaultPageSize,
+	"blockedBy": 10,
+	"blocking":  10,
+}
+
+// checkLimits rejects an operation nested deeper or estimated to cost more
+// than limits allow. A zero limit is not checked.
+func checkLimits(r *request, doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	w := walker{variables: variables, fragments: make(map[string]*ast.FragmentDefinition)}
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		case *ast.FragmentDefinition:
+			w.fragments[definition.Name.Value] = definition
+		}
+	}
+	// The executor reports a missing or ambiguous operation
+	if len(operations) != 1 {
+		return nil
+	}
+
+	complexity, depth := w.selectionSet(operations[0].SelectionSet)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return newError(r, CodeQueryTooDeep, map[string]interface{}{"depth": depth, "max": limits.MaxDepth})
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return newError(r, CodeQueryTooComplex, map[string]interface{}{"complexity": complexity, "max": limits.MaxComplexity})
+	}
+	return nil
+}
+
+// walker measures the selections of an operation
+type walker struct {
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// selectionSet returns the complexity and the depth of a selection set.
+// Each field costs one, plus the cost of its selections times the size of
+// the list it returns.
+func (w *walker) selectionSet(set *ast.SelectionSet) (complexity, depth int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var cost, level int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			// Introspection is cheap and does not touch the database
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			childCost, childDepth := w.selectionSet(selection.SelectionSet)
+			cost = add(1, multiply(w.listSize(selection), childCost))
+			level = 1 + childDepth
+		case *ast.InlineFragment:
+			cost, level = w.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			// Validation has rejected unknown and cyclic fragments
+			if fragment, ok := w.fragments[selection.Name.Value]; ok {
+				cost, level = w.selectionSet(fragment.SelectionSet)
+			}
+		}
+		complexity = add(complexity, cost)
+		depth = max(depth, level)
+	}
+	return complexity, depth
+}
+
+// listSize returns the limit argument of a field, or the assumed size of
+// the list it returns
+func (w *walker) listSize(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			// Variables decoded from JSON are float64
+			switch n := w.variables[value.Name.Value].(type) {
+			case float64:
+				if n > 0 && n < math.MaxInt32 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+	}
+	if size, ok := assumedListSizes[field.Name.Value]; ok {
+		return size
+	}
+	return 1
+}
+
+// add and multiply saturate at the largest int instead of overflowing
+func add(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func multiply(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
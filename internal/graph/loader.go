@@ -0,0 +1,77 @@
+package graph
+
+import "sync"
+
+// Loader batches the lookups of one kind of related data within a request.
+// Load queues a key and returns a thunk. The executor resolves every field
+// of a level before it runs the thunks, so the first thunk fetches the keys
+// of the whole level at once and the others read the cached result.
+type Loader[K comparable, V any] struct {
+	fetch  func(keys []K) (map[K]V, error)
+	mu     sync.Mutex
+	queue  []K
+	queued map[K]bool
+	values map[K]V
+	errs   map[K]error
+	loaded map[K]bool
+}
+
+// NewLoader creates a loader fetching values with fetch. Keys missing from
+// the map fetch returns load the zero value.
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+		loaded: make(map[K]bool),
+	}
+}
+
+// Load queues key and returns a thunk that returns its value
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.loaded[key] && !l.queued[key] {
+		l.queued[key] = true
+		l.queue = append(l.queue, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !l.loaded[key] {
+			l.flush()
+		}
+		return l.values[key], l.errs[key]
+	}
+}
+
+// flush fetches every queued key
+func (l *Loader[K, V]) flush() {
+	keys := l.queue
+	l.queue = nil
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		delete(l.queued, key)
+		l.loaded[key] = true
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.values[key] = values[key]
+	}
+}
+
+// thunk adapts a loader thunk to the signature the executor expects. The
+// executor drops the extensions of errors returned by thunks but keeps those
+// of panics it recovers, so errors are converted and raised by panicking.
+func thunk[V any](r *request, load func() (V, error), convert func(V) interface{}) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			panic(serviceError(r, err))
+		}
+		return convert(value), nil
+	}
+}
@@ -0,0 +1,273 @@
+package graph
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/problem"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graphql-go/graphql"
+)
+
+// queryType creates the root query type
+func (s *Schema) queryType(t *types) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type:        t.user,
+				Description: "The authenticated user",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					return thunk(r, r.users.Load(r.userID), func(user *dto.UserResponse) interface{} {
+						if user == nil {
+							return nil
+						}
+						return user
+					}), nil
+				},
+			},
+			"todo": &graphql.Field{
+				Type: t.todo,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					todoID, err := parseID(r, p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					todo, err := s.todoService.GetTodoByID(todoID, r.userID)
+					if err != nil {
+						return nil, serviceError(r, err)
+					}
+					return todo, nil
+				},
+			},
+			"todos": &graphql.Field{
+				Type:        t.connection,
+				Description: "Todos of the authenticated user, in the order of filter.sort",
+				Args:        pageArgs(t),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					return s.resolveTodos(p, r.userID)
+				},
+			},
+		},
+	})
+}
+
+// mutationType creates the root mutation type
+func (s *Schema) mutationType(t *types) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTodo": &graphql.Field{
+				Type: graphql.NewNonNull(t.todo),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(t.createTodo)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					var req dto.CreateTodoRequest
+					if err := decodeInput(r, p.Args["input"], &req); err != nil {
+						return nil, err
+					}
+					todo, err := s.todoService.CreateTodo(r.userID, req)
+					if err != nil {
+						return nil, serviceError(r, err)
+					}
+					return todo, nil
+				},
+			},
+			"updateTodo": &graphql.Field{
+				Type: graphql.NewNonNull(t.todo),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(t.updateTodo)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					todoID, err := parseID(r, p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					var req dto.UpdateTodoRequest
+					if err := decodeInput(r, p.Args["input"], &req); err != nil {
+						return nil, err
+					}
+					todo, err := s.todoService.UpdateTodo(todoID, r.userID, req)
+					if err != nil {
+						return nil, serviceError(r, err)
+					}
+					return todo, nil
+				},
+			},
+			"deleteTodo": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					todoID, err := parseID(r, p.Args["id"])
+					if err != nil {
+						return nil, err
+					}
+					if err := s.todoService.DeleteTodo(todoID, r.userID); err != nil {
+						return nil, serviceError(r, err)
+					}
+					return true, nil
+				},
+			},
+			"updateProfile": &graphql.Field{
+				Type: graphql.NewNonNull(t.user),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(t.updateProfile)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r, err := authenticated(p)
+					if err != nil {
+						return nil, err
+					}
+					var req dto.UserUpdateRequest
+					if err := decodeInput(r, p.Args["input"], &req); err != nil {
+						return nil, err
+					}
+					user, err := s.authService.UpdateProfile(r.userID, req)
+					if err != nil {
+						return nil, serviceError(r, err)
+					}
+					return user, nil
+				},
+			},
+		},
+	})
+}
+
+// resolveTodos resolves a page of the todos of userID
+func (s *Schema) resolveTodos(p graphql.ResolveParams, userID uint) (interface{}, error) {
+	r := requestFrom(p.Context)
+	limit, _ := p.Args["limit"].(int)
+	offset, _ := p.Args["offset"].(int)
+
+	var errs []dto.FieldError
+	if limit < 1 {
+		errs = append(errs, dto.FieldError{In: "argument", Field: "limit", Code: problem.FieldTooSmall, Params: map[string]interface{}{"min": 1}})
+	} else if limit > maxPageSize {
+		errs = append(errs, dto.FieldError{In: "argument", Field: "limit", Code: problem.FieldTooLarge, Params: map[string]interface{}{"max": maxPageSize}})
+	}
+	if offset < 0 {
+		errs = append(errs, dto.FieldError{In: "argument", Field: "offset", Code: problem.FieldTooSmall, Params: map[string]interface{}{"min": 0}})
+	}
+	if len(errs) > 0 {
+		return nil, validationError(r, errs)
+	}
+
+	var query dto.TodoListQuery
+	if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+		query.Status, _ = filter["status"].(string)
+		query.Priority, _ = filter["priority"].(string)
+		query.Due, _ = filter["due"].(string)
+		query.Query, _ = filter["q"].(string)
+		query.Open, _ = filter["open"].(bool)
+		query.Sort, _ = filter["sort"].(string)
+		if tags, ok := filter["tags"].([]interface{}); ok {
+			for _, tag := range tags {
+				if tag, ok := tag.(string); ok {
+					query.Tags = append(query.Tags, tag)
+				}
+			}
+		}
+	}
+
+	todos, total, err := s.todoService.ListTodosPage(userID, query, limit, offset)
+	if err != nil {
+		return nil, serviceError(r, err)
+	}
+	return &connection{
+		TotalCount:  int(total),
+		Nodes:       todoRefs(todos),
+		HasNextPage: int64(offset+len(todos)) < total,
+	}, nil
+}
+
+// authenticated returns the request state, or an unauthorized error when
+// the request has no valid token
+func authenticated(p graphql.ResolveParams) (*request, error) {
+	r := requestFrom(p.Context)
+	if r.userID == 0 {
+		return nil, newError(r, problem.CodeUnauthorized, nil)
+	}
+	return r, nil
+}
+
+// parseID parses an ID argument
+func parseID(r *request, value interface{}) (uint, error) {
+	text, _ := value.(string)
+	id, err := strconv.ParseUint(text, 10, 0)
+	if err != nil || id == 0 {
+		return 0, newError(r, problem.CodeInvalidID, nil)
+	}
+	return uint(id), nil
+}
+
+// decodeInput fills req with an input argument and validates it like the
+// REST handlers bind a body. The input fields are the camelCase names of
+// the JSON fields of req.
+func decodeInput(r *request, input interface{}, req interface{}) error {
+	fields, _ := input.(map[string]interface{})
+	body := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		body[snakeCase(name)] = value
+	}
+
+	data, err := json.Marshal(body)
+	if err == nil {
+		err = json.Unmarshal(data, req)
+	}
+	if err == nil {
+		err = binding.Validator.ValidateStruct(req)
+	}
+	if err != nil {
+		return inputError(r, "input", err)
+	}
+	return nil
+}
+
+// snakeCase converts a schema field name such as dueDate to its JSON name,
+// due_date
+func snakeCase(name string) string {
+	var b strings.Builder
+	for _, c := range name {
+		if unicode.IsUpper(c) {
+			b.WriteByte('_')
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,294 @@
+package graph
+
+import (
+	"strconv"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Page sizes of todo lists
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// types are the named types of the schema
+type types struct {
+	user       *graphql.Object
+	todo       *graphql.Object
+	connection *graphql.Object
+
+	todoFilter    *graphql.InputObject
+	createTodo    *graphql.InputObject
+	updateTodo    *graphql.InputObject
+	updateProfile *graphql.InputObject
+}
+
+// connection is one page of todos
+type connection struct {
+	TotalCount  int
+	Nodes       []*model.Todo
+	HasNextPage bool
+}
+
+// jsonScalar holds values of any JSON type, used for custom field values
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Any JSON value",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: literal,
+})
+
+// literal converts a value written in the query to its JSON value
+func literal(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.StringValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	case *ast.IntValue:
+		n, err := strconv.ParseInt(value.Value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.FloatValue:
+		n, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.ListValue:
+		list := make([]interface{}, len(value.Values))
+		for i, item := range value.Values {
+			list[i] = literal(item)
+		}
+		return list
+	case *ast.ObjectValue:
+		object := make(map[string]interface{}, len(value.Fields))
+		for _, field := range value.Fields {
+			object[field.Name.Value] = literal(field.Value)
+		}
+		return object
+	}
+	return nil
+}
+
+// priorityEnum maps the priorities to GraphQL enum values
+var priorityEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Priority",
+	Values: graphql.EnumValueConfigMap{
+		"LOW":    &graphql.EnumValueConfig{Value: "low"},
+		"MEDIUM": &graphql.EnumValueConfig{Value: "medium"},
+		"HIGH":   &graphql.EnumValueConfig{Value: "high"},
+	},
+})
+
+// newTypes creates the object and input types
+func (s *Schema) newTypes() *types {
+	t := &types{}
+
+	t.user = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return formatID(p.Source.(*dto.UserResponse).ID), nil
+				}},
+				"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"fullName":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"timeZone":  &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "IANA time zone, empty for UTC"},
+				"locale":    &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Language of messages, empty to follow Accept-Language"},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"todos": &graphql.Field{
+					Type:        graphql.NewNonNull(t.connection),
+					Description: "Todos of the user, in the order of filter.sort",
+					Args:        pageArgs(t),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return s.resolveTodos(p, p.Source.(*dto.UserResponse).ID)
+					},
+				},
+			}
+		}),
+	})
+
+	t.todo = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Todo",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return formatID(p.Source.(*model.Todo).ID), nil
+				}},
+				"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"status":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Status key of the user's workflow"},
+				"priority":    &graphql.Field{Type: graphql.NewNonNull(priorityEnum)},
+				"dueDate": &graphql.Field{Type: graphql.DateTime, Description: "Due date in the user's time zone", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					todo := p.Source.(*model.Todo)
+					if todo.DueDate == nil {
+						return nil, nil
+					}
+					return service.DueDateIn(todo, requestFrom(p.Context).loc), nil
+				}},
+				"dueAllDay": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"dueToday": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.IsDueToday(p.Source.(*model.Todo), time.Now(), requestFrom(p.Context).loc), nil
+				}},
+				"overdue": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.IsOverdue(p.Source.(*model.Todo), time.Now(), requestFrom(p.Context).loc), nil
+				}},
+				"blocked": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Description: "Whether an unfinished todo blocks the todo"},
+				"tags": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					todo := p.Source.(*model.Todo)
+					tags := make([]string, len(todo.Tags))
+					for i, tag := range todo.Tags {
+						tags[i] = tag.Name
+					}
+					return tags, nil
+				}},
+				"recurrence": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "RRULE, empty when the todo does not repeat"},
+				"estimateMinutes": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if minutes := p.Source.(*model.Todo).EstimateMinutes; minutes != nil {
+						return *minutes, nil
+					}
+					return nil, nil
+				}},
+				"position":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"startedAt":   &graphql.Field{Type: graphql.DateTime},
+				"completedAt": &graphql.Field{Type: graphql.DateTime},
+				"customFields": &graphql.Field{Type: graphql.NewNonNull(jsonScalar), Description: "Custom field values by key", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					todo := p.Source.(*model.Todo)
+					fields := make(map[string]interface{}, len(todo.FieldValues))
+					for i := range todo.FieldValues {
+						if field := todo.FieldValues[i].Field; field != nil {
+							fields[field.Key] = service.FieldValueJSON(&todo.FieldValues[i])
+						}
+					}
+					return fields, nil
+				}},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"owner": &graphql.Field{Type: graphql.NewNonNull(t.user), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := requestFrom(p.Context)
+					return thunk(r, r.users.Load(p.Source.(*model.Todo).UserID), func(user *dto.UserResponse) interface{} {
+						if user == nil {
+							return nil
+						}
+						return user
+					}), nil
+				}},
+				"blockedBy": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.todo))), Description: "Todos that must be finished first", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := requestFrom(p.Context)
+					return thunk(r, r.deps.Load(p.Source.(*model.Todo).ID), func(deps dependencies) interface{} {
+						return todoRefs(deps.blockers)
+					}), nil
+				}},
+				"blocking": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.todo))), Description: "Todos waiting for this todo", Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := requestFrom(p.Context)
+					return thunk(r, r.deps.Load(p.Source.(*model.Todo).ID), func(deps dependencies) interface{} {
+						return todoRefs(deps.blocked)
+					}), nil
+				}},
+			}
+		}),
+	})
+
+	t.connection = graphql.NewObject(graphql.ObjectConfig{
+		Name: "TodoConnection",
+		Fields: graphql.Fields{
+			"totalCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Number of todos on all pages"},
+			"nodes":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.todo)))},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	t.todoFilter = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TodoFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"status":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"priority": &graphql.InputObjectFieldConfig{Type: priorityEnum},
+			"tags":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Todos must have all the tags"},
+			"due":      &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "overdue, today, tomorrow, this_week, next_7_days, next_30_days or none"},
+			"q":        &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Text in the title or description"},
+			"open":     &graphql.InputObjectFieldConfig{Type: graphql.Boolean, Description: "Only unfinished todos"},
+			"sort":     &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Todo column or cf.<key>, prefixed with - for descending order"},
+		},
+	})
+
+	t.createTodo = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateTodoInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":           &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"status":          &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Defaults to the initial status of the workflow"},
+			"priority":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(priorityEnum)},
+			"dueDate":         &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "YYYY-MM-DD, or an RFC 3339 date-time"},
+			"tags":            &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"recurrence":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"estimateMinutes": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"customFields":    &graphql.InputObjectFieldConfig{Type: jsonScalar},
+		},
+	})
+
+	t.updateTodo = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateTodoInput",
+		Description: "Only the given fields are changed",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"description":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"status":          &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Must be a transition allowed by the workflow"},
+			"priority":        &graphql.InputObjectFieldConfig{Type: priorityEnum},
+			"dueDate":         &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Empty to clear the due date"},
+			"tags":            &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"recurrence":      &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Empty to stop repeating"},
+			"estimateMinutes": &graphql.InputObjectFieldConfig{Type: graphql.Int, Description: "0 to clear the estimate"},
+			"customFields":    &graphql.InputObjectFieldConfig{Type: jsonScalar, Description: "Only the given keys are changed, null clears a value"},
+		},
+	})
+
+	t.updateProfile = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdateProfileInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"email":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"fullName": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"timeZone": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "IANA time zone, e.g. Asia/Jakarta"},
+			"locale":   &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "en or id"},
+		},
+	})
+
+	return t
+}
+
+// pageArgs are the arguments of the todo lists
+func pageArgs(t *types) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"filter": &graphql.ArgumentConfig{Type: t.todoFilter},
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize, Description: "At most 100"},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+}
+
+// formatID renders a database ID as a GraphQL ID
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// todoRefs returns pointers to todos, the source type of Todo fields
+func todoRefs(todos []model.Todo) []*model.Todo {
+	refs := make([]*model.Todo, len(todos))
+	for i := range todos {
+		refs[i] = &todos[i]
+	}
+	return refs
+}
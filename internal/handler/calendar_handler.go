@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler handles iCalendar feed HTTP requests
+type CalendarHandler struct {
+	calendarService *service.CalendarService
+}
+
+// NewCalendarHandler creates a new calendar handler instance
+func NewCalendarHandler(calendarService *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetFeed handles GET /api/v1/calendar/feed
+// @Summary Get calendar feed URL
+// @Description Get the secret iCalendar feed URL of the authenticated user, creating it on first use
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.CalendarFeedResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/calendar/feed [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	feed, err := h.calendarService.GetOrCreateFeed(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to get calendar feed")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "calendar_feed_retrieved", toCalendarFeedResponse(c, feed)))
+}
+
+// RegenerateFeed handles POST /api/v1/calendar/feed/regenerate
+// @Summary Regenerate calendar feed URL
+// @Description Replace the secret token of the feed; the previous URL stops working
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.CalendarFeedResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/calendar/feed/regenerate [post]
+// @Security BearerAuth
+func (h *CalendarHandler) RegenerateFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	feed, err := h.calendarService.RegenerateFeed(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to regenerate calendar feed")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "calendar_feed_regenerated", toCalendarFeedResponse(c, feed)))
+}
+
+// Feed handles GET /api/v1/calendar/:token.ics
+// @Summary iCalendar feed
+// @Description Todos with a due date as iCalendar, authenticated by the secret token in the URL
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token followed by .ics"
+// @Param type query string false "Components to render (event, todo, both)" default(event)
+// @Param status query string false "Filter by workflow status (default workflow: pending, in_progress, completed)"
+// @Param priority query string false "Filter by priority (low, medium, high)"
+// @Success 200 {string} string
+// @Failure 400 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/calendar/{token} [get]
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.calendarService.RenderFeed(token, c.Query("type"), c.Query("status"), c.Query("priority"))
+	if err != nil {
+		problem.Error(c, err, "Failed to render calendar feed")
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
+
+// toCalendarFeedResponse converts a feed to its response DTO with an absolute URL
+func toCalendarFeedResponse(c *gin.Context, feed *model.CalendarFeed) dto.CalendarFeedResponse {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return dto.CalendarFeedResponse{
+		Token:     feed.Token,
+		URL:       scheme + "://" + c.Request.Host + "/api/v1/calendar/" + feed.Token + ".ics",
+		CreatedAt: feed.CreatedAt,
+		UpdatedAt: feed.UpdatedAt,
+	}
+}
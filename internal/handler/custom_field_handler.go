@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomFieldHandler handles custom field definition HTTP requests
+type CustomFieldHandler struct {
+	fieldService *service.CustomFieldService
+}
+
+// NewCustomFieldHandler creates a new custom field handler instance
+func NewCustomFieldHandler(fieldService *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		fieldService: fieldService,
+	}
+}
+
+// List handles GET /api/v1/custom-fields
+// @Summary List custom fields
+// @Description List the custom fields todos of the authenticated user's workspace can fill in
+// @Tags custom-fields
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.CustomFieldResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/custom-fields [get]
+// @Security BearerAuth
+func (h *CustomFieldHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	fields, err := h.fieldService.ListFields(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve custom fields")
+		return
+	}
+
+	responses := make([]dto.CustomFieldResponse, len(fields))
+	for i := range fields {
+		responses[i] = toCustomFieldResponse(&fields[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "custom_fields_retrieved", responses))
+}
+
+// Create handles POST /api/v1/custom-fields
+// @Summary Create a custom field
+// @Description Define a typed field (text, number, date, select, multi_select, url or user) that todos can set under custom_fields.<key>
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param field body dto.CreateCustomFieldRequest true "Custom field definition"
+// @Success 201 {object} dto.SuccessResponse{data=dto.CustomFieldResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/custom-fields [post]
+// @Security BearerAuth
+func (h *CustomFieldHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.CreateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	field, err := h.fieldService.CreateField(userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to create custom field")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "custom_field_created", toCustomFieldResponse(field)))
+}
+
+// Update handles PUT /api/v1/custom-fields/:id
+// @Summary Update a custom field
+// @Description Change the name, options, required flag or position of a custom field. The key and type cannot change.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Param field body dto.UpdateCustomFieldRequest true "Custom field changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomFieldResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/custom-fields/{id} [put]
+// @Security BearerAuth
+func (h *CustomFieldHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	fieldID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	var req dto.UpdateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	field, err := h.fieldService.UpdateField(uint(fieldID), userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to update custom field")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "custom_field_updated", toCustomFieldResponse(field)))
+}
+
+// Delete handles DELETE /api/v1/custom-fields/:id
+// @Summary Delete a custom field
+// @Description Delete a custom field together with its values on all todos
+// @Tags custom-fields
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/custom-fields/{id} [delete]
+// @Security BearerAuth
+func (h *CustomFieldHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	fieldID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	if err := h.fieldService.DeleteField(uint(fieldID), userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to delete custom field")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "custom_field_deleted", nil))
+}
+
+// toCustomFieldResponse converts a custom field to its response DTO
+func toCustomFieldResponse(field *model.CustomField) dto.CustomFieldResponse {
+	response := dto.CustomFieldResponse{
+		ID:        field.ID,
+		Key:       field.Key,
+		Name:      field.Name,
+		Type:      field.Type,
+		Required:  field.Required,
+		Position:  field.Position,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+	if field.Options != "" {
+		response.Options = service.FieldOptions(field)
+	}
+	return response
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DependencyHandler handles todo dependency HTTP requests
+type DependencyHandler struct {
+	dependencyService *service.DependencyService
+	todoService       *service.TodoService
+}
+
+// NewDependencyHandler creates a new dependency handler instance
+func NewDependencyHandler(dependencyService *service.DependencyService, todoService *service.TodoService) *DependencyHandler {
+	return &DependencyHandler{
+		dependencyService: dependencyService,
+		todoService:       todoService,
+	}
+}
+
+// List handles GET /api/v1/todos/:id/dependencies
+// @Summary List todo dependencies
+// @Description List the todos blocking a todo and the todos it blocks
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DependenciesResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/dependencies [get]
+// @Security BearerAuth
+func (h *DependencyHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	blockers, blocked, err := h.dependencyService.GetDependencies(todoID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve dependencies")
+		return
+	}
+
+	response := dto.DependenciesResponse{
+		TodoID:    todoID,
+		BlockedBy: make([]dto.TodoRef, len(blockers)),
+		Blocks:    make([]dto.TodoRef, len(blocked)),
+	}
+	for i := range blockers {
+		response.BlockedBy[i] = toTodoRef(&blockers[i])
+		response.Blocked = response.Blocked || !response.BlockedBy[i].Done
+	}
+	for i := range blocked {
+		response.Blocks[i] = toTodoRef(&blocked[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "dependencies_retrieved", response))
+}
+
+// Add handles POST /api/v1/todos/:id/dependencies
+// @Summary Add a todo dependency
+// @Description Record that the todo cannot start before blocked_by_id is finished. Links that would create a cycle are rejected.
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param dependency body dto.AddDependencyRequest true "Blocking todo"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/dependencies [post]
+// @Security BearerAuth
+func (h *DependencyHandler) Add(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AddDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	if err := h.dependencyService.AddDependency(todoID, req.BlockedByID, userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to add dependency")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "dependency_added", nil))
+}
+
+// Remove handles DELETE /api/v1/todos/:id/dependencies/:blocker_id
+// @Summary Remove a todo dependency
+// @Description Remove the dependency of a todo on a blocking todo
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param blocker_id path int true "Blocking todo ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/dependencies/{blocker_id} [delete]
+// @Security BearerAuth
+func (h *DependencyHandler) Remove(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+	blockerID, ok := parseTodoID(c, "blocker_id")
+	if !ok {
+		return
+	}
+
+	if err := h.dependencyService.RemoveDependency(todoID, blockerID, userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to remove dependency")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "dependency_removed", nil))
+}
+
+// Next handles GET /api/v1/todos/next
+// @Summary What to work on next
+// @Description List open todos in dependency order: every todo comes after its open blockers, ties are broken by priority and due date. ready=true returns only the todos that are not blocked.
+// @Tags dependencies
+// @Produce json
+// @Param ready query bool false "Only todos without open blockers"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TodoResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/next [get]
+// @Security BearerAuth
+func (h *DependencyHandler) Next(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	readyOnly, _ := strconv.ParseBool(c.Query("ready"))
+
+	todos, err := h.dependencyService.NextTodos(userID.(uint), readyOnly)
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve next todos")
+		return
+	}
+
+	responses := make([]dto.TodoResponse, len(todos))
+	loc := h.todoService.UserLocation(userID.(uint))
+	for i := range todos {
+		responses[i] = service.ToTodoResponse(&todos[i], loc)
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "next_todos_retrieved", responses))
+}
+
+// parseTodoID parses a todo ID path parameter, writing a 400 response when invalid
+func parseTodoID(c *gin.Context, param string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// toTodoRef converts a todo to its summary DTO
+func toTodoRef(todo *model.Todo) dto.TodoRef {
+	return dto.TodoRef{
+		ID:     todo.ID,
+		Title:  todo.Title,
+		Status: todo.Status,
+		Done:   todo.CompletedAt != nil,
+	}
+}
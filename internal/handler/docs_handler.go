@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"rest-api/internal/openapi"
+	"rest-api/internal/problem"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// docsInitializer points the embedded Swagger UI at our document
+const docsInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// DocsHandler serves the OpenAPI document of the API and its docs UI
+type DocsHandler struct {
+	router *gin.Engine
+
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// NewDocsHandler creates a new docs handler instance, documenting the routes of router
+func NewDocsHandler(router *gin.Engine) *DocsHandler {
+	return &DocsHandler{router: router}
+}
+
+// OpenAPI handles GET /openapi.json
+// @Summary OpenAPI document
+// @Description The OpenAPI 3.1 document of this API, generated from the route table and the DTO types
+// @Tags docs
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} dto.Problem
+// @Router /openapi.json [get]
+func (h *DocsHandler) OpenAPI(c *gin.Context) {
+	// Routes are complete once the server handles requests
+	h.once.Do(func() {
+		h.spec, h.err = json.Marshal(openapi.Build(h.router.Routes()))
+	})
+	if h.err != nil {
+		problem.Error(c, h.err, "Failed to build OpenAPI document")
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// Docs handles GET /docs/*filepath
+// @Summary API docs
+// @Description Swagger UI for the OpenAPI document, /docs redirects to it
+// @Tags docs
+// @Produce html
+// @Param filepath path string true "UI asset, empty for the UI itself"
+// @Success 200 {string} string
+// @Failure 404 {string} string
+// @Router /docs/{filepath} [get]
+func (h *DocsHandler) Docs(c *gin.Context) {
+	switch c.Param("filepath") {
+	case "/swagger-initializer.js":
+		c.Data(http.StatusOK, "text/javascript; charset=utf-8", []byte(docsInitializer))
+	default:
+		c.FileFromFS(c.Param("filepath"), http.FS(swaggerFiles.FS))
+	}
+}
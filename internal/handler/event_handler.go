@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/event"
+	"rest-api/internal/problem"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// eventKeepAlive is the interval of keep-alive messages on idle streams,
+// below the idle timeout of common proxies
+const eventKeepAlive = 25 * time.Second
+
+// eventReset tells a client that missed events can no longer be replayed
+// and it has to reload its todos
+const eventReset = "stream.reset"
+
+// upgrader accepts WebSocket connections from any origin; like the REST
+// endpoints they are authenticated with a bearer token, not with cookies
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// EventHandler streams the changes of a user's todos
+type EventHandler struct {
+	bus         *event.Bus
+	todoService *service.TodoService
+}
+
+// NewEventHandler creates a new event handler instance
+func NewEventHandler(bus *event.Bus, todoService *service.TodoService) *EventHandler {
+	return &EventHandler{
+		bus:         bus,
+		todoService: todoService,
+	}
+}
+
+// Stream handles GET /api/v1/events
+// @Summary Stream todo changes
+// @Description Push the created, updated and deleted todos of the authenticated user as Server-Sent Events. The data of each event is a JSON object with id, type, todo_id, occurred_at and the current todo, and the event carries its ID; reconnecting with Last-Event-ID replays the missed events, or sends a stream.reset event when they are no longer buffered and the todos have to be reloaded.
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} dto.Problem
+// @Router /api/v1/events [get]
+// @Security BearerAuth
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	sub, replay, resumed := h.bus.Subscribe(userID.(uint), c.GetHeader("Last-Event-ID"))
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	// The headers go out right away, before the first event
+	c.Writer.Flush()
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	send := func(message dto.TodoEvent) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Type, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	h.stream(c.Request.Context().Done(), sub, replay, resumed, loc, send, func() error {
+		if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+}
+
+// Socket handles GET /api/v1/events/ws
+// @Summary Stream todo changes over WebSocket
+// @Description Upgrade to a WebSocket that pushes the same events as /api/v1/events as JSON text messages. Browsers cannot set Last-Event-ID on a WebSocket, so the ID of the last event received is passed as last_event_id.
+// @Tags events
+// @Param last_event_id query string false "ID of the last event received"
+// @Success 101 {string} string "Switching to the WebSocket protocol"
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Router /api/v1/events/ws [get]
+// @Security BearerAuth
+func (h *EventHandler) Socket(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	// Upgrade writes its own 400 when the request is not a WebSocket handshake
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub, replay, resumed := h.bus.Subscribe(userID.(uint), c.Query("last_event_id"))
+	defer sub.Close()
+
+	// Reading handles pings and the close of the client; messages from the
+	// client are ignored
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	h.stream(closed, sub, replay, resumed, loc, func(message dto.TodoEvent) error {
+		return conn.WriteJSON(message)
+	}, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventKeepAlive))
+	})
+}
+
+// stream sends a reset or the replayed events, then the events of the
+// subscription until done is closed, sending fails or the subscription
+// ends. A client dropped for falling behind reconnects and resumes.
+func (h *EventHandler) stream(done <-chan struct{}, sub *event.Subscription, replay []event.Event, resumed bool, loc *time.Location, send func(dto.TodoEvent) error, keepAlive func() error) {
+	if !resumed {
+		if err := send(dto.TodoEvent{Type: eventReset, OccurredAt: time.Now().UTC()}); err != nil {
+			return
+		}
+	}
+	for _, e := range replay {
+		if err := send(h.toTodoEvent(e, loc)); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(eventKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := send(h.toTodoEvent(e, loc)); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := keepAlive(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// toTodoEvent converts an event to its message with the current state of
+// the todo. A todo deleted since the event is sent without its state.
+func (h *EventHandler) toTodoEvent(e event.Event, loc *time.Location) dto.TodoEvent {
+	message := dto.TodoEvent{ID: e.ID, Type: e.Type, TodoID: e.TodoID, OccurredAt: e.At}
+	if e.Type == event.TodoDeleted {
+		return message
+	}
+
+	todo, err := h.todoService.GetTodoByID(e.TodoID, e.UserID)
+	if err != nil {
+		if !errors.Is(err, service.ErrTodoNotFound) {
+			log.Printf("Failed to load todo %d of event %s: %v", e.TodoID, e.ID, err)
+		}
+		return message
+	}
+	response := service.ToTodoResponse(todo, loc)
+	message.Todo = &response
+	return message
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/graph"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the GraphQL schema of the API
+type GraphQLHandler struct {
+	schema *graph.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQL handler instance
+func NewGraphQLHandler(schema *graph.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query handles a GraphQL query or mutation
+// @Summary Run a GraphQL query
+// @Description Run a query or mutation on users, todos and their dependencies. Errors of fields are returned in errors with the code of the matching REST problem in extensions.code. Queries nested or estimated to cost more than the configured limits are rejected with query_too_deep or query_too_complex.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.GraphQLRequest true "GraphQL request"
+// @Success 200 {object} dto.GraphQLResponse
+// @Failure 400 {object} dto.Problem
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req dto.GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	// Fields that need a user fail one by one when there is none
+	var userID uint
+	if value, exists := c.Get("userID"); exists {
+		userID = value.(uint)
+	}
+
+	render.Render(c, http.StatusOK, h.schema.Execute(c.Request.Context(), userID, i18n.FromContext(c), req))
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"net/http"
 
+	"rest-api/internal/render"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -23,12 +25,13 @@ func NewHealthHandler(db *gorm.DB) *HealthHandler {
 // @Tags health
 // @Produce json
 // @Success 200 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /health [get]
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	// Check database connection
 	sqlDB, err := h.db.DB()
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
+		render.Render(c, http.StatusServiceUnavailable, gin.H{
 			"status":   "error",
 			"message":  "database connection failed",
 			"database": "disconnected",
@@ -38,7 +41,7 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 
 	// Ping database
 	if err := sqlDB.Ping(); err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
+		render.Render(c, http.StatusServiceUnavailable, gin.H{
 			"status":   "error",
 			"message":  "database ping failed",
 			"database": "disconnected",
@@ -47,7 +50,7 @@ func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	}
 
 	// All checks passed
-	c.JSON(http.StatusOK, gin.H{
+	render.Render(c, http.StatusOK, gin.H{
 		"status":   "ok",
 		"message":  "API is running",
 		"database": "connected",
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importMaxBytes limits the size of an uploaded import file
+const importMaxBytes = 10 << 20
+
+// ImportHandler handles todo import HTTP requests
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler creates a new import handler instance
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// Import handles POST /api/v1/todos/import
+// @Summary Import todos
+// @Description Import todos from CSV (with optional column mapping), our JSON export, or Todoist/Trello JSON exports. The file is sent as multipart field "file" or as the raw request body. Large imports run as a background job.
+// @Tags todos
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param file formData file false "Import file"
+// @Param format query string false "Import format (csv, json, todoist, trello)"
+// @Param mapping query string false "CSV column mapping as JSON, e.g. {\"title\":\"Task\"}"
+// @Param dry_run query bool false "Only validate and preview the todos"
+// @Param async query bool false "Run as a background job"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ImportResultResponse}
+// @Success 202 {object} dto.SuccessResponse{data=dto.ImportJobResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/import [post]
+// @Security BearerAuth
+func (h *ImportHandler) Import(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var query dto.ImportTodoQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		problem.BindQuery(c, err)
+		return
+	}
+
+	var mapping map[string]string
+	if query.Mapping != "" {
+		if err := json.Unmarshal([]byte(query.Mapping), &mapping); err != nil {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeValidationFailed, dto.FieldError{
+				In:     "query",
+				Field:  "mapping",
+				Code:   problem.FieldInvalidJSON,
+				Params: map[string]interface{}{"error": err.Error()},
+			})
+			return
+		}
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxBytes)
+
+	file, format, err := importSource(c, query.Format)
+	if err != nil {
+		problem.Error(c, err, "Invalid import file")
+		return
+	}
+	defer file.Close()
+
+	rows, err := h.importService.ParseImport(format, file, mapping)
+	if err != nil {
+		problem.Error(c, err, "Failed to parse import file")
+		return
+	}
+
+	if !query.DryRun && (query.Async || len(rows) > service.ImportSyncLimit) {
+		job, err := h.importService.StartImportJob(userID.(uint), format, rows)
+		if err != nil {
+			problem.Error(c, err, "Failed to start import")
+			return
+		}
+
+		c.Header("Location", fmt.Sprintf("/api/v1/todos/import/%d", job.ID))
+		render.Render(c, http.StatusAccepted, i18n.Success(c, "import_started", toImportJobResponse(job)))
+		return
+	}
+
+	result := h.importService.ImportTodos(userID.(uint), rows, query.DryRun)
+
+	code := "todos_imported"
+	if query.DryRun {
+		code = "import_validated"
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, code, result))
+}
+
+// GetJob handles GET /api/v1/todos/import/:id
+// @Summary Get import job progress
+// @Description Poll the progress of a background todo import
+// @Tags todos
+// @Produce json
+// @Param id path int true "Import job ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ImportJobResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/import/{id} [get]
+// @Security BearerAuth
+func (h *ImportHandler) GetJob(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	job, err := h.importService.GetImportJob(uint(jobID), userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve import job")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "import_job_retrieved", toImportJobResponse(job)))
+}
+
+// importSource returns the uploaded file and its format. The format falls
+// back to the file extension or Content-Type when not given explicitly.
+func importSource(c *gin.Context, format string) (io.ReadCloser, string, error) {
+	var (
+		file     io.ReadCloser
+		hintType string
+	)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", service.ErrInvalidImportFile, err)
+		}
+		opened, err := header.Open()
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", service.ErrInvalidImportFile, err)
+		}
+		file = opened
+		hintType = mime.TypeByExtension(filepath.Ext(header.Filename))
+		if hintType == "" {
+			hintType = header.Header.Get("Content-Type")
+		}
+	} else {
+		file = c.Request.Body
+		hintType = c.ContentType()
+	}
+
+	if format == "" {
+		switch {
+		case strings.Contains(hintType, "csv"):
+			format = service.ImportFormatCSV
+		case strings.Contains(hintType, "json"):
+			format = service.ImportFormatJSON
+		default:
+			file.Close()
+			return nil, "", fmt.Errorf("%w: cannot detect file format, set the format query parameter", service.ErrUnsupportedImportFormat)
+		}
+	}
+
+	return file, format, nil
+}
+
+// toImportJobResponse converts an import job to its response DTO
+func toImportJobResponse(job *model.ImportJob) dto.ImportJobResponse {
+	rowErrors := []dto.ImportRowError{}
+	if job.Errors != "" {
+		json.Unmarshal([]byte(job.Errors), &rowErrors)
+	}
+
+	progress := 100.0
+	if job.Total > 0 {
+		progress = float64(job.Processed) * 100 / float64(job.Total)
+	}
+
+	return dto.ImportJobResponse{
+		ID:         job.ID,
+		Format:     job.Format,
+		Status:     job.Status,
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Failed:     job.Failed,
+		Progress:   progress,
+		Errors:     rowErrors,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
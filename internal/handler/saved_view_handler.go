@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavedViewHandler handles saved view HTTP requests
+type SavedViewHandler struct {
+	viewService *service.SavedViewService
+	todoService *service.TodoService
+}
+
+// NewSavedViewHandler creates a new saved view handler instance
+func NewSavedViewHandler(viewService *service.SavedViewService, todoService *service.TodoService) *SavedViewHandler {
+	return &SavedViewHandler{
+		viewService: viewService,
+		todoService: todoService,
+	}
+}
+
+// List handles GET /api/v1/views
+// @Summary List views
+// @Description List the built-in views (today, upcoming, overdue) followed by the saved views of the authenticated user
+// @Tags views
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SavedViewResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views [get]
+// @Security BearerAuth
+func (h *SavedViewHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	views, err := h.viewService.ListViews(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve views")
+		return
+	}
+
+	responses := make([]dto.SavedViewResponse, len(views))
+	for i := range views {
+		responses[i] = toSavedViewResponse(&views[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "views_retrieved", responses))
+}
+
+// Get handles GET /api/v1/views/:id
+// @Summary Get a view
+// @Description Get a saved view by ID or a built-in view by key
+// @Tags views
+// @Produce json
+// @Param id path string true "Saved view ID or built-in view key (today, upcoming, overdue)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views/{id} [get]
+// @Security BearerAuth
+func (h *SavedViewHandler) Get(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	view, err := h.viewService.GetView(userID.(uint), c.Param("id"))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve view")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "view_retrieved", toSavedViewResponse(view)))
+}
+
+// Todos handles GET /api/v1/views/:id/todos
+// @Summary List the todos of a view
+// @Description Evaluate a saved or built-in view for the authenticated user. Due ranges such as next_7_days are relative to the current day in the user's time zone.
+// @Tags views
+// @Produce json
+// @Param id path string true "Saved view ID or built-in view key (today, upcoming, overdue)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TodoResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views/{id}/todos [get]
+// @Security BearerAuth
+func (h *SavedViewHandler) Todos(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todos, err := h.viewService.ViewTodos(userID.(uint), c.Param("id"))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve todos")
+		return
+	}
+
+	responses := make([]dto.TodoResponse, len(todos))
+	loc := h.todoService.UserLocation(userID.(uint))
+	for i := range todos {
+		responses[i] = service.ToTodoResponse(&todos[i], loc)
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "todos_retrieved", responses))
+}
+
+// Create handles POST /api/v1/views
+// @Summary Save a view
+// @Description Save a named todo list filter (status, priority, tags, due range, text, open, sort and custom fields, as on GET /api/v1/todos)
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param view body dto.CreateSavedViewRequest true "View name and filter"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views [post]
+// @Security BearerAuth
+func (h *SavedViewHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.CreateSavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	view, err := h.viewService.CreateView(userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to save view")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "view_saved", toSavedViewResponse(view)))
+}
+
+// Update handles PUT /api/v1/views/:id
+// @Summary Update a saved view
+// @Description Rename a saved view or replace its filter. Built-in views cannot be changed.
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param id path int true "Saved view ID"
+// @Param view body dto.UpdateSavedViewRequest true "View changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views/{id} [put]
+// @Security BearerAuth
+func (h *SavedViewHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	viewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	var req dto.UpdateSavedViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	view, err := h.viewService.UpdateView(uint(viewID), userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to update view")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "view_updated", toSavedViewResponse(view)))
+}
+
+// Delete handles DELETE /api/v1/views/:id
+// @Summary Delete a saved view
+// @Description Delete a saved view of the authenticated user. Built-in views cannot be deleted.
+// @Tags views
+// @Produce json
+// @Param id path int true "Saved view ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/views/{id} [delete]
+// @Security BearerAuth
+func (h *SavedViewHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	viewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	if err := h.viewService.DeleteView(uint(viewID), userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to delete view")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "view_deleted", nil))
+}
+
+// toSavedViewResponse converts a view to its response DTO
+func toSavedViewResponse(view *service.View) dto.SavedViewResponse {
+	response := dto.SavedViewResponse{
+		ID:      view.ID,
+		Key:     view.Key,
+		Name:    view.Name,
+		BuiltIn: view.BuiltIn(),
+		Filter:  view.Filter,
+	}
+	if !view.BuiltIn() {
+		response.CreatedAt = &view.CreatedAt
+		response.UpdatedAt = &view.UpdatedAt
+	}
+	return response
+}
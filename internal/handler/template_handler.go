@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler handles todo template HTTP requests
+type TemplateHandler struct {
+	templateService *service.TemplateService
+	todoService     *service.TodoService
+}
+
+// NewTemplateHandler creates a new template handler instance
+func NewTemplateHandler(templateService *service.TemplateService, todoService *service.TodoService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		todoService:     todoService,
+	}
+}
+
+// List handles GET /api/v1/templates
+// @Summary List templates
+// @Description List the todo templates of the authenticated user
+// @Tags templates
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TemplateResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates [get]
+// @Security BearerAuth
+func (h *TemplateHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve templates")
+		return
+	}
+
+	responses := make([]dto.TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = toTemplateResponse(&templates[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "templates_retrieved", responses))
+}
+
+// Get handles GET /api/v1/templates/:id
+// @Summary Get a template
+// @Description Get a todo template of the authenticated user with the placeholders it needs
+// @Tags templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TemplateResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates/{id} [get]
+// @Security BearerAuth
+func (h *TemplateHandler) Get(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(templateID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve template")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "template_retrieved", toTemplateResponse(template)))
+}
+
+// Create handles POST /api/v1/templates
+// @Summary Create a template
+// @Description Save a checklist of todos as a template. Items may use {{placeholders}} in title and description and due dates relative to a base date. With todo_id an existing todo is copied as the first item.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body dto.CreateTemplateRequest true "Template definition"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TemplateResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates [post]
+// @Security BearerAuth
+func (h *TemplateHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to create template")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "template_created", toTemplateResponse(template)))
+}
+
+// Update handles PUT /api/v1/templates/:id
+// @Summary Update a template
+// @Description Rename a template, change its description or replace its items
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param template body dto.UpdateTemplateRequest true "Template changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TemplateResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates/{id} [put]
+// @Security BearerAuth
+func (h *TemplateHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(templateID, userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to update template")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "template_updated", toTemplateResponse(template)))
+}
+
+// Delete handles DELETE /api/v1/templates/:id
+// @Summary Delete a template
+// @Description Delete a todo template. Todos created from it are kept.
+// @Tags templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates/{id} [delete]
+// @Security BearerAuth
+func (h *TemplateHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(templateID, userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to delete template")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "template_deleted", nil))
+}
+
+// Instantiate handles POST /api/v1/templates/:id/instantiate
+// @Summary Instantiate a template
+// @Description Create the todos of a template in one transaction, filling in placeholders and due dates relative to the base date (default: today in the user's time zone). {{date}} is replaced with the base date.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param instantiate body dto.InstantiateTemplateRequest false "Base date and placeholder values"
+// @Success 201 {object} dto.SuccessResponse{data=[]dto.TodoResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/templates/{id}/instantiate [post]
+// @Security BearerAuth
+func (h *TemplateHandler) Instantiate(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InstantiateTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Bind(c, err)
+			return
+		}
+	}
+
+	todos, err := h.templateService.Instantiate(templateID, userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to instantiate template")
+		return
+	}
+
+	responses := make([]dto.TodoResponse, len(todos))
+	loc := h.todoService.UserLocation(userID.(uint))
+	for i, todo := range todos {
+		responses[i] = service.ToTodoResponse(todo, loc)
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "template_instantiated", responses))
+}
+
+// parseTemplateID parses the :id path parameter, writing a 400 response when invalid
+func parseTemplateID(c *gin.Context) (uint, bool) {
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return 0, false
+	}
+	return uint(templateID), true
+}
+
+// toTemplateResponse converts a template to its response DTO
+func toTemplateResponse(template *service.Template) dto.TemplateResponse {
+	return dto.TemplateResponse{
+		ID:           template.ID,
+		Name:         template.Name,
+		Description:  template.Description,
+		Items:        template.Items,
+		Placeholders: template.Placeholders(),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeEntryHandler handles time tracking HTTP requests
+type TimeEntryHandler struct {
+	timeEntryService *service.TimeEntryService
+}
+
+// NewTimeEntryHandler creates a new time entry handler instance
+func NewTimeEntryHandler(timeEntryService *service.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService: timeEntryService,
+	}
+}
+
+// Start handles POST /api/v1/todos/:id/timer/start
+// @Summary Start a timer
+// @Description Start tracking time on a todo. Only one timer can run per user.
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param timer body dto.StartTimerRequest false "Optional note"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TimeEntryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/timer/start [post]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Start(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.StartTimerRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Bind(c, err)
+			return
+		}
+	}
+
+	entry, err := h.timeEntryService.StartTimer(todoID, userID.(uint), req.Note)
+	if err != nil {
+		problem.Error(c, err, "Failed to start timer")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "timer_started", toTimeEntryResponse(entry)))
+}
+
+// Stop handles POST /api/v1/time-entries/stop
+// @Summary Stop the running timer
+// @Description Stop the running timer of the authenticated user
+// @Tags time-tracking
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.TimeEntryResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/time-entries/stop [post]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Stop(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	entry, err := h.timeEntryService.StopTimer(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to stop timer")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "timer_stopped", toTimeEntryResponse(entry)))
+}
+
+// Current handles GET /api/v1/time-entries/current
+// @Summary Get the running timer
+// @Description Get the running timer of the authenticated user, data is null when no timer runs
+// @Tags time-tracking
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.TimeEntryResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/time-entries/current [get]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Current(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	entry, err := h.timeEntryService.GetRunningTimer(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve timer")
+		return
+	}
+
+	var data interface{}
+	if entry != nil {
+		data = toTimeEntryResponse(entry)
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "timer_retrieved", data))
+}
+
+// Create handles POST /api/v1/todos/:id/time-entries
+// @Summary Add a manual time entry
+// @Description Record time spent on a todo without a timer. Without started_at the entry ends now.
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param entry body dto.CreateTimeEntryRequest true "Time entry"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TimeEntryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/time-entries [post]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	entry, err := h.timeEntryService.AddEntry(todoID, userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to add time entry")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "time_entry_added", toTimeEntryResponse(entry)))
+}
+
+// List handles GET /api/v1/time-entries
+// @Summary List time entries
+// @Description List the time entries of the authenticated user, newest first
+// @Tags time-tracking
+// @Produce json
+// @Param todo_id query int false "Only entries of this todo"
+// @Param from query string false "First day (YYYY-MM-DD, user time zone)"
+// @Param to query string false "Last day (YYYY-MM-DD, user time zone)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TimeEntryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/time-entries [get]
+// @Security BearerAuth
+func (h *TimeEntryHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var todoID uint64
+	if value := c.Query("todo_id"); value != "" {
+		var err error
+		if todoID, err = strconv.ParseUint(value, 10, 32); err != nil {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+			return
+		}
+	}
+
+	entries, err := h.timeEntryService.ListEntries(userID.(uint), uint(todoID), c.Query("from"), c.Query("to"))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve time entries")
+		return
+	}
+
+	responses := make([]dto.TimeEntryResponse, len(entries))
+	for i := range entries {
+		responses[i] = toTimeEntryResponse(&entries[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "time_entries_retrieved", responses))
+}
+
+// Summary handles GET /api/v1/time-entries/summary
+// @Summary Summarize tracked time
+// @Description Total the finished time entries of the authenticated user over a date range (default: the current week), per todo and per day
+// @Tags time-tracking
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD, user time zone)"
+// @Param to query string false "Last day (YYYY-MM-DD, user time zone)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TimeSummaryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/time-entries/summary [get]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Summary(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	summary, err := h.timeEntryService.Summarize(userID.(uint), c.Query("from"), c.Query("to"))
+	if err != nil {
+		problem.Error(c, err, "Failed to summarize time entries")
+		return
+	}
+
+	response := dto.TimeSummaryResponse{
+		From:         summary.From,
+		To:           summary.To,
+		TotalSeconds: summary.TotalSeconds,
+		ByTodo:       make([]dto.TodoTimeResponse, len(summary.ByTodo)),
+		ByDay:        make([]dto.DayTimeResponse, len(summary.ByDay)),
+	}
+	for i, total := range summary.ByTodo {
+		response.ByTodo[i] = dto.TodoTimeResponse{
+			TodoID:          total.TodoID,
+			Title:           total.Title,
+			Seconds:         total.Seconds,
+			EstimateMinutes: total.EstimateMinutes,
+		}
+	}
+	for i, total := range summary.ByDay {
+		response.ByDay[i] = dto.DayTimeResponse{Day: total.Day, Seconds: total.Seconds}
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "time_summary_retrieved", response))
+}
+
+// Delete handles DELETE /api/v1/time-entries/:id
+// @Summary Delete a time entry
+// @Description Delete a time entry of the authenticated user
+// @Tags time-tracking
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/time-entries/{id} [delete]
+// @Security BearerAuth
+func (h *TimeEntryHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	entryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
+		return
+	}
+
+	if err := h.timeEntryService.DeleteEntry(uint(entryID), userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to delete time entry")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "time_entry_deleted", nil))
+}
+
+// toTimeEntryResponse converts a time entry to its response DTO
+func toTimeEntryResponse(entry *model.TimeEntry) dto.TimeEntryResponse {
+	response := dto.TimeEntryResponse{
+		ID:              entry.ID,
+		TodoID:          entry.TodoID,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		Running:         entry.EndedAt == nil,
+		DurationSeconds: entry.DurationSeconds,
+		Day:             entry.Day,
+		Note:            entry.Note,
+	}
+	if entry.Todo != nil {
+		response.TodoTitle = entry.Todo.Title
+	}
+	if response.Running {
+		response.DurationSeconds = int64(time.Since(entry.StartedAt).Seconds())
+	}
+	return response
+}
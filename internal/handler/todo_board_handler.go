@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Board handles GET /api/v1/board
+// @Summary Get the Kanban board
+// @Description Get the todos of the authenticated user grouped into one column per workflow status, each in manual position order
+// @Tags board
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.BoardResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/board [get]
+// @Security BearerAuth
+func (h *TodoHandler) Board(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	columns, err := h.todoService.GetBoard(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve board")
+		return
+	}
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	response := dto.BoardResponse{Columns: make([]dto.BoardColumnResponse, len(columns))}
+	for i, column := range columns {
+		todos := make([]dto.TodoResponse, len(column.Todos))
+		for j := range column.Todos {
+			todos[j] = service.ToTodoResponse(&column.Todos[j], loc)
+		}
+		response.Columns[i] = dto.BoardColumnResponse{
+			Key:      column.Status.Key,
+			Name:     column.Status.Name,
+			Category: column.Status.Category,
+			Count:    len(todos),
+			Todos:    todos,
+		}
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "board_retrieved", response))
+}
+
+// Move handles POST /api/v1/todos/:id/move
+// @Summary Move a todo on the board
+// @Description Place a todo directly before or after another todo (or at the end without either) and optionally move it to another status column. Status changes follow the workflow like updates do.
+// @Tags board
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param move body dto.MoveTodoRequest true "Target position and status"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TodoResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/{id}/move [post]
+// @Security BearerAuth
+func (h *TodoHandler) Move(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	todoID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.MoveTodoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	todo, err := h.todoService.MoveTodo(todoID, userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to move todo")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "todo_moved", service.ToTodoResponse(todo, h.todoService.UserLocation(userID.(uint)))))
+}
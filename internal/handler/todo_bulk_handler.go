@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bulk handles POST /api/v1/todos/bulk
+// @Summary Run bulk todo operations
+// @Description Execute a batch of create/update/delete and filter-based operations in a single transaction or in best-effort mode
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param operations body dto.BulkTodoRequest true "Bulk operations"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BulkTodoResponse}
+// @Success 207 {object} dto.SuccessResponse{data=dto.BulkTodoResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/bulk [post]
+// @Security BearerAuth
+func (h *TodoHandler) Bulk(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.BulkTodoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	if req.Mode == "" {
+		req.Mode = service.BulkModeTransaction
+	}
+
+	results, err := h.todoService.BulkTodos(userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to run bulk operations")
+		return
+	}
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	response := dto.BulkTodoResponse{
+		Mode:    req.Mode,
+		Results: make([]dto.BulkTodoResult, len(results)),
+	}
+	for i, result := range results {
+		item := dto.BulkTodoResult{
+			Index:    i,
+			Op:       result.Op,
+			Status:   bulkResultStatus(result),
+			Affected: result.Affected,
+		}
+		if result.Err != nil {
+			_, item.Code = problem.Lookup(result.Err)
+			item.Error = problem.Detail(c, result.Err)
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		if result.Err == nil && result.Todo != nil {
+			todo := service.ToTodoResponse(result.Todo, loc)
+			item.Data = &todo
+		}
+		response.Results[i] = item
+	}
+
+	statusCode := http.StatusOK
+	code := "bulk_completed"
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+		code = "bulk_partially_failed"
+	}
+
+	body := i18n.Success(c, code, response)
+	body.Success = response.Failed == 0
+	render.Render(c, statusCode, body)
+}
+
+// bulkResultStatus maps a bulk operation result to an HTTP status code
+func bulkResultStatus(result service.BulkResult) int {
+	err := result.Err
+	switch {
+	case err == nil && result.Op == service.BulkOpCreate:
+		return http.StatusCreated
+	case err == nil:
+		return http.StatusOK
+	default:
+		status, _ := problem.Lookup(err)
+		return status
+	}
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFlushEvery is the number of rows written between flushes to the client
+const exportFlushEvery = 100
+
+// todoExportColumns is the stable column order of CSV and Markdown exports
+var todoExportColumns = []string{
+	"id", "title", "description", "status", "priority", "due_date", "user_id", "created_at", "updated_at",
+}
+
+// todoExporter writes todos in one export format
+type todoExporter interface {
+	contentType() string
+	begin() error
+	write(todo dto.TodoResponse) error
+	flush() error
+	end() error
+}
+
+// Export handles GET /api/v1/todos/export
+// @Summary Export todos
+// @Description Stream the todos of the authenticated user as CSV, JSON or Markdown, with the same filters and order as the list endpoint
+// @Tags todos
+// @Produce text/csv
+// @Produce json
+// @Produce text/markdown
+// @Param format query string false "Export format (csv, json, md)" default(csv)
+// @Param status query string false "Filter by workflow status (default workflow: pending, in_progress, completed)"
+// @Param priority query string false "Filter by priority (low, medium, high)"
+// @Param tags query string false "Comma separated tags a todo must all carry"
+// @Param due query string false "Filter by due range (overdue, today, tomorrow, this_week, next_7_days, next_30_days, none)"
+// @Param q query string false "Search text in title and description"
+// @Param open query bool false "Only todos that are not completed"
+// @Param sort query string false "Sort by position, created_at, updated_at, due_date, title, priority or cf.<key>, prefix - for descending (default position)"
+// @Param cf.key query string false "Filter by the value of custom field key, e.g. cf.size=large"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/export [get]
+// @Security BearerAuth
+func (h *TodoHandler) Export(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	exporter := newTodoExporter(format, c.Writer)
+	if exporter == nil {
+		problem.Abort(c, http.StatusBadRequest, problem.CodeValidationFailed, dto.FieldError{
+			In:     "query",
+			Field:  "format",
+			Code:   problem.FieldInvalidChoice,
+			Params: map[string]interface{}{"options": "csv, json, md"},
+		})
+		return
+	}
+
+	// Headers are written lazily so filter errors can still return JSON
+	started := false
+	start := func() error {
+		started = true
+		filename := fmt.Sprintf("todos-%s.%s", time.Now().Format("20060102"), format)
+		c.Header("Content-Type", exporter.contentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		c.Status(http.StatusOK)
+		return exporter.begin()
+	}
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	count := 0
+	err := h.todoService.StreamUserTodos(userID.(uint), todoListQuery(c), func(todo *model.Todo) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := exporter.write(service.ToTodoResponse(todo, loc)); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			if err := exporter.flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if started {
+			// The response is already partially sent; all we can do is stop
+			log.Printf("Todo export for user %d aborted: %v", userID.(uint), err)
+			return
+		}
+
+		problem.Error(c, err, "Failed to export todos")
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			log.Printf("Todo export for user %d aborted: %v", userID.(uint), err)
+			return
+		}
+	}
+	if err := exporter.end(); err != nil {
+		log.Printf("Todo export for user %d aborted: %v", userID.(uint), err)
+	}
+}
+
+// newTodoExporter returns the exporter for a format, nil when unsupported
+func newTodoExporter(format string, w io.Writer) todoExporter {
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.UseCRLF = true // RFC 4180 line endings
+		return &csvTodoExporter{w: writer}
+	case "json":
+		return &jsonTodoExporter{w: w}
+	case "md":
+		return &markdownTodoExporter{w: w}
+	default:
+		return nil
+	}
+}
+
+// exportRow returns the todo fields in todoExportColumns order
+func exportRow(todo dto.TodoResponse) []string {
+	dueDate := ""
+	if todo.DueDate != nil && todo.DueAllDay {
+		dueDate = todo.DueDate.Format("2006-01-02")
+	} else if todo.DueDate != nil {
+		dueDate = todo.DueDate.Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatUint(uint64(todo.ID), 10),
+		todo.Title,
+		todo.Description,
+		todo.Status,
+		todo.Priority,
+		dueDate,
+		strconv.FormatUint(uint64(todo.UserID), 10),
+		todo.CreatedAt.Format(time.RFC3339),
+		todo.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// csvTodoExporter writes RFC 4180 CSV with a header row
+type csvTodoExporter struct {
+	w *csv.Writer
+}
+
+func (e *csvTodoExporter) contentType() string { return "text/csv; charset=utf-8" }
+
+func (e *csvTodoExporter) begin() error {
+	return e.w.Write(todoExportColumns)
+}
+
+func (e *csvTodoExporter) write(todo dto.TodoResponse) error {
+	return e.w.Write(exportRow(todo))
+}
+
+func (e *csvTodoExporter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvTodoExporter) end() error {
+	return e.flush()
+}
+
+// jsonTodoExporter writes a JSON array of todo objects
+type jsonTodoExporter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonTodoExporter) contentType() string { return "application/json; charset=utf-8" }
+
+func (e *jsonTodoExporter) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonTodoExporter) write(todo dto.TodoResponse) error {
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonTodoExporter) flush() error { return nil }
+
+func (e *jsonTodoExporter) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// markdownTodoExporter writes a Markdown table
+type markdownTodoExporter struct {
+	w io.Writer
+}
+
+func (e *markdownTodoExporter) contentType() string { return "text/markdown; charset=utf-8" }
+
+func (e *markdownTodoExporter) begin() error {
+	separators := make([]string, len(todoExportColumns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	_, err := fmt.Fprintf(e.w, "| %s |\n| %s |\n",
+		strings.Join(todoExportColumns, " | "), strings.Join(separators, " | "))
+	return err
+}
+
+func (e *markdownTodoExporter) write(todo dto.TodoResponse) error {
+	row := exportRow(todo)
+	for i, cell := range row {
+		row[i] = markdownCellReplacer.Replace(cell)
+	}
+	_, err := fmt.Fprintf(e.w, "| %s |\n", strings.Join(row, " | "))
+	return err
+}
+
+func (e *markdownTodoExporter) flush() error { return nil }
+
+func (e *markdownTodoExporter) end() error { return nil }
+
+// markdownCellReplacer keeps cell content from breaking the table layout
+var markdownCellReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
@@ -1,15 +1,17 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
 	"rest-api/internal/service"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // TodoHandler handles todo HTTP requests
@@ -32,68 +34,34 @@ func NewTodoHandler(todoService *service.TodoService) *TodoHandler {
 // @Produce json
 // @Param todo body dto.CreateTodoRequest true "Todo data"
 // @Success 201 {object} dto.SuccessResponse{data=dto.TodoResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
 // @Router /api/v1/todos [post]
 // @Security BearerAuth
 func (h *TodoHandler) Create(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		problem.Unauthorized(c)
 		return
 	}
 
 	var req dto.CreateTodoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+		problem.Bind(c, err)
 		return
 	}
 
 	todo, err := h.todoService.CreateTodo(userID.(uint), req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to create todo"
-
-		if errors.Is(err, service.ErrInvalidStatus) || errors.Is(err, service.ErrInvalidPriority) {
-			statusCode = http.StatusBadRequest
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-			Error:   err.Error(),
-		})
+		problem.Error(c, err, "Failed to create todo")
 		return
 	}
 
-	response := dto.TodoResponse{
-		ID:          todo.ID,
-		Title:       todo.Title,
-		Description: todo.Description,
-		Status:      todo.Status,
-		Priority:    todo.Priority,
-		DueDate:     todo.DueDate,
-		UserID:      todo.UserID,
-		CreatedAt:   todo.CreatedAt,
-		UpdatedAt:   todo.UpdatedAt,
-	}
+	response := service.ToTodoResponse(todo, h.todoService.UserLocation(userID.(uint)))
 
-	c.JSON(http.StatusCreated, dto.SuccessResponse{
-		Success: true,
-		Message: "Todo created successfully",
-		Data:    response,
-	})
+	render.Render(c, http.StatusCreated, i18n.Success(c, "todo_created", response))
 }
 
 // GetAll handles GET /api/v1/todos
@@ -102,68 +70,64 @@ func (h *TodoHandler) Create(c *gin.Context) {
 // @Tags todos
 // @Accept json
 // @Produce json
-// @Param status query string false "Filter by status (pending, in_progress, completed)"
+// @Param status query string false "Filter by workflow status (default workflow: pending, in_progress, completed)"
 // @Param priority query string false "Filter by priority (low, medium, high)"
+// @Param tags query string false "Comma separated tags a todo must all carry"
+// @Param due query string false "Filter by due range (overdue, today, tomorrow, this_week, next_7_days, next_30_days, none)"
+// @Param q query string false "Search text in title and description"
+// @Param open query bool false "Only todos that are not completed"
+// @Param sort query string false "Sort by position, created_at, updated_at, due_date, title, priority or cf.<key>, prefix - for descending (default position)"
+// @Param cf.key query string false "Filter by the value of custom field key, e.g. cf.size=large"
 // @Success 200 {object} dto.SuccessResponse{data=[]dto.TodoResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
 // @Router /api/v1/todos [get]
 // @Security BearerAuth
 func (h *TodoHandler) GetAll(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		problem.Unauthorized(c)
 		return
 	}
 
-	// Get query parameters
-	status := c.Query("status")
-	priority := c.Query("priority")
-
-	todos, err := h.todoService.GetUserTodos(userID.(uint), status, priority)
+	todos, err := h.todoService.ListTodos(userID.(uint), todoListQuery(c))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to retrieve todos"
-
-		if errors.Is(err, service.ErrInvalidStatus) || errors.Is(err, service.ErrInvalidPriority) {
-			statusCode = http.StatusBadRequest
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-			Error:   err.Error(),
-		})
+		problem.Error(c, err, "Failed to retrieve todos")
 		return
 	}
 
 	// Convert to response DTOs
 	responses := make([]dto.TodoResponse, len(todos))
-	for i, todo := range todos {
-		responses[i] = dto.TodoResponse{
-			ID:          todo.ID,
-			Title:       todo.Title,
-			Description: todo.Description,
-			Status:      todo.Status,
-			Priority:    todo.Priority,
-			DueDate:     todo.DueDate,
-			UserID:      todo.UserID,
-			CreatedAt:   todo.CreatedAt,
-			UpdatedAt:   todo.UpdatedAt,
-		}
+	loc := h.todoService.UserLocation(userID.(uint))
+	for i := range todos {
+		responses[i] = service.ToTodoResponse(&todos[i], loc)
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Todos retrieved successfully",
-		Data:    responses,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "todos_retrieved", responses))
+}
+
+// todoListQuery reads the filters of the todo list from the query string,
+// cf.<key> filters by custom field
+func todoListQuery(c *gin.Context) dto.TodoListQuery {
+	query := dto.TodoListQuery{
+		Status:   c.Query("status"),
+		Priority: c.Query("priority"),
+		Due:      c.Query("due"),
+		Query:    c.Query("q"),
+		Open:     c.Query("open") == "true",
+		Sort:     c.Query("sort"),
+		Fields:   map[string]string{},
+	}
+	if tags := c.Query("tags"); tags != "" {
+		query.Tags = strings.Split(tags, ",")
+	}
+	for name, values := range c.Request.URL.Query() {
+		if key := strings.TrimPrefix(name, "cf."); key != name && len(values) > 0 {
+			query.Fields[key] = values[0]
+		}
+	}
+	return query
 }
 
 // GetByID handles GET /api/v1/todos/:id
@@ -174,72 +138,36 @@ func (h *TodoHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Todo ID"
 // @Success 200 {object} dto.SuccessResponse{data=dto.TodoResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
 // @Router /api/v1/todos/{id} [get]
 // @Security BearerAuth
 func (h *TodoHandler) GetByID(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		problem.Unauthorized(c)
 		return
 	}
 
 	// Parse todo ID
 	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid todo ID",
-			Error:   err.Error(),
-		})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
 		return
 	}
 
 	todo, err := h.todoService.GetTodoByID(uint(todoID), userID.(uint))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to retrieve todo"
-
-		if errors.Is(err, service.ErrTodoNotFound) {
-			statusCode = http.StatusNotFound
-			message = "Todo not found"
-		} else if errors.Is(err, service.ErrUnauthorizedAccess) {
-			statusCode = http.StatusForbidden
-			message = "You don't have permission to access this todo"
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-			Error:   err.Error(),
-		})
+		problem.Error(c, err, "Failed to retrieve todo")
 		return
 	}
 
-	response := dto.TodoResponse{
-		ID:          todo.ID,
-		Title:       todo.Title,
-		Description: todo.Description,
-		Status:      todo.Status,
-		Priority:    todo.Priority,
-		DueDate:     todo.DueDate,
-		UserID:      todo.UserID,
-		CreatedAt:   todo.CreatedAt,
-		UpdatedAt:   todo.UpdatedAt,
-	}
+	response := service.ToTodoResponse(todo, h.todoService.UserLocation(userID.(uint)))
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Todo retrieved successfully",
-		Data:    response,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "todo_retrieved", response))
 }
 
 // Update handles PUT /api/v1/todos/:id
@@ -251,88 +179,43 @@ func (h *TodoHandler) GetByID(c *gin.Context) {
 // @Param id path int true "Todo ID"
 // @Param todo body dto.UpdateTodoRequest true "Todo data to update"
 // @Success 200 {object} dto.SuccessResponse{data=dto.TodoResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
 // @Router /api/v1/todos/{id} [put]
 // @Security BearerAuth
 func (h *TodoHandler) Update(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		problem.Unauthorized(c)
 		return
 	}
 
 	// Parse todo ID
 	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid todo ID",
-			Error:   err.Error(),
-		})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
 		return
 	}
 
 	var req dto.UpdateTodoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid request data",
-			Error:   err.Error(),
-		})
+		problem.Bind(c, err)
 		return
 	}
 
 	todo, err := h.todoService.UpdateTodo(uint(todoID), userID.(uint), req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to update todo"
-
-		if errors.Is(err, service.ErrTodoNotFound) {
-			statusCode = http.StatusNotFound
-			message = "Todo not found"
-		} else if errors.Is(err, service.ErrUnauthorizedAccess) {
-			statusCode = http.StatusForbidden
-			message = "You don't have permission to update this todo"
-		} else if errors.Is(err, service.ErrInvalidStatus) || errors.Is(err, service.ErrInvalidPriority) {
-			statusCode = http.StatusBadRequest
-			message = err.Error()
-		} else if errors.Is(err, gorm.ErrRecordNotFound) {
-			statusCode = http.StatusNotFound
-			message = "Todo not found"
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-			Error:   err.Error(),
-		})
+		problem.Error(c, err, "Failed to update todo")
 		return
 	}
 
-	response := dto.TodoResponse{
-		ID:          todo.ID,
-		Title:       todo.Title,
-		Description: todo.Description,
-		Status:      todo.Status,
-		Priority:    todo.Priority,
-		DueDate:     todo.DueDate,
-		UserID:      todo.UserID,
-		CreatedAt:   todo.CreatedAt,
-		UpdatedAt:   todo.UpdatedAt,
-	}
+	response := service.ToTodoResponse(todo, h.todoService.UserLocation(userID.(uint)))
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Todo updated successfully",
-		Data:    response,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "todo_updated", response))
 }
 
 // Delete handles DELETE /api/v1/todos/:id
@@ -343,58 +226,32 @@ func (h *TodoHandler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Todo ID"
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 403 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
 // @Router /api/v1/todos/{id} [delete]
 // @Security BearerAuth
 func (h *TodoHandler) Delete(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Error:   "User ID not found in context",
-		})
+		problem.Unauthorized(c)
 		return
 	}
 
 	// Parse todo ID
 	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid todo ID",
-			Error:   err.Error(),
-		})
+		problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidID)
 		return
 	}
 
 	err = h.todoService.DeleteTodo(uint(todoID), userID.(uint))
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to delete todo"
-
-		if errors.Is(err, service.ErrTodoNotFound) {
-			statusCode = http.StatusNotFound
-			message = "Todo not found"
-		} else if errors.Is(err, service.ErrUnauthorizedAccess) {
-			statusCode = http.StatusForbidden
-			message = "You don't have permission to delete this todo"
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-			Error:   err.Error(),
-		})
+		problem.Error(c, err, "Failed to delete todo")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Todo deleted successfully",
-		Data:    nil,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "todo_deleted", nil))
 }
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuickAdd handles POST /api/v1/todos/quick
+// @Summary Quick-add a todo from text
+// @Description Parse one line like "Send invoice tomorrow 5pm !high #finance every month" into title, due date, priority, tags and recurrence. English and Indonesian date words are understood. With dry_run=true the parsed todo is returned without saving it.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param todo body dto.QuickAddTodoRequest true "Quick-add text"
+// @Param dry_run query bool false "Only parse and preview the todo"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuickAddTodoResponse}
+// @Success 201 {object} dto.SuccessResponse{data=dto.QuickAddTodoResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/todos/quick [post]
+// @Security BearerAuth
+func (h *TodoHandler) QuickAdd(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var query dto.QuickAddTodoQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		problem.BindQuery(c, err)
+		return
+	}
+
+	var req dto.QuickAddTodoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	parsed, todo, err := h.todoService.QuickAddTodo(userID.(uint), req.Text, query.DryRun)
+	if err != nil {
+		problem.Error(c, err, "Failed to create todo")
+		return
+	}
+
+	loc := h.todoService.UserLocation(userID.(uint))
+	response := dto.QuickAddTodoResponse{
+		Parsed: dto.QuickAddParsed{
+			Title:      parsed.Title,
+			DueAllDay:  parsed.DueAllDay,
+			Priority:   parsed.Priority,
+			Tags:       parsed.Tags,
+			Recurrence: parsed.Recurrence,
+		},
+	}
+	if parsed.DueDate != nil {
+		dueDate := service.DueDateIn(&model.Todo{DueDate: parsed.DueDate, DueAllDay: parsed.DueAllDay}, loc)
+		response.Parsed.DueDate = &dueDate
+	}
+
+	if query.DryRun {
+		render.Render(c, http.StatusOK, i18n.Success(c, "todo_parsed", response))
+		return
+	}
+
+	created := service.ToTodoResponse(todo, loc)
+	response.Todo = &created
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "todo_created", response))
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Stats handles GET /api/v1/stats
+// @Summary Get productivity stats
+// @Description Get counts by status and priority, the overdue count, the completion rate and cycle time of a date range (default: the current week), completed todos per day and completion streaks of the authenticated user
+// @Tags stats
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD, user time zone)"
+// @Param to query string false "Last day (YYYY-MM-DD, user time zone), at most 366 days after from"
+// @Success 200 {object} dto.SuccessResponse{data=dto.StatsResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/stats [get]
+// @Security BearerAuth
+func (h *TodoHandler) Stats(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	stats, err := h.todoService.GetStats(userID.(uint), c.Query("from"), c.Query("to"))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve stats")
+		return
+	}
+
+	response := dto.StatsResponse{
+		From:       stats.From,
+		To:         stats.To,
+		Total:      stats.Total,
+		ByStatus:   stats.ByStatus,
+		ByPriority: stats.ByPriority,
+		Overdue:    stats.Overdue,
+		Completion: dto.CompletionResponse{
+			Created:   stats.Completion.Created,
+			Completed: stats.Completion.Completed,
+		},
+		CycleTime: dto.CycleTimeResponse{
+			Count:          stats.CycleTime.Count,
+			AverageSeconds: stats.CycleTime.AverageSeconds,
+			MedianSeconds:  stats.CycleTime.MedianSeconds,
+		},
+		CompletedPerDay: make([]dto.DayCountResponse, len(stats.CompletedPerDay)),
+	}
+	if stats.Completion.Created > 0 {
+		response.Completion.Rate = float64(stats.Completion.Completed) / float64(stats.Completion.Created)
+	}
+	for i, day := range stats.CompletedPerDay {
+		response.CompletedPerDay[i] = dto.DayCountResponse{Day: day.Day, Count: day.Count}
+	}
+	if streak := stats.CurrentStreak; streak != nil {
+		response.Streaks.Current = dto.StreakResponse{Days: streak.Days, StartDay: streak.StartDay, EndDay: streak.EndDay}
+	}
+	if streak := stats.LongestStreak; streak != nil {
+		response.Streaks.Longest = dto.StreakResponse{Days: streak.Days, StartDay: streak.StartDay, EndDay: streak.EndDay}
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "stats_retrieved", response))
+}
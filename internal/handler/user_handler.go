@@ -1,10 +1,12 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
 	"rest-api/internal/middleware"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
 	"rest-api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -26,21 +28,18 @@ func NewUserHandler(authService *service.AuthService) *UserHandler {
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param user body dto.UserRegisterRequest true "User registration data"
+// @Param user body dto.RegisterRequest true "User registration data"
 // @Success 201 {object} dto.SuccessResponse{data=dto.UserResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /auth/register
+// @Failure 400 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/auth/register [post]
 func (h *UserHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
 
 	// parse  and validate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid Input",
-			Error:   err.Error(),
-		})
+		problem.Bind(c, err)
 		return
 	}
 
@@ -48,27 +47,12 @@ func (h *UserHandler) Register(c *gin.Context) {
 	user, err := h.authService.Register(req)
 	if err != nil {
 		// map error
-		statusCode := http.StatusInternalServerError
-		message := "Failed to register user"
-
-		if errors.Is(err, service.ErrUserExists) {
-			statusCode = http.StatusBadRequest
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-		})
+		problem.Error(c, err, "Failed to register user")
 		return
 	}
 
 	// tidak 500 dan req ok tidak bad request
-	c.JSON(http.StatusCreated, dto.SuccessResponse{
-		Success: true,
-		Message: "User registered successfully",
-		Data:    user,
-	})
+	render.Render(c, http.StatusCreated, i18n.Success(c, "user_registered", user))
 }
 
 // Login handles user login
@@ -77,49 +61,30 @@ func (h *UserHandler) Register(c *gin.Context) {
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param credentials body dto.UserLoginRequest true "Login credentials"
+// @Param credentials body dto.LoginRequest true "Login credentials"
 // @Success 200 {object} dto.SuccessResponse{data=dto.LoginResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /auth/login [post]
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/auth/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 
 	// Parse and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid input",
-			Error:   err.Error(),
-		})
+		problem.Bind(c, err)
 		return
 	}
 
 	// Call service
 	authResp, err := h.authService.Login(req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to login"
-
-		if errors.Is(err, service.ErrInvalidCredentials) {
-			statusCode = http.StatusUnauthorized
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-		})
+		problem.Error(c, err, "Failed to login")
 		return
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Login successful",
-		Data:    authResp,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "login_succeeded", authResp))
 }
 
 // GetProfile handles get user profile (requires auth)
@@ -130,10 +95,10 @@ func (h *UserHandler) Login(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /users/profile [get]
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/users/profile [get]
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	// Get user ID from JWT (set by auth middleware)
 	userID := middleware.GetUserID(c)
@@ -141,27 +106,12 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	// Call service
 	user, err := h.authService.GetProfile(userID)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to get profile"
-
-		if errors.Is(err, service.ErrUserNotFound) {
-			statusCode = http.StatusNotFound
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-		})
+		problem.Error(c, err, "Failed to get profile")
 		return
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Profile retrieved successfully",
-		Data:    user,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "profile_retrieved", user))
 }
 
 // UpdateProfile handles update user profile (requires auth)
@@ -173,11 +123,12 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 // @Security BearerAuth
 // @Param profile body dto.UserUpdateRequest true "Profile update data"
 // @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /users/profile [put]
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/users/profile [put]
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	// Get user ID from JWT
 	userID := middleware.GetUserID(c)
@@ -186,41 +137,19 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	// Parse and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Success: false,
-			Message: "Invalid input",
-			Error:   err.Error(),
-		})
+		problem.Bind(c, err)
 		return
 	}
 
 	// Call service
 	user, err := h.authService.UpdateProfile(userID, req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Failed to update profile"
-
-		if errors.Is(err, service.ErrUserNotFound) {
-			statusCode = http.StatusNotFound
-			message = err.Error()
-		} else if errors.Is(err, service.ErrUserExists) {
-			statusCode = http.StatusBadRequest
-			message = err.Error()
-		}
-
-		c.JSON(statusCode, dto.ErrorResponse{
-			Success: false,
-			Message: message,
-		})
+		problem.Error(c, err, "Failed to update profile")
 		return
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Success: true,
-		Message: "Profile updated successfully",
-		Data:    user,
-	})
+	render.Render(c, http.StatusOK, i18n.Success(c, "profile_updated", user))
 }
 
 // ResetPasswordRequest initiates a reset email
@@ -231,14 +160,14 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 // @Produce json
 // @Param body body dto.ResetPasswordRequest true "Email"
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /auth/reset-password [post]
+// @Failure 400 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/auth/reset-password [post]
 func (h *UserHandler) ResetPasswordRequest(c *gin.Context) {
 	var req dto.ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Success: false, Message: "Invalid input", Error: err.Error()})
+		problem.Bind(c, err)
 		return
 	}
 
@@ -250,17 +179,11 @@ func (h *UserHandler) ResetPasswordRequest(c *gin.Context) {
 	}
 
 	if err := h.authService.RequestPasswordReset(req.Email, cfgServerPort); err != nil {
-		status := http.StatusInternalServerError
-		msg := "Failed to request password reset"
-		if errors.Is(err, service.ErrUserNotFound) {
-			status = http.StatusNotFound
-			msg = err.Error()
-		}
-		c.JSON(status, dto.ErrorResponse{Success: false, Message: msg})
+		problem.Error(c, err, "Failed to request password reset")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{Success: true, Message: "If the email exists, a reset link was sent"})
+	render.Render(c, http.StatusOK, i18n.Success(c, "password_reset_requested", nil))
 }
 
 // ResetPasswordConfirm completes the reset using token and new password
@@ -271,27 +194,21 @@ func (h *UserHandler) ResetPasswordRequest(c *gin.Context) {
 // @Produce json
 // @Param body body dto.ResetPasswordConfirmRequest true "Token and new password"
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /auth/reset-password/confirm [post]
+// @Failure 400 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/auth/reset-password/confirm [post]
 func (h *UserHandler) ResetPasswordConfirm(c *gin.Context) {
 	var req dto.ResetPasswordConfirmRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Success: false, Message: "Invalid input", Error: err.Error()})
+		problem.Bind(c, err)
 		return
 	}
 
 	if err := h.authService.ResetPassword(req.Token, req.NewPassword); err != nil {
-		status := http.StatusInternalServerError
-		msg := "Failed to reset password"
-		if errors.Is(err, service.ErrUserNotFound) {
-			status = http.StatusNotFound
-			msg = err.Error()
-		}
-		c.JSON(status, dto.ErrorResponse{Success: false, Message: msg, Error: err.Error()})
+		problem.Error(c, err, "Failed to reset password")
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{Success: true, Message: "Password reset successful"})
+	render.Render(c, http.StatusOK, i18n.Success(c, "password_reset", nil))
 }
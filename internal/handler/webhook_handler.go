@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook HTTP requests
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// List handles GET /api/v1/webhooks
+// @Summary List webhooks
+// @Description List the webhooks of the authenticated user. Secrets are not included.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.WebhookResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks [get]
+// @Security BearerAuth
+func (h *WebhookHandler) List(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve webhooks")
+		return
+	}
+
+	responses := make([]dto.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		responses[i] = toWebhookResponse(&webhooks[i], false)
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhooks_retrieved", responses))
+}
+
+// Get handles GET /api/v1/webhooks/:id
+// @Summary Get a webhook
+// @Description Get a webhook of the authenticated user without its secret
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id} [get]
+// @Security BearerAuth
+func (h *WebhookHandler) Get(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(webhookID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve webhook")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhook_retrieved", toWebhookResponse(webhook, false)))
+}
+
+// Create handles POST /api/v1/webhooks
+// @Summary Create a webhook
+// @Description Register a URL that receives the selected todo events as signed JSON POST requests. Each request carries X-Webhook-ID (the event ID, the same on retries and redeliveries), X-Webhook-Event, X-Webhook-Timestamp (Unix seconds) and X-Webhook-Signature, which is sha256= followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. Failed deliveries are retried with exponential backoff and end up dead after 10 attempts. The secret is only returned here and when it is replaced.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body dto.CreateWebhookRequest true "Webhook URL, events and optional secret"
+// @Success 201 {object} dto.SuccessResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks [post]
+// @Security BearerAuth
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to create webhook")
+		return
+	}
+
+	render.Render(c, http.StatusCreated, i18n.Success(c, "webhook_created", toWebhookResponse(webhook, true)))
+}
+
+// Update handles PUT /api/v1/webhooks/:id
+// @Summary Update a webhook
+// @Description Change the URL, events or secret of a webhook, or pause it with active false. Deliveries queued while a webhook is paused are dead and can be redelivered.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body dto.UpdateWebhookRequest true "Webhook changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id} [put]
+// @Security BearerAuth
+func (h *WebhookHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(webhookID, userID.(uint), req)
+	if err != nil {
+		problem.Error(c, err, "Failed to update webhook")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhook_updated", toWebhookResponse(webhook, req.Secret != nil)))
+}
+
+// Delete handles DELETE /api/v1/webhooks/:id
+// @Summary Delete a webhook
+// @Description Delete a webhook with its delivery log. Queued deliveries are dropped.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id} [delete]
+// @Security BearerAuth
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(webhookID, userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to delete webhook")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhook_deleted", nil))
+}
+
+// Ping handles POST /api/v1/webhooks/:id/ping
+// @Summary Send a test ping
+// @Description Send a signed ping event to a webhook right away, also when it is paused, and return the logged delivery with the response status. Failed pings are not retried.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id}/ping [post]
+// @Security BearerAuth
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.Ping(webhookID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to ping webhook")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhook_pinged", toWebhookDeliveryResponse(delivery)))
+}
+
+// Deliveries handles GET /api/v1/webhooks/:id/deliveries
+// @Summary List webhook deliveries
+// @Description List the latest 100 deliveries of a webhook, newest first, with their status, attempts and the response of the last attempt
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.WebhookDeliveryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+// @Security BearerAuth
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(webhookID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = toWebhookDeliveryResponse(&deliveries[i])
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "webhook_deliveries_retrieved", responses))
+}
+
+// Redeliver handles POST /api/v1/webhooks/:id/deliveries/:delivery_id/redeliver
+// @Summary Redeliver a webhook delivery
+// @Description Queue a delivery again, typically a dead one, with the same event ID and payload. The new delivery is attempted right away and retried like any other.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 202 {object} dto.SuccessResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 404 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+// @Security BearerAuth
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, ok := parseTodoID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseTodoID(c, "delivery_id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(webhookID, deliveryID, userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to redeliver webhook delivery")
+		return
+	}
+
+	render.Render(c, http.StatusAccepted, i18n.Success(c, "webhook_redelivery_queued", toWebhookDeliveryResponse(delivery)))
+}
+
+// toWebhookResponse converts a webhook to its response DTO, with the secret
+// only when it was just set
+func toWebhookResponse(webhook *model.Webhook, withSecret bool) dto.WebhookResponse {
+	response := dto.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    service.WebhookEvents(webhook),
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+	if withSecret {
+		response.Secret = webhook.Secret
+	}
+	return response
+}
+
+// toWebhookDeliveryResponse converts a delivery to its response DTO
+func toWebhookDeliveryResponse(delivery *model.WebhookDelivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
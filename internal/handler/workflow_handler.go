@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkflowHandler handles status workflow HTTP requests
+type WorkflowHandler struct {
+	workflowService *service.WorkflowService
+}
+
+// NewWorkflowHandler creates a new workflow handler instance
+func NewWorkflowHandler(workflowService *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// Get handles GET /api/v1/workflow
+// @Summary Get status workflow
+// @Description Get the todo statuses and allowed transitions of the authenticated user's workspace
+// @Tags workflow
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.WorkflowResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/workflow [get]
+// @Security BearerAuth
+func (h *WorkflowHandler) Get(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	workflow, stored, err := h.workflowService.GetWorkflow(userID.(uint))
+	if err != nil {
+		problem.Error(c, err, "Failed to retrieve workflow")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "workflow_retrieved", toWorkflowResponse(workflow, stored)))
+}
+
+// Update handles PUT /api/v1/workflow
+// @Summary Replace status workflow
+// @Description Define custom todo statuses (each in the todo, in_progress or done category), the allowed transitions and the initial status. Statuses still used by todos cannot be removed.
+// @Tags workflow
+// @Accept json
+// @Produce json
+// @Param workflow body dto.WorkflowRequest true "Workflow definition"
+// @Success 200 {object} dto.SuccessResponse{data=dto.WorkflowResponse}
+// @Failure 400 {object} dto.Problem
+// @Failure 401 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/workflow [put]
+// @Security BearerAuth
+func (h *WorkflowHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	var req dto.WorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Bind(c, err)
+		return
+	}
+
+	workflow := &service.Workflow{
+		Statuses:            make([]service.WorkflowStatus, len(req.Statuses)),
+		Transitions:         req.Transitions,
+		Initial:             req.Initial,
+		EnforceDependencies: req.EnforceDependencies,
+	}
+	for i, status := range req.Statuses {
+		workflow.Statuses[i] = service.WorkflowStatus(status)
+	}
+	if workflow.Transitions == nil {
+		workflow.Transitions = map[string][]string{}
+	}
+
+	stored, err := h.workflowService.UpdateWorkflow(userID.(uint), workflow)
+	if err != nil {
+		problem.Error(c, err, "Failed to update workflow")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "workflow_updated", toWorkflowResponse(workflow, stored)))
+}
+
+// Reset handles DELETE /api/v1/workflow
+// @Summary Reset status workflow
+// @Description Switch back to the default pending/in_progress/completed workflow
+// @Tags workflow
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.WorkflowResponse}
+// @Failure 401 {object} dto.Problem
+// @Failure 409 {object} dto.Problem
+// @Failure 500 {object} dto.Problem
+// @Router /api/v1/workflow [delete]
+// @Security BearerAuth
+func (h *WorkflowHandler) Reset(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		problem.Unauthorized(c)
+		return
+	}
+
+	if err := h.workflowService.ResetWorkflow(userID.(uint)); err != nil {
+		problem.Error(c, err, "Failed to reset workflow")
+		return
+	}
+
+	render.Render(c, http.StatusOK, i18n.Success(c, "workflow_reset", toWorkflowResponse(service.DefaultWorkflow(), nil)))
+}
+
+// toWorkflowResponse converts a workflow to its response DTO
+func toWorkflowResponse(workflow *service.Workflow, stored *model.Workflow) dto.WorkflowResponse {
+	response := dto.WorkflowResponse{
+		Statuses:            make([]dto.WorkflowStatus, len(workflow.Statuses)),
+		Transitions:         workflow.Transitions,
+		Initial:             workflow.Initial,
+		EnforceDependencies: workflow.EnforceDependencies,
+		IsDefault:           stored == nil,
+	}
+	for i, status := range workflow.Statuses {
+		response.Statuses[i] = dto.WorkflowStatus(status)
+	}
+	if stored != nil {
+		response.UpdatedAt = &stored.UpdatedAt
+	}
+	return response
+}
@@ -0,0 +1,154 @@
+// Package i18n holds the message catalog of the API. Messages are keyed by
+// the stable codes of success and error responses, so clients can switch on
+// the code while users read the message in their own language.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"rest-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale is a supported language
+type Locale string
+
+// Supported locales
+const (
+	English    Locale = "en"
+	Indonesian Locale = "id"
+)
+
+// Default is used when nothing the client accepts is supported
+const Default = English
+
+// ContextKey is the gin context key of the negotiated locale
+const ContextKey = "locale"
+
+var catalogs = map[Locale]map[string]string{
+	English:    english,
+	Indonesian: indonesian,
+}
+
+// Locales returns the supported locales
+func Locales() []Locale {
+	return []Locale{English, Indonesian}
+}
+
+// Parse returns the supported locale of a language tag such as id-ID
+func Parse(tag string) (Locale, bool) {
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	locale := Locale(strings.ToLower(primary))
+	_, ok := catalogs[locale]
+	return locale, ok
+}
+
+// Negotiate picks the supported locale with the highest quality from an
+// Accept-Language header, the default when none is supported
+func Negotiate(acceptLanguage string) Locale {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		// Earlier tags win ties
+		if locale, ok := Parse(tag); ok && q > bestQ {
+			best, bestQ = locale, q
+		}
+	}
+	return best
+}
+
+// Resolver picks the locale of a request. The locale middleware stores one
+// instead of a locale so the user preference is read only once the user is
+// known and a message is actually written.
+type Resolver func(c *gin.Context) Locale
+
+// FromContext returns the locale of a request, resolving it on first use and
+// announcing it in Content-Language. Without the locale middleware the
+// Accept-Language header decides.
+func FromContext(c *gin.Context) Locale {
+	switch value := c.Value(ContextKey).(type) {
+	case Locale:
+		return value
+	case Resolver:
+		locale := value(c)
+		c.Set(ContextKey, locale)
+		c.Header("Content-Language", string(locale))
+		return locale
+	}
+	return Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// Has reports whether a locale has a message for key
+func Has(locale Locale, key string) bool {
+	_, ok := catalogs[locale][key]
+	return ok
+}
+
+// Keys returns the message keys of a locale, sorted
+func Keys(locale Locale) []string {
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// T returns the message of key in locale, falling back to English and then to
+// the key itself. {name} placeholders are replaced by params; a string param
+// with a message of its own, like types.string, is translated, and a list
+// param is translated item by item and joined with "or".
+func T(locale Locale, key string, params map[string]interface{}) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		if message, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", formatParam(locale, name, value))
+	}
+	return message
+}
+
+// formatParam writes a param in locale
+func formatParam(locale Locale, name string, value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		if translated, ok := catalogs[locale][name+"."+value]; ok {
+			return translated
+		}
+		return value
+	case []string:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = formatParam(locale, name, item)
+		}
+		return strings.Join(items, T(locale, "list.or", nil))
+	}
+	return fmt.Sprint(value)
+}
+
+// Success is the response envelope of a successful request, with the
+// message of code in the locale of the request
+func Success(c *gin.Context, code string, data interface{}) dto.SuccessResponse {
+	return dto.SuccessResponse{
+		Success: true,
+		Code:    code,
+		Message: T(FromContext(c), "success."+code, nil),
+		Data:    data,
+	}
+}
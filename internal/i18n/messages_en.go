@@ -0,0 +1,207 @@
+package i18n
+
+// english is the catalog of the default locale. Every key here must also be
+// in the other catalogs.
+var english = map[string]string{
+	// Success responses
+	"success.user_registered":          "User registered successfully",
+	"success.login_succeeded":          "Login successful",
+	"success.profile_retrieved":        "Profile retrieved successfully",
+	"success.profile_updated":          "Profile updated successfully",
+	"success.password_reset_requested": "If the email exists, a reset link was sent",
+	"success.password_reset":           "Password reset successful",
+
+	"success.todos_retrieved":      "Todos retrieved successfully",
+	"success.todo_retrieved":       "Todo retrieved successfully",
+	"success.todo_created":         "Todo created successfully",
+	"success.todo_updated":         "Todo updated successfully",
+	"success.todo_deleted":         "Todo deleted successfully",
+	"success.todo_moved":           "Todo moved successfully",
+	"success.todo_parsed":          "Todo parsed, nothing was created",
+	"success.next_todos_retrieved": "Next todos retrieved successfully",
+	"success.board_retrieved":      "Board retrieved successfully",
+	"success.stats_retrieved":      "Stats retrieved successfully",
+
+	"success.bulk_completed":        "Bulk operations completed successfully",
+	"success.bulk_partially_failed": "Some bulk operations failed",
+
+	"success.workflow_retrieved": "Workflow retrieved successfully",
+	"success.workflow_updated":   "Workflow updated successfully",
+	"success.workflow_reset":     "Workflow reset to default",
+
+	"success.custom_fields_retrieved": "Custom fields retrieved successfully",
+	"success.custom_field_created":    "Custom field created successfully",
+	"success.custom_field_updated":    "Custom field updated successfully",
+	"success.custom_field_deleted":    "Custom field deleted successfully",
+
+	"success.dependencies_retrieved": "Dependencies retrieved successfully",
+	"success.dependency_added":       "Dependency added successfully",
+	"success.dependency_removed":     "Dependency removed successfully",
+
+	"success.timer_retrieved":        "Timer retrieved successfully",
+	"success.timer_started":          "Timer started successfully",
+	"success.timer_stopped":          "Timer stopped successfully",
+	"success.time_entries_retrieved": "Time entries retrieved successfully",
+	"success.time_entry_added":       "Time entry added successfully",
+	"success.time_entry_deleted":     "Time entry deleted successfully",
+	"success.time_summary_retrieved": "Time summary retrieved successfully",
+
+	"success.views_retrieved": "Views retrieved successfully",
+	"success.view_retrieved":  "View retrieved successfully",
+	"success.view_saved":      "View saved successfully",
+	"success.view_updated":    "View updated successfully",
+	"success.view_deleted":    "View deleted successfully",
+
+	"success.templates_retrieved":   "Templates retrieved successfully",
+	"success.template_retrieved":    "Template retrieved successfully",
+	"success.template_created":      "Template created successfully",
+	"success.template_updated":      "Template updated successfully",
+	"success.template_deleted":      "Template deleted successfully",
+	"success.template_instantiated": "Template instantiated successfully",
+
+	"success.todos_imported":       "Todos imported",
+	"success.import_validated":     "Import validated, nothing was created",
+	"success.import_started":       "Import started",
+	"success.import_job_retrieved": "Import job retrieved successfully",
+
+	"success.calendar_feed_retrieved":   "Calendar feed retrieved successfully",
+	"success.calendar_feed_regenerated": "Calendar feed regenerated successfully",
+
+	"success.webhooks_retrieved":           "Webhooks retrieved successfully",
+	"success.webhook_retrieved":            "Webhook retrieved successfully",
+	"success.webhook_created":              "Webhook created successfully",
+	"success.webhook_updated":              "Webhook updated successfully",
+	"success.webhook_deleted":              "Webhook deleted successfully",
+	"success.webhook_pinged":               "Ping sent",
+	"success.webhook_deliveries_retrieved": "Webhook deliveries retrieved successfully",
+	"success.webhook_redelivery_queued":    "Redelivery queued",
+
+	// Problems that are not caused by a service error
+	"error.unauthorized":            "User ID not found in context",
+	"error.missing_token":           "Token not found",
+	"error.malformed_token":         "Invalid token format",
+	"error.invalid_token":           "Token is invalid or expired",
+	"error.validation_failed":       "Invalid request data",
+	"error.unsupported_media_type":  "Unsupported media type, send application/json, application/msgpack, application/cbor or application/xml",
+	"error.not_acceptable":          "None of the accepted media types can be produced, accept application/json, application/msgpack, application/cbor or application/xml",
+	"error.unreadable_body":         "Failed to read request body",
+	"error.request_too_large":       "Request body is too large",
+	"error.invalid_id":              "Invalid ID",
+	"error.invalid_idempotency_key": "Idempotency-Key must be at most 255 characters",
+	"error.idempotency_key_reused":  "Idempotency-Key was already used with a different request",
+	"error.request_in_progress":     "A request with this Idempotency-Key is still being processed",
+	"error.response_mismatch":       "Response does not match the OpenAPI document",
+	"error.internal_error":          "Something went wrong, please try again later",
+	"error.not_found":               "Resource not found",
+
+	// GraphQL errors
+	"error.query_too_deep":    "Query is nested {depth} levels deep, at most {max} are allowed",
+	"error.query_too_complex": "Query has a complexity of {complexity}, at most {max} is allowed",
+
+	// Service errors
+	"error.user_exists":         "username already exists",
+	"error.email_exists":        "email already exists",
+	"error.invalid_credentials": "invalid username or password",
+	"error.user_not_found":      "user not found",
+	"error.invalid_time_zone":   "invalid time zone, use an IANA name such as Asia/Jakarta",
+	"error.invalid_reset_token": "reset token expired or invalid",
+
+	"error.todo_not_found":         "todo not found",
+	"error.forbidden":              "unauthorized access to todo",
+	"error.invalid_status":         "invalid status value",
+	"error.invalid_priority":       "invalid priority value",
+	"error.invalid_title":          "title is required and must be at most 200 characters",
+	"error.invalid_due_date":       "invalid due date, use YYYY-MM-DD or RFC 3339 date-time",
+	"error.invalid_tag":            "tags must be 1-50 characters without spaces, at most 20 per todo",
+	"error.invalid_estimate":       "estimate must be between 1 and 100000 minutes",
+	"error.invalid_recurrence":     "invalid recurrence, use an RRULE like FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+	"error.invalid_sort":           "invalid sort, use position, created_at, updated_at, due_date, title, priority or cf.<key>",
+	"error.invalid_due_filter":     "invalid due filter, use overdue, today, tomorrow, this_week, next_7_days, next_30_days or none",
+	"error.invalid_move":           "invalid move, give before_id or after_id of another todo",
+	"error.invalid_bulk_operation": "invalid bulk operation",
+	"error.bulk_rolled_back":       "operation rolled back because another operation failed",
+	"error.invalid_stats_range":    "invalid range, use YYYY-MM-DD dates at most 366 days apart",
+
+	"error.invalid_transition":     "status transition is not allowed by the workflow",
+	"error.todo_blocked":           "todo is blocked by unfinished todos",
+	"error.invalid_workflow":       "invalid workflow",
+	"error.workflow_status_in_use": "workflow removes a status that is still used by todos",
+
+	"error.custom_field_not_found":          "custom field not found",
+	"error.custom_field_exists":             "custom field key already exists",
+	"error.invalid_custom_field_definition": "invalid custom field definition",
+	"error.invalid_custom_field":            "invalid custom field value",
+
+	"error.dependency_not_found": "dependency not found",
+	"error.dependency_exists":    "dependency already exists",
+	"error.dependency_cycle":     "dependency would create a cycle",
+
+	"error.time_entry_not_found": "time entry not found",
+	"error.no_timer_running":     "no timer is running",
+	"error.timer_running":        "a timer is already running, stop it first",
+	"error.invalid_time_entry":   "invalid time entry, use RFC 3339 start times and YYYY-MM-DD dates",
+
+	"error.saved_view_not_found": "saved view not found",
+	"error.saved_view_exists":    "a saved view with this name already exists",
+	"error.invalid_saved_view":   "invalid saved view",
+	"error.template_not_found":   "template not found",
+	"error.template_exists":      "a template with this name already exists",
+	"error.invalid_template":     "invalid template",
+
+	"error.unsupported_import_format": "unsupported import format",
+	"error.invalid_import_file":       "invalid import file",
+	"error.invalid_import_mapping":    "invalid column mapping",
+	"error.import_job_not_found":      "import job not found",
+	"error.calendar_feed_not_found":   "calendar feed not found",
+	"error.invalid_calendar_type":     "invalid calendar type, use event, todo or both",
+
+	"error.webhook_not_found":          "webhook not found",
+	"error.webhook_delivery_not_found": "webhook delivery not found",
+	"error.invalid_webhook":            "invalid webhook",
+	"error.webhook_url_not_allowed":    "webhook URL must not point to a loopback, private or link-local address",
+
+	// Field errors
+	"field.required":                "is required",
+	"field.required.body":           "request body is required",
+	"field.invalid_type":            "must be {types}",
+	"field.invalid_json":            "invalid JSON: {error}",
+	"field.invalid_body":            "invalid {format}: {error}",
+	"field.invalid_value":           "is invalid ({error})",
+	"field.invalid_choice":          "must be one of {options}",
+	"field.invalid_format":          "must be {format}",
+	"field.too_short":               "must be at least {min} characters",
+	"field.too_long":                "must be at most {max} characters",
+	"field.too_small":               "must be at least {min}",
+	"field.too_large":               "must be at most {max}",
+	"field.too_few_items":           "must have at least {min} items",
+	"field.too_many_items":          "must have at most {max} items",
+	"field.undocumented_status":     "status {status} is not documented",
+	"field.undocumented_media_type": "status {status} is not documented as {type}",
+
+	"types.string":  "a string",
+	"types.integer": "an integer",
+	"types.number":  "a number",
+	"types.boolean": "a boolean",
+	"types.array":   "an array",
+	"types.object":  "an object",
+	"types.null":    "null",
+
+	"format.email":     "a valid email address",
+	"format.date-time": "an RFC 3339 date-time",
+	"format.uri":       "a valid URL",
+
+	"list.or": " or ",
+
+	// Problem titles
+	"status.400": "Bad Request",
+	"status.401": "Unauthorized",
+	"status.403": "Forbidden",
+	"status.404": "Not Found",
+	"status.409": "Conflict",
+	"status.413": "Payload Too Large",
+	"status.406": "Not Acceptable",
+	"status.415": "Unsupported Media Type",
+	"status.422": "Unprocessable Entity",
+	"status.424": "Failed Dependency",
+	"status.500": "Internal Server Error",
+}
@@ -0,0 +1,206 @@
+package i18n
+
+// indonesian is the catalog of Bahasa Indonesia
+var indonesian = map[string]string{
+	// Success responses
+	"success.user_registered":          "User berhasil didaftarkan",
+	"success.login_succeeded":          "Login berhasil",
+	"success.profile_retrieved":        "Profil berhasil diambil",
+	"success.profile_updated":          "Profil berhasil diperbarui",
+	"success.password_reset_requested": "Jika email terdaftar, link reset sudah dikirim",
+	"success.password_reset":           "Password berhasil direset",
+
+	"success.todos_retrieved":      "Todo berhasil diambil",
+	"success.todo_retrieved":       "Todo berhasil diambil",
+	"success.todo_created":         "Todo berhasil dibuat",
+	"success.todo_updated":         "Todo berhasil diperbarui",
+	"success.todo_deleted":         "Todo berhasil dihapus",
+	"success.todo_moved":           "Todo berhasil dipindahkan",
+	"success.todo_parsed":          "Todo berhasil diurai, tidak ada yang dibuat",
+	"success.next_todos_retrieved": "Todo berikutnya berhasil diambil",
+	"success.board_retrieved":      "Board berhasil diambil",
+	"success.stats_retrieved":      "Statistik berhasil diambil",
+
+	"success.bulk_completed":        "Semua operasi bulk berhasil",
+	"success.bulk_partially_failed": "Sebagian operasi bulk gagal",
+
+	"success.workflow_retrieved": "Workflow berhasil diambil",
+	"success.workflow_updated":   "Workflow berhasil diperbarui",
+	"success.workflow_reset":     "Workflow dikembalikan ke default",
+
+	"success.custom_fields_retrieved": "Custom field berhasil diambil",
+	"success.custom_field_created":    "Custom field berhasil dibuat",
+	"success.custom_field_updated":    "Custom field berhasil diperbarui",
+	"success.custom_field_deleted":    "Custom field berhasil dihapus",
+
+	"success.dependencies_retrieved": "Dependensi berhasil diambil",
+	"success.dependency_added":       "Dependensi berhasil ditambahkan",
+	"success.dependency_removed":     "Dependensi berhasil dihapus",
+
+	"success.timer_retrieved":        "Timer berhasil diambil",
+	"success.timer_started":          "Timer berhasil dimulai",
+	"success.timer_stopped":          "Timer berhasil dihentikan",
+	"success.time_entries_retrieved": "Catatan waktu berhasil diambil",
+	"success.time_entry_added":       "Catatan waktu berhasil ditambahkan",
+	"success.time_entry_deleted":     "Catatan waktu berhasil dihapus",
+	"success.time_summary_retrieved": "Ringkasan waktu berhasil diambil",
+
+	"success.views_retrieved": "View berhasil diambil",
+	"success.view_retrieved":  "View berhasil diambil",
+	"success.view_saved":      "View berhasil disimpan",
+	"success.view_updated":    "View berhasil diperbarui",
+	"success.view_deleted":    "View berhasil dihapus",
+
+	"success.templates_retrieved":   "Template berhasil diambil",
+	"success.template_retrieved":    "Template berhasil diambil",
+	"success.template_created":      "Template berhasil dibuat",
+	"success.template_updated":      "Template berhasil diperbarui",
+	"success.template_deleted":      "Template berhasil dihapus",
+	"success.template_instantiated": "Todo berhasil dibuat dari template",
+
+	"success.todos_imported":       "Todo berhasil diimpor",
+	"success.import_validated":     "Impor valid, tidak ada yang dibuat",
+	"success.import_started":       "Impor dimulai",
+	"success.import_job_retrieved": "Status impor berhasil diambil",
+
+	"success.calendar_feed_retrieved":   "Feed kalender berhasil diambil",
+	"success.calendar_feed_regenerated": "Feed kalender berhasil dibuat ulang",
+
+	"success.webhooks_retrieved":           "Webhook berhasil diambil",
+	"success.webhook_retrieved":            "Webhook berhasil diambil",
+	"success.webhook_created":              "Webhook berhasil dibuat",
+	"success.webhook_updated":              "Webhook berhasil diperbarui",
+	"success.webhook_deleted":              "Webhook berhasil dihapus",
+	"success.webhook_pinged":               "Ping terkirim",
+	"success.webhook_deliveries_retrieved": "Pengiriman webhook berhasil diambil",
+	"success.webhook_redelivery_queued":    "Pengiriman ulang dijadwalkan",
+
+	// Problems that are not caused by a service error
+	"error.unauthorized":            "User ID tidak ditemukan di context",
+	"error.missing_token":           "Token tidak ditemukan",
+	"error.malformed_token":         "Format token tidak valid",
+	"error.invalid_token":           "Token tidak valid atau expired",
+	"error.validation_failed":       "Data request tidak valid",
+	"error.unsupported_media_type":  "Media type tidak didukung, kirim application/json, application/msgpack, application/cbor atau application/xml",
+	"error.not_acceptable":          "Tidak ada media type yang diterima yang dapat dihasilkan, terima application/json, application/msgpack, application/cbor atau application/xml",
+	"error.unreadable_body":         "Gagal membaca body request",
+	"error.request_too_large":       "Body request terlalu besar",
+	"error.invalid_id":              "ID tidak valid",
+	"error.invalid_idempotency_key": "Idempotency-Key maksimal 255 karakter",
+	"error.idempotency_key_reused":  "Idempotency-Key sudah dipakai untuk request yang berbeda",
+	"error.request_in_progress":     "Request dengan Idempotency-Key ini masih diproses",
+	"error.response_mismatch":       "Response tidak sesuai dengan dokumen OpenAPI",
+	"error.internal_error":          "Terjadi kesalahan, silakan coba lagi nanti",
+	"error.not_found":               "Data tidak ditemukan",
+
+	// GraphQL errors
+	"error.query_too_deep":    "Query bersarang {depth} level, maksimal {max} level",
+	"error.query_too_complex": "Kompleksitas query {complexity}, maksimal {max}",
+
+	// Service errors
+	"error.user_exists":         "username sudah dipakai",
+	"error.email_exists":        "email sudah dipakai",
+	"error.invalid_credentials": "username atau password salah",
+	"error.user_not_found":      "user tidak ditemukan",
+	"error.invalid_time_zone":   "zona waktu tidak valid, gunakan nama IANA seperti Asia/Jakarta",
+	"error.invalid_reset_token": "token reset expired atau tidak valid",
+
+	"error.todo_not_found":         "todo tidak ditemukan",
+	"error.forbidden":              "tidak punya akses ke todo ini",
+	"error.invalid_status":         "nilai status tidak valid",
+	"error.invalid_priority":       "nilai prioritas tidak valid",
+	"error.invalid_title":          "judul wajib diisi dan maksimal 200 karakter",
+	"error.invalid_due_date":       "tenggat tidak valid, gunakan YYYY-MM-DD atau date-time RFC 3339",
+	"error.invalid_tag":            "tag harus 1-50 karakter tanpa spasi, maksimal 20 per todo",
+	"error.invalid_estimate":       "estimasi harus antara 1 dan 100000 menit",
+	"error.invalid_recurrence":     "pengulangan tidak valid, gunakan RRULE seperti FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+	"error.invalid_sort":           "urutan tidak valid, gunakan position, created_at, updated_at, due_date, title, priority atau cf.<key>",
+	"error.invalid_due_filter":     "filter tenggat tidak valid, gunakan overdue, today, tomorrow, this_week, next_7_days, next_30_days atau none",
+	"error.invalid_move":           "pemindahan tidak valid, isi before_id atau after_id dengan todo lain",
+	"error.invalid_bulk_operation": "operasi bulk tidak valid",
+	"error.bulk_rolled_back":       "operasi dibatalkan karena operasi lain gagal",
+	"error.invalid_stats_range":    "rentang tidak valid, gunakan tanggal YYYY-MM-DD dengan jarak maksimal 366 hari",
+
+	"error.invalid_transition":     "perubahan status tidak diizinkan oleh workflow",
+	"error.todo_blocked":           "todo masih diblokir oleh todo yang belum selesai",
+	"error.invalid_workflow":       "workflow tidak valid",
+	"error.workflow_status_in_use": "workflow menghapus status yang masih dipakai todo",
+
+	"error.custom_field_not_found":          "custom field tidak ditemukan",
+	"error.custom_field_exists":             "key custom field sudah dipakai",
+	"error.invalid_custom_field_definition": "definisi custom field tidak valid",
+	"error.invalid_custom_field":            "nilai custom field tidak valid",
+
+	"error.dependency_not_found": "dependensi tidak ditemukan",
+	"error.dependency_exists":    "dependensi sudah ada",
+	"error.dependency_cycle":     "dependensi akan membuat siklus",
+
+	"error.time_entry_not_found": "catatan waktu tidak ditemukan",
+	"error.no_timer_running":     "tidak ada timer yang berjalan",
+	"error.timer_running":        "timer masih berjalan, hentikan dulu",
+	"error.invalid_time_entry":   "catatan waktu tidak valid, gunakan waktu mulai RFC 3339 dan tanggal YYYY-MM-DD",
+
+	"error.saved_view_not_found": "view tidak ditemukan",
+	"error.saved_view_exists":    "view dengan nama ini sudah ada",
+	"error.invalid_saved_view":   "view tidak valid",
+	"error.template_not_found":   "template tidak ditemukan",
+	"error.template_exists":      "template dengan nama ini sudah ada",
+	"error.invalid_template":     "template tidak valid",
+
+	"error.unsupported_import_format": "format impor tidak didukung",
+	"error.invalid_import_file":       "file impor tidak valid",
+	"error.invalid_import_mapping":    "pemetaan kolom tidak valid",
+	"error.import_job_not_found":      "status impor tidak ditemukan",
+	"error.calendar_feed_not_found":   "feed kalender tidak ditemukan",
+	"error.invalid_calendar_type":     "tipe kalender tidak valid, gunakan event, todo atau both",
+
+	"error.webhook_not_found":          "webhook tidak ditemukan",
+	"error.webhook_delivery_not_found": "pengiriman webhook tidak ditemukan",
+	"error.invalid_webhook":            "webhook tidak valid",
+	"error.webhook_url_not_allowed":    "URL webhook tidak boleh mengarah ke alamat loopback, privat atau link-local",
+
+	// Field errors
+	"field.required":                "wajib diisi",
+	"field.required.body":           "body request wajib diisi",
+	"field.invalid_type":            "harus berupa {types}",
+	"field.invalid_json":            "JSON tidak valid: {error}",
+	"field.invalid_body":            "{format} tidak valid: {error}",
+	"field.invalid_value":           "tidak valid ({error})",
+	"field.invalid_choice":          "harus salah satu dari {options}",
+	"field.invalid_format":          "harus berupa {format}",
+	"field.too_short":               "minimal {min} karakter",
+	"field.too_long":                "maksimal {max} karakter",
+	"field.too_small":               "minimal {min}",
+	"field.too_large":               "maksimal {max}",
+	"field.too_few_items":           "minimal {min} item",
+	"field.too_many_items":          "maksimal {max} item",
+	"field.undocumented_status":     "status {status} tidak ada di dokumen",
+	"field.undocumented_media_type": "status {status} tidak didokumentasikan sebagai {type}",
+
+	"types.string":  "string",
+	"types.integer": "bilangan bulat",
+	"types.number":  "angka",
+	"types.boolean": "boolean",
+	"types.array":   "array",
+	"types.object":  "objek",
+	"types.null":    "null",
+
+	"format.email":     "alamat email yang valid",
+	"format.date-time": "date-time RFC 3339",
+	"format.uri":       "URL yang valid",
+
+	"list.or": " atau ",
+
+	// Problem titles
+	"status.400": "Request Tidak Valid",
+	"status.401": "Tidak Terautentikasi",
+	"status.403": "Akses Ditolak",
+	"status.404": "Tidak Ditemukan",
+	"status.409": "Konflik",
+	"status.413": "Payload Terlalu Besar",
+	"status.406": "Tidak Dapat Diterima",
+	"status.415": "Media Type Tidak Didukung",
+	"status.422": "Tidak Dapat Diproses",
+	"status.424": "Dependensi Gagal",
+	"status.500": "Kesalahan Server",
+}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 	"strings"
 
-	"rest-api/internal/dto"
+	"rest-api/internal/problem"
 	"rest-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -16,22 +16,14 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Ambil token dari header Authorization
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Success: false,
-				Message: "Token tidak ditemukan",
-			})
-			c.Abort()
+			problem.Abort(c, http.StatusUnauthorized, problem.CodeMissingToken)
 			return
 		}
 
 		// Format: Bearer <token>
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Success: false,
-				Message: "Format token tidak valid",
-			})
-			c.Abort()
+			problem.Abort(c, http.StatusUnauthorized, problem.CodeMalformedToken)
 			return
 		}
 
@@ -40,12 +32,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Validasi token
 		claims, err := utils.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-				Success: false,
-				Message: "Token tidak valid atau expired",
-				Error:   err.Error(),
-			})
-			c.Abort()
+			problem.Abort(c, http.StatusUnauthorized, problem.CodeInvalidToken)
 			return
 		}
 
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"rest-api/internal/dto"
+	"rest-api/internal/problem"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,12 +11,7 @@ func ErrorHandler() gin.HandlerFunc {
 		c.Next()
 
 		if len(c.Errors) > 0 {
-			err := c.Errors.Last()
-			c.JSON(500, dto.ErrorResponse{
-				Success: false,
-				Message: "Internal Server Error",
-				Error:   err.Error(),
-			})
+			problem.Error(c, c.Errors.Last().Err, "Internal Server Error")
 		}
 	}
 }
@@ -26,7 +21,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Idempotency-Key, Last-Event-ID")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/repository"
+	"rest-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client generated key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	// idempotencyMaxBytes limits the request bodies read to fingerprint a request
+	idempotencyMaxBytes = 10 << 20
+	// idempotencyLease is how long a key stays in progress; a key still
+	// without a response after it was left by a process that died, and is
+	// taken over by the next retry
+	idempotencyLease = 5 * time.Minute
+)
+
+// idempotencyExcludedPrefix is the path prefix of the auth routes. Their
+// callers are anonymous and their responses hold credentials, which must
+// not be stored.
+const idempotencyExcludedPrefix = "/api/v1/auth/"
+
+// idempotencyWriter captures the response body while writing it to the client
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware replays the stored response of POST requests retried
+// with the same Idempotency-Key header. Keys are scoped per user and expire
+// after ttl. Reusing a key with a different request body returns 422.
+// Anonymous requests and the auth routes are not deduplicated: their keys
+// would be shared by every client.
+func IdempotencyMiddleware(repo *repository.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || strings.HasPrefix(c.Request.URL.Path, idempotencyExcludedPrefix) {
+			c.Next()
+			return
+		}
+
+		userID, ok := idempotencyScope(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if len(key) > 255 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeInvalidIdempotency)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, idempotencyMaxBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				problem.Abort(c, http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge)
+				return
+			}
+			problem.Abort(c, http.StatusBadRequest, problem.CodeUnreadableBody)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := fingerprintRequest(c.Request.Method, c.Request.URL.Path, body)
+
+		existing, err := repo.FindByUserAndKey(userID, key)
+		if err != nil {
+			problem.Error(c, err, "Failed to check idempotency key")
+			return
+		}
+
+		if existing != nil && (time.Now().After(existing.ExpiresAt) || abandoned(existing)) {
+			if err := repo.Delete(existing.ID); err != nil {
+				problem.Error(c, err, "Failed to check idempotency key")
+				return
+			}
+			existing = nil
+		}
+
+		if existing != nil {
+			replayIdempotentResponse(c, existing, requestHash)
+			return
+		}
+
+		record := &model.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		if err := repo.Create(record); err != nil {
+			// Another request with the same key was stored in the meantime
+			if concurrent, findErr := repo.FindByUserAndKey(userID, key); findErr == nil && concurrent != nil {
+				replayIdempotentResponse(c, concurrent, requestHash)
+				return
+			}
+			problem.Error(c, err, "Failed to store idempotency key")
+			return
+		}
+
+		// Release the key unless a response was stored, also when a handler
+		// panics, so the client can retry with it
+		saved := false
+		defer func() {
+			if saved {
+				return
+			}
+			if err := repo.Delete(record.ID); err != nil {
+				log.Printf("Failed to release idempotency key %q: %v", key, err)
+			}
+		}()
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// Server errors are not stored so the client can retry with the same key
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		headers, _ := json.Marshal(writer.Header())
+		record.StatusCode = writer.Status()
+		record.Headers = string(headers)
+		record.Body = writer.body.Bytes()
+		if err := repo.SaveResponse(record); err != nil {
+			log.Printf("Failed to store idempotent response for key %q: %v", key, err)
+			return
+		}
+		saved = true
+	}
+}
+
+// abandoned reports whether a key is still in progress after its lease
+func abandoned(record *model.IdempotencyKey) bool {
+	return record.StatusCode == 0 && time.Since(record.CreatedAt) > idempotencyLease
+}
+
+// replayIdempotentResponse writes the stored response of a previous request
+func replayIdempotentResponse(c *gin.Context, record *model.IdempotencyKey, requestHash string) {
+	if record.RequestHash != requestHash {
+		problem.Abort(c, http.StatusUnprocessableEntity, problem.CodeIdempotencyMismatch)
+		return
+	}
+
+	if record.StatusCode == 0 {
+		problem.Abort(c, http.StatusConflict, problem.CodeRequestInProgress)
+		return
+	}
+
+	var headers http.Header
+	if err := json.Unmarshal([]byte(record.Headers), &headers); err == nil {
+		for name, values := range headers {
+			c.Writer.Header()[name] = values
+		}
+	}
+	c.Writer.Header().Set("Idempotent-Replayed", "true")
+	c.Writer.WriteHeader(record.StatusCode)
+	c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// idempotencyScope returns the user the key belongs to, false for
+// anonymous requests
+func idempotencyScope(c *gin.Context) (uint, bool) {
+	if userID, exists := c.Get("userID"); exists {
+		return userID.(uint), true
+	}
+
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		if claims, err := utils.ValidateToken(parts[1]); err == nil {
+			return claims.UserID, true
+		}
+	}
+
+	return 0, false
+}
+
+// fingerprintRequest hashes the parts of a request that must match on retry
+func fingerprintRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
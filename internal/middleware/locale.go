@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"rest-api/internal/i18n"
+	"rest-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware memilih bahasa pesan response: preferensi user yang
+// login, kalau kosong dari header Accept-Language
+func LocaleMiddleware(userRepo *repository.UserRepository) gin.HandlerFunc {
+	resolve := func(c *gin.Context) i18n.Locale {
+		// userID baru ada setelah AuthMiddleware, jadi dibaca saat pesan ditulis
+		if userID, ok := c.Get("userID"); ok {
+			if user, err := userRepo.FindByID(userID.(uint)); err == nil && user != nil {
+				if locale, ok := i18n.Parse(user.Locale); ok {
+					return locale
+				}
+			}
+		}
+		return i18n.Negotiate(c.GetHeader("Accept-Language"))
+	}
+
+	return func(c *gin.Context) {
+		c.Set(i18n.ContextKey, i18n.Resolver(resolve))
+		c.Next()
+	}
+}
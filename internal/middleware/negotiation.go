@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	"rest-api/internal/openapi"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NegotiationMiddleware picks the format of the responses from the Accept
+// header: JSON, MessagePack, CBOR or XML. Requests accepting none of them,
+// nor another media type their operation produces, like text/csv, get a 406
+// in JSON.
+func NegotiationMiddleware(router *gin.Engine) gin.HandlerFunc {
+	var once sync.Once
+	var validator *openapi.Validator
+
+	return func(c *gin.Context) {
+		// The routes are complete once requests are served
+		once.Do(func() {
+			validator = openapi.NewValidator(openapi.Build(router.Routes()))
+		})
+
+		c.Writer.Header().Add("Vary", "Accept")
+		accept := c.GetHeader("Accept")
+		format, ok := render.Negotiate(accept)
+		if !ok && !acceptsOther(validator.Operation(c.Request.Method, c.FullPath()), accept) {
+			problem.Abort(c, http.StatusNotAcceptable, problem.CodeNotAcceptable)
+			return
+		}
+		render.Use(c, format)
+		c.Next()
+	}
+}
+
+// acceptsOther reports whether an Accept header accepts a media type an
+// operation produces besides the render formats. Undocumented routes, with a
+// nil operation, accept anything.
+func acceptsOther(op *openapi.OperationObject, accept string) bool {
+	if op == nil {
+		return true
+	}
+	for _, response := range op.Responses {
+		for mediaType := range response.Content {
+			if _, ok := render.ForContentType(mediaType); !ok && render.Acceptable(accept, mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
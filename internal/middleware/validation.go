@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+
+	"rest-api/internal/openapi"
+	"rest-api/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter holds back the response until it has been validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *bufferedWriter) Flush() {}
+
+// ValidationMiddleware validates requests against the OpenAPI document of the
+// routes of router: path, query and header params and JSON bodies. Invalid
+// requests get a 400 listing every invalid field. With validateResponses
+// (meant for tests, it buffers every response) responses with an undocumented
+// status or a body that does not match the document are replaced by a 500.
+func ValidationMiddleware(router *gin.Engine, validateResponses bool) gin.HandlerFunc {
+	var once sync.Once
+	var validator *openapi.Validator
+
+	return func(c *gin.Context) {
+		// The routes are complete once requests are served
+		once.Do(func() {
+			validator = openapi.NewValidator(openapi.Build(router.Routes()))
+		})
+
+		op := validator.Operation(c.Request.Method, c.FullPath())
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		errs, err := validator.ValidateRequest(op, c.Request, c.Params)
+		if errors.Is(err, openapi.ErrUnsupportedMediaType) {
+			problem.Abort(c, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType)
+			return
+		}
+		if err != nil {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeUnreadableBody)
+			return
+		}
+		if len(errs) > 0 {
+			problem.Abort(c, http.StatusBadRequest, problem.CodeValidationFailed, errs...)
+			return
+		}
+
+		if !validateResponses || streams(op) {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if errs := validator.ValidateResponse(op, writer.status, writer.Header().Get("Content-Type"), writer.body.Bytes()); len(errs) > 0 {
+			mismatch := problem.New(c, http.StatusInternalServerError, problem.CodeResponseMismatch, errs...)
+			log.Printf("Response of %s %s does not match the OpenAPI document: %v", c.Request.Method, c.FullPath(), mismatch.Errors)
+			writer.Header().Del("Content-Length")
+			writer.Header().Del("Content-Type")
+			problem.Write(c, mismatch)
+			return
+		}
+
+		c.Writer.WriteHeader(writer.status)
+		c.Writer.Write(writer.body.Bytes())
+	}
+}
+
+// streams reports whether an operation streams events or switches to a
+// WebSocket; its response never ends, so it cannot be buffered
+func streams(op *openapi.OperationObject) bool {
+	if op.Responses["101"] != nil {
+		return true
+	}
+	ok := op.Responses["200"]
+	return ok != nil && ok.Content["text/event-stream"] != nil
+}
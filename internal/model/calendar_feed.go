@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// CalendarFeed holds the secret token of a user's iCalendar feed URL
+type CalendarFeed struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex"`
+	Token     string `gorm:"size:64;not null;uniqueIndex"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// Custom field types
+const (
+	CustomFieldText        = "text"
+	CustomFieldNumber      = "number"
+	CustomFieldDate        = "date"
+	CustomFieldSelect      = "select"
+	CustomFieldMultiSelect = "multi_select"
+	CustomFieldURL         = "url"
+	CustomFieldUser        = "user"
+)
+
+// CustomField is a typed field definition the todos of a workspace can fill in
+type CustomField struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_custom_fields_user_key"`
+	Key       string `gorm:"size:30;not null;uniqueIndex:idx_custom_fields_user_key"`
+	Name      string `gorm:"size:50;not null"`
+	Type      string `gorm:"type:varchar(20);not null"`
+	Options   string `gorm:"type:text"` // JSON array of choices for select and multi_select
+	Required  bool   `gorm:"not null;default:false"`
+	Position  int    `gorm:"not null;default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
+
+// TodoFieldValue is the value of a custom field on a todo. Only the column
+// matching the field type is set: text, url, select and multi_select (as a
+// JSON array) use TextValue, number and user use NumberValue, date uses DateValue.
+type TodoFieldValue struct {
+	ID          uint         `gorm:"primaryKey"`
+	TodoID      uint         `gorm:"not null;uniqueIndex:idx_todo_field_values_todo_field"`
+	FieldID     uint         `gorm:"not null;uniqueIndex:idx_todo_field_values_todo_field;index"`
+	Field       *CustomField `gorm:"foreignKey:FieldID"`
+	TextValue   *string      `gorm:"type:text"`
+	NumberValue *float64
+	DateValue   *time.Time
+}
+
+func (TodoFieldValue) TableName() string {
+	return "todo_field_values"
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// IdempotencyKey stores the response of a request sent with an Idempotency-Key
+// header so that retries of the same request can be replayed.
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_idempotency_user_key"` // 0 for unauthenticated requests
+	Key         string `gorm:"size:255;not null;uniqueIndex:idx_idempotency_user_key"`
+	Method      string `gorm:"size:10;not null"`
+	Path        string `gorm:"size:255;not null"`
+	RequestHash string `gorm:"size:64;not null"`
+	StatusCode  int    // 0 while the original request is still being processed
+	Headers     string `gorm:"type:text"` // JSON encoded response headers
+	Body        []byte
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
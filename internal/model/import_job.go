@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Import job statuses
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// ImportJob tracks the progress of a todo import running in the background
+type ImportJob struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null;index"`
+	Format     string `gorm:"size:20;not null"`
+	Status     string `gorm:"type:varchar(20);not null;default:'queued'"`
+	Total      int
+	Processed  int
+	Created    int
+	Failed     int
+	Errors     string `gorm:"type:text"` // JSON encoded per-row errors
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
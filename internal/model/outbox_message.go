@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// Outbox message statuses
+const (
+	OutboxPending   = "pending"
+	OutboxPublished = "published"
+	OutboxDead      = "dead"
+)
+
+// OutboxMessage is a side effect of a change, like an email or a todo
+// event. It is written in the transaction of the change and published by
+// the outbox relay once that has committed, so the side effect happens if
+// and only if the change does.
+type OutboxMessage struct {
+	ID            uint       `gorm:"primaryKey"`
+	Topic         string     `gorm:"size:50;not null"`
+	DedupKey      string     `gorm:"size:255;not null;uniqueIndex"` // identifies the side effect across retries
+	Payload       string     `gorm:"type:text;not null"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_messages_due"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index:idx_outbox_messages_due"`
+	Error         string     `gorm:"type:text"`
+	PublishedAt   *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// SavedView is a named todo list filter of a user, evaluated whenever the
+// view is opened so relative due ranges stay current
+type SavedView struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_saved_views_user_name"`
+	Name      string `gorm:"size:100;not null;uniqueIndex:idx_saved_views_user_name"`
+	Filter    string `gorm:"type:text;not null"` // JSON encoded dto.TodoListQuery
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SavedView) TableName() string {
+	return "saved_views"
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Tag is a label a user attaches to todos, unique per user
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	Name      string `gorm:"size:50;not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
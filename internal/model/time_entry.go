@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// TimeEntry is time spent on a todo, recorded by a start/stop timer or
+// entered manually. A running timer has no EndedAt; a user has at most one.
+type TimeEntry struct {
+	ID              uint       `gorm:"primaryKey"`
+	UserID          uint       `gorm:"not null;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	TodoID          uint       `gorm:"not null;index"`
+	Todo            *Todo      `gorm:"foreignKey:TodoID"`
+	StartedAt       time.Time  `gorm:"not null"`
+	EndedAt         *time.Time // nil while the timer runs
+	DurationSeconds int64      `gorm:"not null;default:0"`
+	Day             string     `gorm:"type:varchar(10);not null;index"` // YYYY-MM-DD the entry started on, in the user's time zone
+	Note            string     `gorm:"size:200"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
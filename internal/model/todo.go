@@ -7,17 +7,26 @@ import (
 )
 
 type Todo struct {
-	ID          uint   `gorm:"primaryKey"`
-	Title       string `gorm:"size:200;not null"`
-	Description string `gorm:"type:text"`
-	Status      string `gorm:"type:varchar(20);default:'pending';index"`
-	Priority    string `gorm:"type:varchar(10);default:'medium'"`
-	DueDate     *time.Time
-	User        User `gorm:"not null;index"`
-	UserID      uint `gorm:"foreignKey:UserID"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID              uint             `gorm:"primaryKey"`
+	Title           string           `gorm:"size:200;not null"`
+	Description     string           `gorm:"type:text"`
+	Status          string           `gorm:"type:varchar(20);default:'pending';index"`
+	Priority        string           `gorm:"type:varchar(10);default:'medium'"`
+	DueDate         *time.Time       `gorm:"index"`                  // stored in UTC
+	DueAllDay       bool             `gorm:"not null;default:false"` // DueDate is a calendar date without time
+	Recurrence      string           `gorm:"size:100"`               // RFC 5545 RRULE, e.g. FREQ=WEEKLY;BYDAY=MO
+	EstimateMinutes *int             // planned effort
+	StartedAt       *time.Time       // set when the todo enters an in-progress status
+	CompletedAt     *time.Time       `gorm:"index"`         // set when the todo enters a done status
+	Position        string           `gorm:"size:64;index"` // manual order, compared as a string (see service.RankBetween)
+	Tags            []Tag            `gorm:"many2many:todo_tags"`
+	FieldValues     []TodoFieldValue `gorm:"foreignKey:TodoID"`
+	Blocked         bool             `gorm:"-"` // has an open blocker, set by the service when loaded
+	User            User             `gorm:"not null;index"`
+	UserID          uint             `gorm:"foreignKey:UserID"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
 }
 
 func (Todo) TableName() string {
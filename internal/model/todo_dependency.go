@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// TodoDependency records that a todo cannot start before another todo
+// (its blocker) is finished
+type TodoDependency struct {
+	ID          uint `gorm:"primaryKey"`
+	UserID      uint `gorm:"not null;index"`
+	TodoID      uint `gorm:"not null;uniqueIndex:idx_todo_dependencies_pair"`       // the blocked todo
+	BlockedByID uint `gorm:"not null;uniqueIndex:idx_todo_dependencies_pair;index"` // the blocking todo
+	CreatedAt   time.Time
+}
+
+func (TodoDependency) TableName() string {
+	return "todo_dependencies"
+}
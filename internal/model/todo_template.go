@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// TodoTemplate is a reusable checklist of todos, e.g. for onboarding or a
+// release, whose items are created together when the template is instantiated
+type TodoTemplate struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_todo_templates_user_name"`
+	Name        string `gorm:"size:100;not null;uniqueIndex:idx_todo_templates_user_name"`
+	Description string `gorm:"type:text"`
+	Items       string `gorm:"type:text;not null"` // JSON encoded []dto.TemplateItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (TodoTemplate) TableName() string {
+	return "todo_templates"
+}
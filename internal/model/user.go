@@ -12,6 +12,8 @@ type User struct {
 	Email    string `gorm:"unique;not null;size:50;index"`
 	Password string `gorm:"not null"`
 	Fullname string `gorm:"size:100"`
+	TimeZone string `gorm:"size:64;not null;default:'UTC'"` // IANA name, e.g. Asia/Jakarta
+	Locale   string `gorm:"size:10;not null;default:''"`    // en or id, empty to follow Accept-Language
 	// Reset password token and expiry
 	ResetPasswordToken  string     `gorm:"size:255;index"`
 	ResetPasswordExpiry *time.Time `gorm:"index"`
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead"
+)
+
+// Webhook is a URL of a user that receives signed todo events
+type Webhook struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	URL       string `gorm:"size:2048;not null"`
+	Events    string `gorm:"type:text;not null"` // JSON array of event types
+	Secret    string `gorm:"size:100;not null"`
+	Active    bool   `gorm:"not null;default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is one event queued for a webhook, with the outcome of
+// its last attempt. Pending deliveries are attempted once NextAttemptAt has
+// passed; deliveries that failed every attempt are dead.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey"`
+	WebhookID      uint       `gorm:"not null;index;index:idx_webhook_deliveries_event,priority:1"`
+	EventID        string     `gorm:"size:64;not null;index:idx_webhook_deliveries_event,priority:2"`
+	Event          string     `gorm:"size:50;not null"`
+	Payload        string     `gorm:"type:text;not null"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_due"`
+	Attempts       int        `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_deliveries_due"`
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	ResponseBody   string `gorm:"type:text"` // start of the last response body
+	Error          string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
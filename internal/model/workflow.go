@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Workflow holds the custom status workflow of a user's workspace. Users
+// without a row use the default pending/in_progress/completed workflow.
+type Workflow struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"not null;uniqueIndex"`
+	Definition string `gorm:"type:text;not null"` // JSON encoded statuses, transitions and initial status
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (Workflow) TableName() string {
+	return "workflows"
+}
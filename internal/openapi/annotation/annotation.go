@@ -0,0 +1,422 @@
+// Package annotation reads the swag style annotations of the HTTP handlers
+// (@Summary, @Param, @Success, @Router, ...) and renders them as the operation
+// table of the openapi package. It does not import the packages it reads, so
+// the table can be regenerated even when a stale annotation broke the build.
+package annotation
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is one annotated handler
+type Operation struct {
+	Handler     string // receiver and method, e.g. TodoHandler.Create
+	Method      string
+	Path        string // OpenAPI path, e.g. /api/v1/todos/{id}
+	Summary     string
+	Description string
+	Tags        []string
+	Accept      []string
+	Produce     []string
+	Params      []Param
+	Responses   []Response
+	Security    []string
+}
+
+// Param is a @Param line
+type Param struct {
+	Name        string
+	In          string // path, query, header, body or formData
+	Type        string // primitive type, or a Go type expression for body params
+	Required    bool
+	Description string
+	Default     string
+	Enum        []string
+}
+
+// Response is a @Success or @Failure line
+type Response struct {
+	Status      int
+	Kind        string // object, array, string or file
+	Type        string // Go type expression of object and array responses
+	Data        string // Go type expression of the data field, from {data=...}
+	Description string
+}
+
+var (
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(\S+)\s*(.*)$`)
+	responsePattern = regexp.MustCompile(`^(\d{3})\s+\{(\w+)\}\s+(\S+?)(?:\{data=(\S+)\})?(?:\s+"(.*)")?$`)
+	typeNamePattern = regexp.MustCompile(`dto\.(\w+)`)
+	attrPattern     = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+)
+
+// primitiveTypes are the types of non-body params
+var primitiveTypes = map[string]bool{
+	"string": true, "int": true, "integer": true, "number": true,
+	"bool": true, "boolean": true, "file": true,
+}
+
+// Parse reads the annotated methods of the Go files in handlerDir. Types are
+// checked against the type declarations in dtoDir.
+func Parse(handlerDir, dtoDir string) ([]Operation, error) {
+	types, err := declaredTypes(dtoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, handlerDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []Operation
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Doc == nil {
+				continue
+			}
+			op, err := parseOperation(fn, types)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fset.Position(fn.Pos()), err)
+			}
+			if op != nil {
+				operations = append(operations, *op)
+			}
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path != operations[j].Path {
+			return operations[i].Path < operations[j].Path
+		}
+		return operations[i].Method < operations[j].Method
+	})
+	for i := 1; i < len(operations); i++ {
+		if operations[i].Path == operations[i-1].Path && operations[i].Method == operations[i-1].Method {
+			return nil, fmt.Errorf("%s %s is annotated on both %s and %s",
+				operations[i].Method, operations[i].Path, operations[i-1].Handler, operations[i].Handler)
+		}
+	}
+	return operations, nil
+}
+
+// parseOperation reads the annotations of one method, nil when it has none
+func parseOperation(fn *ast.FuncDecl, types map[string]bool) (*Operation, error) {
+	op := &Operation{Handler: receiverName(fn) + "." + fn.Name.Name}
+	annotated := false
+
+	for _, comment := range fn.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		annotated = true
+
+		directive, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+		switch directive {
+		case "@Summary":
+			op.Summary = value
+		case "@Description":
+			op.Description = value
+		case "@Tags":
+			op.Tags = append(op.Tags, splitList(value)...)
+		case "@Accept":
+			op.Accept = append(op.Accept, splitList(value)...)
+		case "@Produce":
+			op.Produce = append(op.Produce, splitList(value)...)
+		case "@Security":
+			op.Security = append(op.Security, value)
+		case "@Param":
+			param, err := parseParam(value, types)
+			if err != nil {
+				return nil, err
+			}
+			op.Params = append(op.Params, param)
+		case "@Success", "@Failure":
+			response, err := parseResponse(value, types)
+			if err != nil {
+				return nil, err
+			}
+			op.Responses = append(op.Responses, response)
+		case "@Router":
+			match := routerPattern.FindStringSubmatch(value)
+			if match == nil {
+				return nil, fmt.Errorf("%s: @Router needs a path and a [method], got %q", op.Handler, value)
+			}
+			op.Path, op.Method = match[1], strings.ToUpper(match[2])
+		default:
+			return nil, fmt.Errorf("%s: unknown annotation %s", op.Handler, directive)
+		}
+	}
+
+	if !annotated {
+		return nil, nil
+	}
+	if op.Path == "" {
+		return nil, fmt.Errorf("%s: missing @Router", op.Handler)
+	}
+	return op, nil
+}
+
+// parseParam reads `name in type required "description" attributes`
+func parseParam(value string, types map[string]bool) (Param, error) {
+	fields := paramPattern.FindStringSubmatch(value)
+	if fields == nil {
+		return Param{}, fmt.Errorf("@Param needs a name, location, type and required flag, got %q", value)
+	}
+
+	param := Param{Name: fields[1], In: fields[2], Type: fields[3]}
+	switch param.In {
+	case "path", "query", "header", "formData":
+		if !primitiveTypes[param.Type] {
+			return param, fmt.Errorf("@Param %s: unsupported %s type %s", param.Name, param.In, param.Type)
+		}
+	case "body":
+		if err := checkTypes(param.Type, types); err != nil {
+			return param, fmt.Errorf("@Param %s: %w", param.Name, err)
+		}
+	default:
+		return param, fmt.Errorf("@Param %s: unknown location %s", param.Name, param.In)
+	}
+
+	required, err := strconv.ParseBool(fields[4])
+	if err != nil {
+		return param, fmt.Errorf("@Param %s: required must be true or false", param.Name)
+	}
+	param.Required = required || param.In == "path"
+
+	rest := fields[5]
+	if strings.HasPrefix(rest, `"`) {
+		description, tail, err := unquotePrefix(rest)
+		if err != nil {
+			return param, fmt.Errorf("@Param %s: %w", param.Name, err)
+		}
+		param.Description, rest = description, tail
+	}
+	for _, attr := range attrPattern.FindAllStringSubmatch(rest, -1) {
+		switch strings.ToLower(attr[1]) {
+		case "default":
+			param.Default = attr[2]
+		case "enums":
+			param.Enum = splitList(attr[2])
+		default:
+			return param, fmt.Errorf("@Param %s: unsupported attribute %s", param.Name, attr[1])
+		}
+	}
+	return param, nil
+}
+
+// parseResponse reads `status {kind} type{data=type} "description"`
+func parseResponse(value string, types map[string]bool) (Response, error) {
+	match := responsePattern.FindStringSubmatch(value)
+	if match == nil {
+		return Response{}, fmt.Errorf("malformed response %q", value)
+	}
+
+	status, _ := strconv.Atoi(match[1])
+	response := Response{Status: status, Kind: match[2], Description: match[5]}
+	switch response.Kind {
+	case "object", "array":
+		response.Type = match[3]
+		if response.Kind == "array" {
+			response.Type = "[]" + response.Type
+		}
+		if err := checkTypes(response.Type, types); err != nil {
+			return response, err
+		}
+		if match[4] != "" {
+			response.Data = match[4]
+			if err := checkTypes(response.Data, types); err != nil {
+				return response, err
+			}
+		}
+	case "string", "file":
+	default:
+		return response, fmt.Errorf("unknown response kind {%s}", response.Kind)
+	}
+	return response, nil
+}
+
+// checkTypes reports dto types referenced by a type expression that do not exist
+func checkTypes(expr string, types map[string]bool) error {
+	if strings.Contains(expr, ".") && !strings.Contains(expr, "dto.") {
+		return fmt.Errorf("type %s: only dto types can be referenced", expr)
+	}
+	for _, match := range typeNamePattern.FindAllStringSubmatch(expr, -1) {
+		if !types[match[1]] {
+			return fmt.Errorf("unknown type dto.%s", match[1])
+		}
+	}
+	return nil
+}
+
+// Render formats operations as the Go source of the openapi operation table
+func Render(operations []Operation) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by go run ./gen; DO NOT EDIT.\n\n")
+	b.WriteString("package openapi\n\n")
+	b.WriteString("import \"rest-api/internal/dto\"\n\n")
+	b.WriteString("var operations = []Operation{\n")
+	for _, op := range operations {
+		b.WriteString("{\n")
+		fmt.Fprintf(&b, "Handler: %q,\n", op.Handler)
+		fmt.Fprintf(&b, "Method: %q,\n", op.Method)
+		fmt.Fprintf(&b, "Path: %q,\n", op.Path)
+		writeString(&b, "Summary", op.Summary)
+		writeString(&b, "Description", op.Description)
+		writeStrings(&b, "Tags", op.Tags)
+		writeStrings(&b, "Accept", op.Accept)
+		writeStrings(&b, "Produce", op.Produce)
+		if len(op.Params) > 0 {
+			b.WriteString("Params: []Param{\n")
+			for _, param := range op.Params {
+				fmt.Fprintf(&b, "{Name: %q, In: %q", param.Name, param.In)
+				if param.In == "body" {
+					fmt.Fprintf(&b, ", Model: typeOf[%s]()", param.Type)
+				} else {
+					fmt.Fprintf(&b, ", Type: %q", param.Type)
+				}
+				if param.Required {
+					b.WriteString(", Required: true")
+				}
+				if param.Description != "" {
+					fmt.Fprintf(&b, ", Description: %q", param.Description)
+				}
+				if param.Default != "" {
+					fmt.Fprintf(&b, ", Default: %q", param.Default)
+				}
+				if len(param.Enum) > 0 {
+					fmt.Fprintf(&b, ", Enum: %#v", param.Enum)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		if len(op.Responses) > 0 {
+			b.WriteString("Responses: []Response{\n")
+			for _, response := range op.Responses {
+				fmt.Fprintf(&b, "{Status: %d, Kind: %q", response.Status, response.Kind)
+				if response.Type != "" {
+					fmt.Fprintf(&b, ", Model: typeOf[%s]()", response.Type)
+				}
+				if response.Data != "" {
+					fmt.Fprintf(&b, ", Data: typeOf[%s]()", response.Data)
+				}
+				if response.Description != "" {
+					fmt.Fprintf(&b, ", Description: %q", response.Description)
+				}
+				b.WriteString("},\n")
+			}
+			b.WriteString("},\n")
+		}
+		writeStrings(&b, "Security", op.Security)
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func writeString(b *bytes.Buffer, field, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "%s: %q,\n", field, value)
+	}
+}
+
+func writeStrings(b *bytes.Buffer, field string, values []string) {
+	if len(values) > 0 {
+		fmt.Fprintf(b, "%s: %#v,\n", field, values)
+	}
+}
+
+// declaredTypes returns the names of the types declared in dir
+func declaredTypes(dir string) (map[string]bool, error) {
+	files, err := parseDir(token.NewFileSet(), dir)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				types[spec.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+	return types, nil
+}
+
+// parseDir parses the non-test Go files of dir in name order
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// unquotePrefix splits a leading quoted string from the rest of s
+func unquotePrefix(s string) (string, string, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			unquoted, err := strconv.Unquote(s[:i+1])
+			return unquoted, strings.TrimSpace(s[i+1:]), err
+		}
+	}
+	return "", "", fmt.Errorf("unterminated description %s", s)
+}
+
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
@@ -0,0 +1,25 @@
+// Command gen regenerates operations_gen.go, the operation table of the
+// openapi package, from the handler annotations. Run it with go generate in
+// internal/openapi after changing an annotation.
+package main
+
+import (
+	"log"
+	"os"
+
+	"rest-api/internal/openapi/annotation"
+)
+
+func main() {
+	operations, err := annotation.Parse("../handler", "../dto")
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := annotation.Render(operations)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("operations_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,432 @@
+// Package openapi builds the OpenAPI 3.1 document of the API. Paths come from
+// the gin route table, operations from the handler annotations (compiled into
+// operations_gen.go) and schemas from the DTO types by reflection, so the
+// document cannot drift from the code it describes.
+package openapi
+
+//go:generate go run ./gen
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of the generated document
+const Version = "3.1.0"
+
+// Operation is an annotated handler of the operation table
+type Operation struct {
+	Handler     string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Accept      []string
+	Produce     []string
+	Params      []Param
+	Responses   []Response
+	Security    []string
+}
+
+// Param is a path, query, header, form or body parameter of an operation
+type Param struct {
+	Name        string
+	In          string
+	Type        string       // primitive type of non-body params
+	Model       reflect.Type // body type
+	Required    bool
+	Description string
+	Default     string
+	Enum        []string
+}
+
+// Response is a documented status of an operation
+type Response struct {
+	Status      int
+	Kind        string       // object, array, string or file
+	Model       reflect.Type // type of object and array responses
+	Data        reflect.Type // type of the data field of the response envelope
+	Description string
+}
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations of one path by lower case method
+type PathItem map[string]*OperationObject
+
+// OperationObject is an operation of the document
+type OperationObject struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []*Parameter               `json:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of an operation by media type
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// ResponseObject is a response of an operation by media type
+type ResponseObject struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of one media type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas shared by operations
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+var ginParamPattern = regexp.MustCompile(`[:*](\w+)`)
+
+// problemType is the type of error responses
+var problemType = typeOf[dto.Problem]()
+
+// Path converts a gin route path (/todos/:id) to an OpenAPI path (/todos/{id})
+func Path(ginPath string) string {
+	return ginParamPattern.ReplaceAllString(ginPath, "{$1}")
+}
+
+// Lookup returns the operation of a route, nil when it is not annotated
+func Lookup(method, ginPath string) *Operation {
+	path := Path(ginPath)
+	for i := range operations {
+		if operations[i].Method == method && operations[i].Path == path {
+			return &operations[i]
+		}
+	}
+	return nil
+}
+
+// Operations returns the annotated operations
+func Operations() []Operation {
+	return operations
+}
+
+// Build creates the document of the given routes. Routes without annotations
+// are left out.
+func Build(routes gin.RoutesInfo) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Todo REST API",
+			Description: "Todos with workflows, custom fields, dependencies, time tracking, saved views and templates.",
+			Version:     "1.0",
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]*SecurityScheme{
+				"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	schemas := newSchemaGenerator(doc.Components.Schemas)
+
+	for _, route := range routes {
+		op := Lookup(route.Method, route.Path)
+		if op == nil {
+			continue
+		}
+		path := Path(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = buildOperation(op, schemas)
+	}
+	return doc
+}
+
+func buildOperation(op *Operation, schemas *schemaGenerator) *OperationObject {
+	object := &OperationObject{
+		OperationID: operationID(op.Handler),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]*ResponseObject),
+	}
+
+	var body *Param
+	var form []Param
+	for i, param := range op.Params {
+		switch param.In {
+		case "body":
+			body = &op.Params[i]
+		case "formData":
+			form = append(form, param)
+		default:
+			object.Parameters = append(object.Parameters, &Parameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      paramSchema(param),
+			})
+		}
+	}
+	if body != nil || len(form) > 0 {
+		object.RequestBody = requestBody(op, body, form, schemas)
+	}
+
+	for _, response := range op.Responses {
+		description := response.Description
+		if description == "" {
+			description = http.StatusText(response.Status)
+		}
+		object.Responses[strconv.Itoa(response.Status)] = &ResponseObject{
+			Description: description,
+			Content:     responseContent(op, response, schemas),
+		}
+	}
+
+	addValidationResponses(object, schemas)
+	if object.Responses["406"] == nil {
+		object.Responses["406"] = &ResponseObject{Description: http.StatusText(http.StatusNotAcceptable), Content: encoded(schemas.schema(problemType), true)}
+	}
+
+	for _, scheme := range op.Security {
+		object.Security = append(object.Security, map[string][]string{scheme: {}})
+	}
+	return object
+}
+
+// addValidationResponses documents the responses of the validation
+// middleware: 400 for invalid params and bodies, 415 for typed bodies that
+// are not in one of the render formats
+func addValidationResponses(object *OperationObject, schemas *schemaGenerator) {
+	content := func() map[string]*MediaType {
+		return encoded(schemas.schema(problemType), true)
+	}
+
+	validated := len(object.Parameters) > 0
+	if object.RequestBody != nil {
+		if media := object.RequestBody.Content["application/json"]; media != nil && !isEmptySchema(media.Schema) {
+			validated = true
+			if object.Responses["415"] == nil {
+				object.Responses["415"] = &ResponseObject{Description: http.StatusText(http.StatusUnsupportedMediaType), Content: content()}
+			}
+		}
+	}
+	if validated && object.Responses["400"] == nil {
+		object.Responses["400"] = &ResponseObject{Description: http.StatusText(http.StatusBadRequest), Content: content()}
+	}
+}
+
+// requestBody describes the body in each accepted media type. Form media
+// types carry the form params, others the body param or the raw body.
+func requestBody(op *Operation, body *Param, form []Param, schemas *schemaGenerator) *RequestBody {
+	request := &RequestBody{Content: make(map[string]*MediaType)}
+	if body != nil {
+		request.Description, request.Required = body.Description, body.Required
+	}
+
+	accept := op.Accept
+	if len(accept) == 0 {
+		accept = []string{"json"}
+	}
+	for _, mime := range accept {
+		mime = mediaType(mime)
+		var schema *Schema
+		switch {
+		case mime == "multipart/form-data" || mime == "application/x-www-form-urlencoded":
+			schema = &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+			for _, param := range form {
+				schema.Properties[param.Name] = paramSchema(param)
+				if param.Required {
+					schema.Required = append(schema.Required, param.Name)
+				}
+			}
+		case body != nil && mime == render.JSON.MediaType:
+			for mime, media := range encoded(schemas.schema(body.Model), false) {
+				request.Content[mime] = media
+			}
+			continue
+		case body != nil:
+			schema = schemas.schema(body.Model)
+		case strings.HasSuffix(mime, "json"):
+			schema = &Schema{}
+		default:
+			schema = &Schema{Type: Types{"string"}}
+		}
+		request.Content[mime] = &MediaType{Schema: schema}
+	}
+	return request
+}
+
+// responseContent describes a response: problems and other objects in every
+// render format, strings and files in each produced media type
+func responseContent(op *Operation, response Response, schemas *schemaGenerator) map[string]*MediaType {
+	switch response.Kind {
+	case "object", "array":
+		schema := schemas.schema(response.Model)
+		if response.Model == problemType {
+			return encoded(schema, true)
+		}
+		if response.Data != nil {
+			schema = &Schema{AllOf: []*Schema{schema, {
+				Type:       Types{"object"},
+				Properties: map[string]*Schema{"data": schemas.schema(response.Data)},
+			}}}
+		}
+		return encoded(schema, false)
+	default:
+		schema := &Schema{Type: Types{"string"}}
+		if response.Kind == "file" {
+			schema.Format = "binary"
+		}
+		content := make(map[string]*MediaType)
+		for _, mime := range op.Produce {
+			mime = mediaType(mime)
+			if strings.HasSuffix(mime, "json") {
+				// A JSON file is any JSON value
+				content[mime] = &MediaType{Schema: &Schema{}}
+				continue
+			}
+			content[mime] = &MediaType{Schema: schema}
+		}
+		return content
+	}
+}
+
+// encoded describes a schema in the media type of every render format, or
+// in their problem media types
+func encoded(schema *Schema, problems bool) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(render.Formats))
+	for _, format := range render.Formats {
+		mime := format.MediaType
+		if problems {
+			mime = format.ProblemType
+		}
+		content[mime] = &MediaType{Schema: schema}
+	}
+	return content
+}
+
+// paramSchema is the schema of a primitive param
+func paramSchema(param Param) *Schema {
+	schema := &Schema{}
+	switch param.Type {
+	case "int", "integer":
+		schema.Type = Types{"integer"}
+	case "number":
+		schema.Type = Types{"number"}
+	case "bool", "boolean":
+		schema.Type = Types{"boolean"}
+	case "file":
+		schema.Type, schema.Format = Types{"string"}, "binary"
+	default:
+		schema.Type = Types{"string"}
+	}
+	if param.Default != "" {
+		schema.Default = paramValue(schema, param.Default)
+	}
+	for _, value := range param.Enum {
+		schema.Enum = append(schema.Enum, paramValue(schema, value))
+	}
+	return schema
+}
+
+// paramValue converts a default or enum value to the type of the param
+func paramValue(schema *Schema, value string) any {
+	switch schema.Type[0] {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// mediaType expands the swag shorthands of @Accept and @Produce
+func mediaType(mime string) string {
+	switch mime {
+	case "json":
+		return "application/json"
+	case "xml":
+		return "application/xml"
+	case "plain":
+		return "text/plain"
+	case "html":
+		return "text/html"
+	case "mpfd":
+		return "multipart/form-data"
+	case "x-www-form-urlencoded":
+		return "application/x-www-form-urlencoded"
+	}
+	return mime
+}
+
+// operationID derives an operation id from the handler, e.g. TodoHandler.Create
+// becomes todoCreate
+func operationID(handler string) string {
+	receiver, method, _ := strings.Cut(handler, ".")
+	receiver = strings.TrimSuffix(receiver, "Handler")
+	if receiver == "" {
+		return method
+	}
+	return strings.ToLower(receiver[:1]) + receiver[1:] + method
+}
+
+// typeOf returns the reflect type of T, used by the generated operation table
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
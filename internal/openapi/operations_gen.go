@@ -0,0 +1,1310 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package openapi
+
+import "rest-api/internal/dto"
+
+var operations = []Operation{
+	{
+		Handler:     "UserHandler.Login",
+		Method:      "POST",
+		Path:        "/api/v1/auth/login",
+		Summary:     "User login",
+		Description: "Login with username and password, returns JWT token",
+		Tags:        []string{"auth"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "credentials", In: "body", Model: typeOf[dto.LoginRequest](), Required: true, Description: "Login credentials"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.LoginResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+	{
+		Handler:     "UserHandler.Register",
+		Method:      "POST",
+		Path:        "/api/v1/auth/register",
+		Summary:     "Register new user",
+		Description: "Register a new user account",
+		Tags:        []string{"auth"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "user", In: "body", Model: typeOf[dto.RegisterRequest](), Required: true, Description: "User registration data"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.UserResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+	{
+		Handler:     "UserHandler.ResetPasswordRequest",
+		Method:      "POST",
+		Path:        "/api/v1/auth/reset-password",
+		Summary:     "Request password reset",
+		Description: "Send a password reset email when email exists",
+		Tags:        []string{"auth"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "body", In: "body", Model: typeOf[dto.ResetPasswordRequest](), Required: true, Description: "Email"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+	{
+		Handler:     "UserHandler.ResetPasswordConfirm",
+		Method:      "POST",
+		Path:        "/api/v1/auth/reset-password/confirm",
+		Summary:     "Complete password reset",
+		Description: "Reset password using token and new password",
+		Tags:        []string{"auth"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "body", In: "body", Model: typeOf[dto.ResetPasswordConfirmRequest](), Required: true, Description: "Token and new password"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+	{
+		Handler:     "TodoHandler.Board",
+		Method:      "GET",
+		Path:        "/api/v1/board",
+		Summary:     "Get the Kanban board",
+		Description: "Get the todos of the authenticated user grouped into one column per workflow status, each in manual position order",
+		Tags:        []string{"board"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.BoardResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CalendarHandler.GetFeed",
+		Method:      "GET",
+		Path:        "/api/v1/calendar/feed",
+		Summary:     "Get calendar feed URL",
+		Description: "Get the secret iCalendar feed URL of the authenticated user, creating it on first use",
+		Tags:        []string{"calendar"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.CalendarFeedResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CalendarHandler.RegenerateFeed",
+		Method:      "POST",
+		Path:        "/api/v1/calendar/feed/regenerate",
+		Summary:     "Regenerate calendar feed URL",
+		Description: "Replace the secret token of the feed; the previous URL stops working",
+		Tags:        []string{"calendar"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.CalendarFeedResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CalendarHandler.Feed",
+		Method:      "GET",
+		Path:        "/api/v1/calendar/{token}",
+		Summary:     "iCalendar feed",
+		Description: "Todos with a due date as iCalendar, authenticated by the secret token in the URL",
+		Tags:        []string{"calendar"},
+		Produce:     []string{"text/calendar"},
+		Params: []Param{
+			{Name: "token", In: "path", Type: "string", Required: true, Description: "Feed token followed by .ics"},
+			{Name: "type", In: "query", Type: "string", Description: "Components to render (event, todo, both)", Default: "event"},
+			{Name: "status", In: "query", Type: "string", Description: "Filter by workflow status (default workflow: pending, in_progress, completed)"},
+			{Name: "priority", In: "query", Type: "string", Description: "Filter by priority (low, medium, high)"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "string"},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+	{
+		Handler:     "CustomFieldHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/custom-fields",
+		Summary:     "List custom fields",
+		Description: "List the custom fields todos of the authenticated user's workspace can fill in",
+		Tags:        []string{"custom-fields"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.CustomFieldResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CustomFieldHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/custom-fields",
+		Summary:     "Create a custom field",
+		Description: "Define a typed field (text, number, date, select, multi_select, url or user) that todos can set under custom_fields.<key>",
+		Tags:        []string{"custom-fields"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "field", In: "body", Model: typeOf[dto.CreateCustomFieldRequest](), Required: true, Description: "Custom field definition"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.CustomFieldResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CustomFieldHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/custom-fields/{id}",
+		Summary:     "Delete a custom field",
+		Description: "Delete a custom field together with its values on all todos",
+		Tags:        []string{"custom-fields"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Custom field ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "CustomFieldHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/custom-fields/{id}",
+		Summary:     "Update a custom field",
+		Description: "Change the name, options, required flag or position of a custom field. The key and type cannot change.",
+		Tags:        []string{"custom-fields"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Custom field ID"},
+			{Name: "field", In: "body", Model: typeOf[dto.UpdateCustomFieldRequest](), Required: true, Description: "Custom field changes"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.CustomFieldResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "EventHandler.Stream",
+		Method:      "GET",
+		Path:        "/api/v1/events",
+		Summary:     "Stream todo changes",
+		Description: "Push the created, updated and deleted todos of the authenticated user as Server-Sent Events. The data of each event is a JSON object with id, type, todo_id, occurred_at and the current todo, and the event carries its ID; reconnecting with Last-Event-ID replays the missed events, or sends a stream.reset event when they are no longer buffered and the todos have to be reloaded.",
+		Tags:        []string{"events"},
+		Produce:     []string{"text/event-stream"},
+		Params: []Param{
+			{Name: "Last-Event-ID", In: "header", Type: "string", Description: "ID of the last event received"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "string", Description: "Event stream"},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "EventHandler.Socket",
+		Method:      "GET",
+		Path:        "/api/v1/events/ws",
+		Summary:     "Stream todo changes over WebSocket",
+		Description: "Upgrade to a WebSocket that pushes the same events as /api/v1/events as JSON text messages. Browsers cannot set Last-Event-ID on a WebSocket, so the ID of the last event received is passed as last_event_id.",
+		Tags:        []string{"events"},
+		Params: []Param{
+			{Name: "last_event_id", In: "query", Type: "string", Description: "ID of the last event received"},
+		},
+		Responses: []Response{
+			{Status: 101, Kind: "string", Description: "Switching to the WebSocket protocol"},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Stats",
+		Method:      "GET",
+		Path:        "/api/v1/stats",
+		Summary:     "Get productivity stats",
+		Description: "Get counts by status and priority, the overdue count, the completion rate and cycle time of a date range (default: the current week), completed todos per day and completion streaks of the authenticated user",
+		Tags:        []string{"stats"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "from", In: "query", Type: "string", Description: "First day (YYYY-MM-DD, user time zone)"},
+			{Name: "to", In: "query", Type: "string", Description: "Last day (YYYY-MM-DD, user time zone), at most 366 days after from"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.StatsResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/templates",
+		Summary:     "List templates",
+		Description: "List the todo templates of the authenticated user",
+		Tags:        []string{"templates"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TemplateResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/templates",
+		Summary:     "Create a template",
+		Description: "Save a checklist of todos as a template. Items may use {{placeholders}} in title and description and due dates relative to a base date. With todo_id an existing todo is copied as the first item.",
+		Tags:        []string{"templates"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "template", In: "body", Model: typeOf[dto.CreateTemplateRequest](), Required: true, Description: "Template definition"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TemplateResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/templates/{id}",
+		Summary:     "Delete a template",
+		Description: "Delete a todo template. Todos created from it are kept.",
+		Tags:        []string{"templates"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Template ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.Get",
+		Method:      "GET",
+		Path:        "/api/v1/templates/{id}",
+		Summary:     "Get a template",
+		Description: "Get a todo template of the authenticated user with the placeholders it needs",
+		Tags:        []string{"templates"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Template ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TemplateResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/templates/{id}",
+		Summary:     "Update a template",
+		Description: "Rename a template, change its description or replace its items",
+		Tags:        []string{"templates"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Template ID"},
+			{Name: "template", In: "body", Model: typeOf[dto.UpdateTemplateRequest](), Required: true, Description: "Template changes"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TemplateResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TemplateHandler.Instantiate",
+		Method:      "POST",
+		Path:        "/api/v1/templates/{id}/instantiate",
+		Summary:     "Instantiate a template",
+		Description: "Create the todos of a template in one transaction, filling in placeholders and due dates relative to the base date (default: today in the user's time zone). {{date}} is replaced with the base date.",
+		Tags:        []string{"templates"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Template ID"},
+			{Name: "instantiate", In: "body", Model: typeOf[dto.InstantiateTemplateRequest](), Description: "Base date and placeholder values"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/time-entries",
+		Summary:     "List time entries",
+		Description: "List the time entries of the authenticated user, newest first",
+		Tags:        []string{"time-tracking"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "todo_id", In: "query", Type: "int", Description: "Only entries of this todo"},
+			{Name: "from", In: "query", Type: "string", Description: "First day (YYYY-MM-DD, user time zone)"},
+			{Name: "to", In: "query", Type: "string", Description: "Last day (YYYY-MM-DD, user time zone)"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TimeEntryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Current",
+		Method:      "GET",
+		Path:        "/api/v1/time-entries/current",
+		Summary:     "Get the running timer",
+		Description: "Get the running timer of the authenticated user, data is null when no timer runs",
+		Tags:        []string{"time-tracking"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TimeEntryResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Stop",
+		Method:      "POST",
+		Path:        "/api/v1/time-entries/stop",
+		Summary:     "Stop the running timer",
+		Description: "Stop the running timer of the authenticated user",
+		Tags:        []string{"time-tracking"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TimeEntryResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Summary",
+		Method:      "GET",
+		Path:        "/api/v1/time-entries/summary",
+		Summary:     "Summarize tracked time",
+		Description: "Total the finished time entries of the authenticated user over a date range (default: the current week), per todo and per day",
+		Tags:        []string{"time-tracking"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "from", In: "query", Type: "string", Description: "First day (YYYY-MM-DD, user time zone)"},
+			{Name: "to", In: "query", Type: "string", Description: "Last day (YYYY-MM-DD, user time zone)"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TimeSummaryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/time-entries/{id}",
+		Summary:     "Delete a time entry",
+		Description: "Delete a time entry of the authenticated user",
+		Tags:        []string{"time-tracking"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Time entry ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.GetAll",
+		Method:      "GET",
+		Path:        "/api/v1/todos",
+		Summary:     "Get all todos for authenticated user",
+		Description: "Retrieve all todos for the authenticated user with optional filters",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "status", In: "query", Type: "string", Description: "Filter by workflow status (default workflow: pending, in_progress, completed)"},
+			{Name: "priority", In: "query", Type: "string", Description: "Filter by priority (low, medium, high)"},
+			{Name: "tags", In: "query", Type: "string", Description: "Comma separated tags a todo must all carry"},
+			{Name: "due", In: "query", Type: "string", Description: "Filter by due range (overdue, today, tomorrow, this_week, next_7_days, next_30_days, none)"},
+			{Name: "q", In: "query", Type: "string", Description: "Search text in title and description"},
+			{Name: "open", In: "query", Type: "bool", Description: "Only todos that are not completed"},
+			{Name: "sort", In: "query", Type: "string", Description: "Sort by position, created_at, updated_at, due_date, title, priority or cf.<key>, prefix - for descending (default position)"},
+			{Name: "cf.key", In: "query", Type: "string", Description: "Filter by the value of custom field key, e.g. cf.size=large"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/todos",
+		Summary:     "Create a new todo",
+		Description: "Create a new todo for the authenticated user",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "todo", In: "body", Model: typeOf[dto.CreateTodoRequest](), Required: true, Description: "Todo data"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Bulk",
+		Method:      "POST",
+		Path:        "/api/v1/todos/bulk",
+		Summary:     "Run bulk todo operations",
+		Description: "Execute a batch of create/update/delete and filter-based operations in a single transaction or in best-effort mode",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "operations", In: "body", Model: typeOf[dto.BulkTodoRequest](), Required: true, Description: "Bulk operations"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.BulkTodoResponse]()},
+			{Status: 207, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.BulkTodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Export",
+		Method:      "GET",
+		Path:        "/api/v1/todos/export",
+		Summary:     "Export todos",
+		Description: "Stream the todos of the authenticated user as CSV, JSON or Markdown, with the same filters and order as the list endpoint",
+		Tags:        []string{"todos"},
+		Produce:     []string{"text/csv", "json", "text/markdown"},
+		Params: []Param{
+			{Name: "format", In: "query", Type: "string", Description: "Export format (csv, json, md)", Default: "csv"},
+			{Name: "status", In: "query", Type: "string", Description: "Filter by workflow status (default workflow: pending, in_progress, completed)"},
+			{Name: "priority", In: "query", Type: "string", Description: "Filter by priority (low, medium, high)"},
+			{Name: "tags", In: "query", Type: "string", Description: "Comma separated tags a todo must all carry"},
+			{Name: "due", In: "query", Type: "string", Description: "Filter by due range (overdue, today, tomorrow, this_week, next_7_days, next_30_days, none)"},
+			{Name: "q", In: "query", Type: "string", Description: "Search text in title and description"},
+			{Name: "open", In: "query", Type: "bool", Description: "Only todos that are not completed"},
+			{Name: "sort", In: "query", Type: "string", Description: "Sort by position, created_at, updated_at, due_date, title, priority or cf.<key>, prefix - for descending (default position)"},
+			{Name: "cf.key", In: "query", Type: "string", Description: "Filter by the value of custom field key, e.g. cf.size=large"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "file"},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "ImportHandler.Import",
+		Method:      "POST",
+		Path:        "/api/v1/todos/import",
+		Summary:     "Import todos",
+		Description: "Import todos from CSV (with optional column mapping), our JSON export, or Todoist/Trello JSON exports. The file is sent as multipart field \"file\" or as the raw request body. Large imports run as a background job.",
+		Tags:        []string{"todos"},
+		Accept:      []string{"multipart/form-data", "text/csv", "json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "file", In: "formData", Type: "file", Description: "Import file"},
+			{Name: "format", In: "query", Type: "string", Description: "Import format (csv, json, todoist, trello)"},
+			{Name: "mapping", In: "query", Type: "string", Description: "CSV column mapping as JSON, e.g. {\"title\":\"Task\"}"},
+			{Name: "dry_run", In: "query", Type: "bool", Description: "Only validate and preview the todos"},
+			{Name: "async", In: "query", Type: "bool", Description: "Run as a background job"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.ImportResultResponse]()},
+			{Status: 202, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.ImportJobResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "ImportHandler.GetJob",
+		Method:      "GET",
+		Path:        "/api/v1/todos/import/{id}",
+		Summary:     "Get import job progress",
+		Description: "Poll the progress of a background todo import",
+		Tags:        []string{"todos"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Import job ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.ImportJobResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "DependencyHandler.Next",
+		Method:      "GET",
+		Path:        "/api/v1/todos/next",
+		Summary:     "What to work on next",
+		Description: "List open todos in dependency order: every todo comes after its open blockers, ties are broken by priority and due date. ready=true returns only the todos that are not blocked.",
+		Tags:        []string{"dependencies"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "ready", In: "query", Type: "bool", Description: "Only todos without open blockers"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TodoResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.QuickAdd",
+		Method:      "POST",
+		Path:        "/api/v1/todos/quick",
+		Summary:     "Quick-add a todo from text",
+		Description: "Parse one line like \"Send invoice tomorrow 5pm !high #finance every month\" into title, due date, priority, tags and recurrence. English and Indonesian date words are understood. With dry_run=true the parsed todo is returned without saving it.",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "todo", In: "body", Model: typeOf[dto.QuickAddTodoRequest](), Required: true, Description: "Quick-add text"},
+			{Name: "dry_run", In: "query", Type: "bool", Description: "Only parse and preview the todo"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.QuickAddTodoResponse]()},
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.QuickAddTodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/todos/{id}",
+		Summary:     "Delete a todo",
+		Description: "Delete a specific todo for the authenticated user",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.GetByID",
+		Method:      "GET",
+		Path:        "/api/v1/todos/{id}",
+		Summary:     "Get a specific todo",
+		Description: "Retrieve a specific todo by ID for the authenticated user",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/todos/{id}",
+		Summary:     "Update a todo",
+		Description: "Update a specific todo for the authenticated user",
+		Tags:        []string{"todos"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "todo", In: "body", Model: typeOf[dto.UpdateTodoRequest](), Required: true, Description: "Todo data to update"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "DependencyHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/todos/{id}/dependencies",
+		Summary:     "List todo dependencies",
+		Description: "List the todos blocking a todo and the todos it blocks",
+		Tags:        []string{"dependencies"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.DependenciesResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "DependencyHandler.Add",
+		Method:      "POST",
+		Path:        "/api/v1/todos/{id}/dependencies",
+		Summary:     "Add a todo dependency",
+		Description: "Record that the todo cannot start before blocked_by_id is finished. Links that would create a cycle are rejected.",
+		Tags:        []string{"dependencies"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "dependency", In: "body", Model: typeOf[dto.AddDependencyRequest](), Required: true, Description: "Blocking todo"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "DependencyHandler.Remove",
+		Method:      "DELETE",
+		Path:        "/api/v1/todos/{id}/dependencies/{blocker_id}",
+		Summary:     "Remove a todo dependency",
+		Description: "Remove the dependency of a todo on a blocking todo",
+		Tags:        []string{"dependencies"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "blocker_id", In: "path", Type: "int", Required: true, Description: "Blocking todo ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TodoHandler.Move",
+		Method:      "POST",
+		Path:        "/api/v1/todos/{id}/move",
+		Summary:     "Move a todo on the board",
+		Description: "Place a todo directly before or after another todo (or at the end without either) and optionally move it to another status column. Status changes follow the workflow like updates do.",
+		Tags:        []string{"board"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "move", In: "body", Model: typeOf[dto.MoveTodoRequest](), Required: true, Description: "Target position and status"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/todos/{id}/time-entries",
+		Summary:     "Add a manual time entry",
+		Description: "Record time spent on a todo without a timer. Without started_at the entry ends now.",
+		Tags:        []string{"time-tracking"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "entry", In: "body", Model: typeOf[dto.CreateTimeEntryRequest](), Required: true, Description: "Time entry"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TimeEntryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "TimeEntryHandler.Start",
+		Method:      "POST",
+		Path:        "/api/v1/todos/{id}/timer/start",
+		Summary:     "Start a timer",
+		Description: "Start tracking time on a todo. Only one timer can run per user.",
+		Tags:        []string{"time-tracking"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Todo ID"},
+			{Name: "timer", In: "body", Model: typeOf[dto.StartTimerRequest](), Description: "Optional note"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.TimeEntryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 403, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "UserHandler.GetProfile",
+		Method:      "GET",
+		Path:        "/api/v1/users/profile",
+		Summary:     "Get user profile",
+		Description: "Get authenticated user's profile",
+		Tags:        []string{"users"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.UserResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "UserHandler.UpdateProfile",
+		Method:      "PUT",
+		Path:        "/api/v1/users/profile",
+		Summary:     "Update user profile",
+		Description: "Update authenticated user's profile",
+		Tags:        []string{"users"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "profile", In: "body", Model: typeOf[dto.UserUpdateRequest](), Required: true, Description: "Profile update data"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.UserResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/views",
+		Summary:     "List views",
+		Description: "List the built-in views (today, upcoming, overdue) followed by the saved views of the authenticated user",
+		Tags:        []string{"views"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.SavedViewResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/views",
+		Summary:     "Save a view",
+		Description: "Save a named todo list filter (status, priority, tags, due range, text, open, sort and custom fields, as on GET /api/v1/todos)",
+		Tags:        []string{"views"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "view", In: "body", Model: typeOf[dto.CreateSavedViewRequest](), Required: true, Description: "View name and filter"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.SavedViewResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/views/{id}",
+		Summary:     "Delete a saved view",
+		Description: "Delete a saved view of the authenticated user. Built-in views cannot be deleted.",
+		Tags:        []string{"views"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Saved view ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.Get",
+		Method:      "GET",
+		Path:        "/api/v1/views/{id}",
+		Summary:     "Get a view",
+		Description: "Get a saved view by ID or a built-in view by key",
+		Tags:        []string{"views"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "string", Required: true, Description: "Saved view ID or built-in view key (today, upcoming, overdue)"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.SavedViewResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/views/{id}",
+		Summary:     "Update a saved view",
+		Description: "Rename a saved view or replace its filter. Built-in views cannot be changed.",
+		Tags:        []string{"views"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Saved view ID"},
+			{Name: "view", In: "body", Model: typeOf[dto.UpdateSavedViewRequest](), Required: true, Description: "View changes"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.SavedViewResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "SavedViewHandler.Todos",
+		Method:      "GET",
+		Path:        "/api/v1/views/{id}/todos",
+		Summary:     "List the todos of a view",
+		Description: "Evaluate a saved or built-in view for the authenticated user. Due ranges such as next_7_days are relative to the current day in the user's time zone.",
+		Tags:        []string{"views"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "string", Required: true, Description: "Saved view ID or built-in view key (today, upcoming, overdue)"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.TodoResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.List",
+		Method:      "GET",
+		Path:        "/api/v1/webhooks",
+		Summary:     "List webhooks",
+		Description: "List the webhooks of the authenticated user. Secrets are not included.",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.WebhookResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Create",
+		Method:      "POST",
+		Path:        "/api/v1/webhooks",
+		Summary:     "Create a webhook",
+		Description: "Register a URL that receives the selected todo events as signed JSON POST requests. Each request carries X-Webhook-ID (the event ID, the same on retries and redeliveries), X-Webhook-Event, X-Webhook-Timestamp (Unix seconds) and X-Webhook-Signature, which is sha256= followed by the hex HMAC-SHA256 of \"<timestamp>.<body>\" keyed with the secret. Failed deliveries are retried with exponential backoff and end up dead after 10 attempts. The secret is only returned here and when it is replaced.",
+		Tags:        []string{"webhooks"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "webhook", In: "body", Model: typeOf[dto.CreateWebhookRequest](), Required: true, Description: "Webhook URL, events and optional secret"},
+		},
+		Responses: []Response{
+			{Status: 201, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WebhookResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Delete",
+		Method:      "DELETE",
+		Path:        "/api/v1/webhooks/{id}",
+		Summary:     "Delete a webhook",
+		Description: "Delete a webhook with its delivery log. Queued deliveries are dropped.",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Get",
+		Method:      "GET",
+		Path:        "/api/v1/webhooks/{id}",
+		Summary:     "Get a webhook",
+		Description: "Get a webhook of the authenticated user without its secret",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WebhookResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/webhooks/{id}",
+		Summary:     "Update a webhook",
+		Description: "Change the URL, events or secret of a webhook, or pause it with active false. Deliveries queued while a webhook is paused are dead and can be redelivered.",
+		Tags:        []string{"webhooks"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+			{Name: "webhook", In: "body", Model: typeOf[dto.UpdateWebhookRequest](), Required: true, Description: "Webhook changes"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WebhookResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Deliveries",
+		Method:      "GET",
+		Path:        "/api/v1/webhooks/{id}/deliveries",
+		Summary:     "List webhook deliveries",
+		Description: "List the latest 100 deliveries of a webhook, newest first, with their status, attempts and the response of the last attempt",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[[]dto.WebhookDeliveryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Redeliver",
+		Method:      "POST",
+		Path:        "/api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver",
+		Summary:     "Redeliver a webhook delivery",
+		Description: "Queue a delivery again, typically a dead one, with the same event ID and payload. The new delivery is attempted right away and retried like any other.",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+			{Name: "delivery_id", In: "path", Type: "int", Required: true, Description: "Delivery ID"},
+		},
+		Responses: []Response{
+			{Status: 202, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WebhookDeliveryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WebhookHandler.Ping",
+		Method:      "POST",
+		Path:        "/api/v1/webhooks/{id}/ping",
+		Summary:     "Send a test ping",
+		Description: "Send a signed ping event to a webhook right away, also when it is paused, and return the logged delivery with the response status. Failed pings are not retried.",
+		Tags:        []string{"webhooks"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "id", In: "path", Type: "int", Required: true, Description: "Webhook ID"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WebhookDeliveryResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 404, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WorkflowHandler.Reset",
+		Method:      "DELETE",
+		Path:        "/api/v1/workflow",
+		Summary:     "Reset status workflow",
+		Description: "Switch back to the default pending/in_progress/completed workflow",
+		Tags:        []string{"workflow"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WorkflowResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WorkflowHandler.Get",
+		Method:      "GET",
+		Path:        "/api/v1/workflow",
+		Summary:     "Get status workflow",
+		Description: "Get the todo statuses and allowed transitions of the authenticated user's workspace",
+		Tags:        []string{"workflow"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WorkflowResponse]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "WorkflowHandler.Update",
+		Method:      "PUT",
+		Path:        "/api/v1/workflow",
+		Summary:     "Replace status workflow",
+		Description: "Define custom todo statuses (each in the todo, in_progress or done category), the allowed transitions and the initial status. Statuses still used by todos cannot be removed.",
+		Tags:        []string{"workflow"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "workflow", In: "body", Model: typeOf[dto.WorkflowRequest](), Required: true, Description: "Workflow definition"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.SuccessResponse](), Data: typeOf[dto.WorkflowResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 401, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 409, Kind: "object", Model: typeOf[dto.Problem]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "DocsHandler.Docs",
+		Method:      "GET",
+		Path:        "/docs/{filepath}",
+		Summary:     "API docs",
+		Description: "Swagger UI for the OpenAPI document, /docs redirects to it",
+		Tags:        []string{"docs"},
+		Produce:     []string{"html"},
+		Params: []Param{
+			{Name: "filepath", In: "path", Type: "string", Required: true, Description: "UI asset, empty for the UI itself"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "string"},
+			{Status: 404, Kind: "string"},
+		},
+	},
+	{
+		Handler:     "GraphQLHandler.Query",
+		Method:      "POST",
+		Path:        "/graphql",
+		Summary:     "Run a GraphQL query",
+		Description: "Run a query or mutation on users, todos and their dependencies. Errors of fields are returned in errors with the code of the matching REST problem in extensions.code. Queries nested or estimated to cost more than the configured limits are rejected with query_too_deep or query_too_complex.",
+		Tags:        []string{"graphql"},
+		Accept:      []string{"json"},
+		Produce:     []string{"json"},
+		Params: []Param{
+			{Name: "body", In: "body", Model: typeOf[dto.GraphQLRequest](), Required: true, Description: "GraphQL request"},
+		},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[dto.GraphQLResponse]()},
+			{Status: 400, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+		Security: []string{"BearerAuth"},
+	},
+	{
+		Handler:     "HealthHandler.HealthCheck",
+		Method:      "GET",
+		Path:        "/health",
+		Summary:     "Health check",
+		Description: "Check if API and database connection are healthy",
+		Tags:        []string{"health"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[map[string]interface{}]()},
+			{Status: 503, Kind: "object", Model: typeOf[map[string]interface{}]()},
+		},
+	},
+	{
+		Handler:     "DocsHandler.OpenAPI",
+		Method:      "GET",
+		Path:        "/openapi.json",
+		Summary:     "OpenAPI document",
+		Description: "The OpenAPI 3.1 document of this API, generated from the route table and the DTO types",
+		Tags:        []string{"docs"},
+		Produce:     []string{"json"},
+		Responses: []Response{
+			{Status: 200, Kind: "object", Model: typeOf[map[string]interface{}]()},
+			{Status: 500, Kind: "object", Model: typeOf[dto.Problem]()},
+		},
+	},
+}
@@ -0,0 +1,243 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the JSON Schema subset the DTO types are described with
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// Types are the allowed JSON types of a schema, written as a single string
+// unless the schema is nullable
+type Types []string
+
+// MarshalJSON writes a single type as a string
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Has reports whether t allows the JSON type name
+func (t Types) Has(name string) bool {
+	for _, typ := range t {
+		if typ == name {
+			return true
+		}
+	}
+	return false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaGenerator describes Go types as schemas, named structs as components
+type schemaGenerator struct {
+	components map[string]*Schema
+}
+
+func newSchemaGenerator(components map[string]*Schema) *schemaGenerator {
+	return &schemaGenerator{components: components}
+}
+
+// schema returns the schema of t, a reference for named structs
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		return nullable(g.schema(t.Elem()))
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := g.components[t.Name()]; !ok {
+			// Register the name first so recursive types end in a reference
+			g.components[t.Name()] = &Schema{}
+			*g.components[t.Name()] = *g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.object(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		// encoding/json writes nil slices as null
+		return nullable(&Schema{Type: Types{"array"}, Items: g.schema(t.Elem())})
+	case reflect.Map:
+		return nullable(&Schema{Type: Types{"object"}, AdditionalProperties: g.schema(t.Elem())})
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}, Minimum: ptr(0.0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	}
+	// Interfaces hold any value
+	return &Schema{}
+}
+
+// object describes the exported fields of a struct as properties, with the
+// required flags and limits of their binding tags
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: Types{"object"}, Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := g.object(field.Type)
+			for key, property := range embedded.Properties {
+				schema.Properties[key] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schema(field.Type)
+		if applyBinding(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return schema
+}
+
+// applyBinding adds the validator rules of a binding tag to a property schema
+// and reports whether the field is required. Rules after dive apply to the
+// elements and are left to the element schema.
+func applyBinding(property *Schema, binding string) bool {
+	target := property
+	if len(property.OneOf) > 0 {
+		target = property.OneOf[0]
+	}
+
+	required, omitEmpty := false, false
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "omitempty":
+			omitEmpty = true
+		case "dive":
+			return required
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		case "oneof":
+			// The empty string of a string field skips the rule
+			if omitEmpty && target == property && target.Type.Has("string") {
+				target.Enum = append(target.Enum, "")
+			}
+			for _, option := range strings.Fields(value) {
+				target.Enum = append(target.Enum, ruleValue(target, option))
+			}
+		case "min", "gte", "max", "lte", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			if name != "max" && name != "lte" {
+				setLimit(target, n, false)
+			}
+			if name != "min" && name != "gte" {
+				setLimit(target, n, true)
+			}
+		}
+	}
+	return required
+}
+
+// setLimit sets a lower or upper limit, which is a length for strings and a
+// count for arrays and objects
+func setLimit(schema *Schema, n float64, upper bool) {
+	count := int(n)
+	switch {
+	case schema.Type.Has("string"):
+		if upper {
+			schema.MaxLength = &count
+		} else {
+			schema.MinLength = &count
+		}
+	case schema.Type.Has("array"):
+		if upper {
+			schema.MaxItems = &count
+		} else {
+			schema.MinItems = &count
+		}
+	case schema.Type.Has("object"):
+		if upper {
+			schema.MaxProperties = &count
+		}
+	case schema.Type.Has("integer"), schema.Type.Has("number"):
+		if upper {
+			schema.Maximum = &n
+		} else {
+			schema.Minimum = &n
+		}
+	}
+}
+
+// ruleValue converts a oneof option to the type of the schema
+func ruleValue(schema *Schema, option string) any {
+	if schema.Type.Has("integer") || schema.Type.Has("number") {
+		if n, err := strconv.ParseFloat(option, 64); err == nil {
+			return n
+		}
+	}
+	return option
+}
+
+// nullable allows null in addition to the values of schema
+func nullable(schema *Schema) *Schema {
+	switch {
+	case schema.Ref != "":
+		return &Schema{OneOf: []*Schema{schema, {Type: Types{"null"}}}}
+	case len(schema.Type) > 0 && !schema.Type.Has("null"):
+		schema.Type = append(schema.Type, "null")
+	}
+	return schema
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
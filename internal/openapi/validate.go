@@ -0,0 +1,501 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/problem"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrUnsupportedMediaType is returned for a typed request body that is not in
+// one of the render formats
+var ErrUnsupportedMediaType = errors.New("unsupported media type, send application/json, application/msgpack, application/cbor or application/xml")
+
+// Validator checks requests and responses against a document
+type Validator struct {
+	doc *Document
+}
+
+// NewValidator creates a validator for the operations of doc
+func NewValidator(doc *Document) *Validator {
+	return &Validator{doc: doc}
+}
+
+// Operation returns the operation of a route, nil when it is not documented
+func (v *Validator) Operation(method, ginPath string) *OperationObject {
+	return v.doc.Paths[Path(ginPath)][strings.ToLower(method)]
+}
+
+// ValidateRequest checks the path, query and header params and the body of a
+// request. The body is restored for the handler, as JSON. The error is set when
+// the body cannot be read or has an unsupported media type.
+func (v *Validator) ValidateRequest(op *OperationObject, req *http.Request, params gin.Params) ([]dto.FieldError, error) {
+	var errs []dto.FieldError
+	query := req.URL.Query()
+
+	for _, param := range op.Parameters {
+		var values []string
+		switch param.In {
+		case "path":
+			if value, ok := params.Get(param.Name); ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[param.Name]
+		case "header":
+			values = req.Header.Values(param.Name)
+		}
+
+		if len(values) == 0 || values[0] == "" {
+			if param.Required {
+				errs = append(errs, dto.FieldError{In: param.In, Field: param.Name, Code: problem.FieldRequired})
+			}
+			continue
+		}
+		for _, value := range values {
+			if paramErr := v.checkParam(param.Schema, value); paramErr != nil {
+				paramErr.In, paramErr.Field = param.In, param.Name
+				errs = append(errs, *paramErr)
+				break
+			}
+		}
+	}
+
+	bodyErrs, err := v.validateBody(op, req)
+	return append(errs, bodyErrs...), err
+}
+
+// validateBody checks the body against the schema of the operation. Bodies
+// in MessagePack, CBOR or XML are handed to the handler as JSON once they are
+// valid, so handlers bind every format the same way. Bodies without a typed
+// JSON schema, like file uploads, are not checked.
+func (v *Validator) validateBody(op *OperationObject, req *http.Request) ([]dto.FieldError, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+	media := op.RequestBody.Content["application/json"]
+	if media == nil || isEmptySchema(media.Schema) {
+		return nil, nil
+	}
+
+	format := render.JSON
+	if contentType := req.Header.Get("Content-Type"); contentType != "" && req.ContentLength != 0 {
+		var ok bool
+		if format, ok = render.ForContentType(contentType); !ok {
+			return nil, ErrUnsupportedMediaType
+		}
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return []dto.FieldError{{In: "body", Code: problem.FieldRequired}}, nil
+		}
+		return nil, nil
+	}
+
+	value, decodeErr := v.decode(format, media.Schema, body, "body")
+	if decodeErr != nil {
+		return []dto.FieldError{*decodeErr}, nil
+	}
+	var errs []dto.FieldError
+	v.validateValue(media.Schema, value, "", "body", &errs)
+	if len(errs) > 0 || format == render.JSON {
+		return errs, nil
+	}
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", render.JSON.MediaType)
+	return nil, nil
+}
+
+// ValidateResponse checks that a status is documented for the operation and
+// that a body in one of the render formats matches the schema of its media
+// type
+func (v *Validator) ValidateResponse(op *OperationObject, status int, contentType string, body []byte) []dto.FieldError {
+	response := op.Responses[strconv.Itoa(status)]
+	if response == nil {
+		return []dto.FieldError{{In: "response", Code: problem.FieldUndocumentedStatus, Params: map[string]any{"status": status}}}
+	}
+
+	format, ok := render.ForContentType(contentType)
+	if !ok {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media := response.Content[mediaType]
+	if media == nil {
+		if len(response.Content) > 0 {
+			return []dto.FieldError{{In: "response", Code: problem.FieldUndocumentedMediaType, Params: map[string]any{"status": status, "type": mediaType}}}
+		}
+		return nil
+	}
+
+	value, decodeErr := v.decode(format, media.Schema, body, "response")
+	if decodeErr != nil {
+		return []dto.FieldError{*decodeErr}
+	}
+	var errs []dto.FieldError
+	v.validateValue(media.Schema, value, "", "response", &errs)
+	return errs
+}
+
+// decode decodes a body in a format into the values encoding/json decodes
+// into with UseNumber. XML is typed by the schema. The error is the field error of an undecodable body.
+func (v *Validator) decode(format *render.Format, schema *Schema, body []byte, in string) (any, *dto.FieldError) {
+	var value any
+	var err error
+	switch format {
+	case render.JSON:
+		if value, err = render.JSON.Decode(body); err != nil {
+			return nil, &dto.FieldError{In: in, Code: problem.FieldInvalidJSON, Params: map[string]any{"error": err.Error()}}
+		}
+		return value, nil
+	case render.XML:
+		var root *render.Element
+		if root, err = render.ParseXML(body); err == nil {
+			value = v.xmlValue(schema, root)
+		}
+	default:
+		value, err = format.Decode(body)
+	}
+	if err != nil {
+		return nil, &dto.FieldError{In: in, Code: problem.FieldInvalidBody, Params: map[string]any{"format": format.Name, "error": err.Error()}}
+	}
+	return value, nil
+}
+
+// xmlValue converts an XML element to the value its schema describes. XML
+// has no types, so text is a number or boolean only where the schema asks for
+// one, and elements are arrays only where it asks for an array; elements
+// without a schema are arrays when all their children are items.
+func (v *Validator) xmlValue(schema *Schema, element *render.Element) any {
+	if element.Nil {
+		return nil
+	}
+	schema = v.flatten(schema)
+	text := strings.TrimSpace(element.Text)
+	composite := len(element.Children) > 0 || text == ""
+
+	switch {
+	case composite && (schema.Type.Has("array") || len(schema.Type) == 0 && len(element.Children) > 0 && itemsOnly(element)):
+		items := make([]any, len(element.Children))
+		for i, child := range element.Children {
+			itemSchema := schema.Items
+			if itemSchema == nil {
+				itemSchema = &Schema{}
+			}
+			items[i] = v.xmlValue(itemSchema, child)
+		}
+		return items
+	case composite && (schema.Type.Has("object") || len(schema.Type) == 0 && len(element.Children) > 0):
+		object := make(map[string]any, len(element.Children))
+		for _, child := range element.Children {
+			memberSchema := schema.Properties[child.Name]
+			if memberSchema == nil {
+				memberSchema = schema.AdditionalProperties
+			}
+			if memberSchema == nil {
+				memberSchema = &Schema{}
+			}
+			object[child.Name] = v.xmlValue(memberSchema, child)
+		}
+		return object
+	case schema.Type.Has("integer") || schema.Type.Has("number"):
+		if isJSONNumber(text) {
+			return json.Number(text)
+		}
+	case schema.Type.Has("boolean"):
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	}
+	return element.Text
+}
+
+// flatten merges the references, allOf and oneOf of a schema into one, for
+// typing XML. Properties in several of them are merged too.
+func (v *Validator) flatten(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return v.flatten(v.resolve(schema.Ref))
+	}
+	if len(schema.AllOf) == 0 && len(schema.OneOf) == 0 {
+		return schema
+	}
+
+	flat := *schema
+	flat.AllOf, flat.OneOf = nil, nil
+	flat.Type = append(Types{}, schema.Type...)
+	flat.Properties = make(map[string]*Schema, len(schema.Properties))
+	for name, property := range schema.Properties {
+		flat.Properties[name] = property
+	}
+	for _, sub := range append(append([]*Schema{}, schema.AllOf...), schema.OneOf...) {
+		sub = v.flatten(sub)
+		for _, t := range sub.Type {
+			if !flat.Type.Has(t) {
+				flat.Type = append(flat.Type, t)
+			}
+		}
+		for name, property := range sub.Properties {
+			if existing, ok := flat.Properties[name]; ok {
+				property = &Schema{AllOf: []*Schema{existing, property}}
+			}
+			flat.Properties[name] = property
+		}
+		if flat.Items == nil {
+			flat.Items = sub.Items
+		}
+		if flat.AdditionalProperties == nil {
+			flat.AdditionalProperties = sub.AdditionalProperties
+		}
+	}
+	return &flat
+}
+
+// itemsOnly reports whether every child of an element is an array item
+func itemsOnly(element *render.Element) bool {
+	for _, child := range element.Children {
+		if child.Name != "i" {
+			return false
+		}
+	}
+	return true
+}
+
+// isJSONNumber reports whether text is a JSON number literal
+func isJSONNumber(text string) bool {
+	if text == "" || (text[0] != '-' && (text[0] < '0' || text[0] > '9')) {
+		return false
+	}
+	var n json.Number
+	return json.Unmarshal([]byte(text), &n) == nil
+}
+
+// checkParam converts a param value to the type of its schema and checks it,
+// returning the first problem or nil
+func (v *Validator) checkParam(schema *Schema, raw string) *dto.FieldError {
+	var value any = raw
+	switch {
+	case schema.Type.Has("integer"):
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return &dto.FieldError{Code: problem.FieldInvalidType, Params: map[string]any{"types": []string{"integer"}}}
+		}
+		value = json.Number(strconv.FormatInt(n, 10))
+	case schema.Type.Has("number"):
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return &dto.FieldError{Code: problem.FieldInvalidType, Params: map[string]any{"types": []string{"number"}}}
+		}
+		value = json.Number(raw)
+	case schema.Type.Has("boolean"):
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return &dto.FieldError{Code: problem.FieldInvalidType, Params: map[string]any{"types": []string{"boolean"}}}
+		}
+		value = b
+	}
+
+	var errs []dto.FieldError
+	v.validateValue(schema, value, "", "", &errs)
+	if len(errs) > 0 {
+		return &errs[0]
+	}
+	return nil
+}
+
+// validateValue appends the mismatches between a decoded JSON value and a
+// schema, naming nested values by their path from the root
+func (v *Validator) validateValue(schema *Schema, value any, field, in string, errs *[]dto.FieldError) {
+	fail := func(code string, params map[string]any) {
+		*errs = append(*errs, dto.FieldError{In: in, Field: field, Code: code, Params: params})
+	}
+
+	if schema.Ref != "" {
+		v.validateValue(v.resolve(schema.Ref), value, field, in, errs)
+		return
+	}
+	for _, sub := range schema.AllOf {
+		v.validateValue(sub, value, field, in, errs)
+	}
+	if len(schema.OneOf) > 0 {
+		var first []dto.FieldError
+		for i, sub := range schema.OneOf {
+			var subErrs []dto.FieldError
+			v.validateValue(sub, value, field, in, &subErrs)
+			if len(subErrs) == 0 {
+				first = nil
+				break
+			}
+			if i == 0 {
+				first = subErrs
+			}
+		}
+		*errs = append(*errs, first...)
+		return
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.Has(jsonType(value, schema.Type)) {
+		fail(problem.FieldInvalidType, map[string]any{"types": []string(schema.Type)})
+		return
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		fail(problem.FieldInvalidChoice, map[string]any{"options": joinEnum(schema.Enum)})
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := len([]rune(value))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			fail(problem.FieldTooShort, map[string]any{"min": *schema.MinLength})
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			fail(problem.FieldTooLong, map[string]any{"max": *schema.MaxLength})
+		}
+		if !checkFormat(schema.Format, value) {
+			fail(problem.FieldInvalidFormat, map[string]any{"format": schema.Format})
+		}
+	case json.Number:
+		n, _ := value.Float64()
+		if schema.Minimum != nil && n < *schema.Minimum {
+			fail(problem.FieldTooSmall, map[string]any{"min": *schema.Minimum})
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			fail(problem.FieldTooLarge, map[string]any{"max": *schema.Maximum})
+		}
+	case []any:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			fail(problem.FieldTooFew, map[string]any{"min": *schema.MinItems})
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			fail(problem.FieldTooMany, map[string]any{"max": *schema.MaxItems})
+		}
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), in, errs)
+			}
+		}
+	case map[string]any:
+		if schema.MaxProperties != nil && len(value) > *schema.MaxProperties {
+			fail(problem.FieldTooMany, map[string]any{"max": *schema.MaxProperties})
+		}
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				*errs = append(*errs, dto.FieldError{In: in, Field: joinField(field, name), Code: problem.FieldRequired})
+			}
+		}
+		for name, property := range value {
+			if sub, ok := schema.Properties[name]; ok {
+				v.validateValue(sub, property, joinField(field, name), in, errs)
+			} else if schema.AdditionalProperties != nil {
+				v.validateValue(schema.AdditionalProperties, property, joinField(field, name), in, errs)
+			}
+		}
+	}
+}
+
+// resolve returns the component a reference points to
+func (v *Validator) resolve(ref string) *Schema {
+	if schema, ok := v.doc.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]; ok {
+		return schema
+	}
+	return &Schema{}
+}
+
+// jsonType names the JSON type of a decoded value. Whole numbers are integers
+// when the schema asks for one.
+func jsonType(value any, allowed Types) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if allowed.Has("integer") {
+			if _, err := value.Int64(); err == nil {
+				return "integer"
+			}
+		}
+		return "number"
+	}
+	return ""
+}
+
+// checkFormat reports whether a string matches a format; unknown formats
+// always match
+func checkFormat(format, value string) bool {
+	switch format {
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "uri":
+		_, err := url.ParseRequestURI(value)
+		return err == nil
+	}
+	return true
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, option := range enum {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinEnum(enum []any) string {
+	options := make([]string, len(enum))
+	for i, option := range enum {
+		options[i] = fmt.Sprint(option)
+	}
+	return strings.Join(options, ", ")
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func isEmptySchema(schema *Schema) bool {
+	return schema.Ref == "" && len(schema.Type) == 0 && len(schema.AllOf) == 0 && len(schema.OneOf) == 0
+}
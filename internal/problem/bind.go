@@ -0,0 +1,149 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"rest-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Name validation errors after the JSON or query names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName is the json name of a field, its form name for query DTOs
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Bind aborts with a 400 listing the invalid fields of a JSON body that
+// failed to bind
+func Bind(c *gin.Context, err error) {
+	Abort(c, http.StatusBadRequest, CodeValidationFailed, FieldErrors("body", err)...)
+}
+
+// BindQuery aborts with a 400 listing the invalid query params that failed
+// to bind
+func BindQuery(c *gin.Context, err error) {
+	Abort(c, http.StatusBadRequest, CodeValidationFailed, FieldErrors("query", err)...)
+}
+
+// FieldErrors converts a binding error to field errors named by their JSON
+// path, e.g. operations[0].title. Messages are written when the problem is.
+func FieldErrors(in string, err error) []dto.FieldError {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		errs := make([]dto.FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			code, params := describeRule(fe)
+			errs[i] = dto.FieldError{In: in, Field: fieldPath(fe.Namespace()), Code: code, Params: params}
+		}
+		return errs
+	case errors.As(err, &typeErr):
+		return []dto.FieldError{{In: in, Field: typeErr.Field, Code: FieldInvalidType, Params: map[string]interface{}{"types": []string{describeKind(typeErr.Type.Kind())}}}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return []dto.FieldError{{In: in, Code: FieldInvalidJSON, Params: map[string]interface{}{"error": err.Error()}}}
+	case errors.Is(err, io.EOF):
+		return []dto.FieldError{{In: in, Code: FieldRequired}}
+	}
+	return []dto.FieldError{{In: in, Code: FieldInvalidValue, Params: map[string]interface{}{"error": err.Error()}}}
+}
+
+// fieldPath drops the struct name from a validator namespace
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// describeRule returns the code and message params of a failed validator
+// rule, matching the errors of the OpenAPI validation
+func describeRule(fe validator.FieldError) (string, map[string]interface{}) {
+	kind := fe.Kind()
+	counted := kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+
+	switch fe.Tag() {
+	case "required":
+		return FieldRequired, nil
+	case "email":
+		return FieldInvalidFormat, map[string]interface{}{"format": "email"}
+	case "url", "uri":
+		return FieldInvalidFormat, map[string]interface{}{"format": "uri"}
+	case "oneof":
+		return FieldInvalidChoice, map[string]interface{}{"options": strings.Join(strings.Fields(fe.Param()), ", ")}
+	case "min", "gte":
+		params := map[string]interface{}{"min": number(fe.Param())}
+		switch {
+		case kind == reflect.String:
+			return FieldTooShort, params
+		case counted:
+			return FieldTooFew, params
+		}
+		return FieldTooSmall, params
+	case "max", "lte":
+		params := map[string]interface{}{"max": number(fe.Param())}
+		switch {
+		case kind == reflect.String:
+			return FieldTooLong, params
+		case counted:
+			return FieldTooMany, params
+		}
+		return FieldTooLarge, params
+	}
+	return FieldInvalidValue, map[string]interface{}{"error": fe.Tag()}
+}
+
+// number returns a rule param as a number so params read the same as the
+// ones of the OpenAPI validation
+func number(param string) interface{} {
+	if n, err := strconv.ParseFloat(param, 64); err == nil {
+		return n
+	}
+	return param
+}
+
+// describeKind names the JSON type of a Go kind for type errors
+func describeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return kind.String()
+}
@@ -0,0 +1,144 @@
+package problem
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"rest-api/internal/i18n"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// mapping is the response of a service sentinel error
+type mapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// mappings maps the service sentinel errors to responses. The first match
+// wins, so wrapped errors keep the status of their sentinel.
+var mappings = []mapping{
+	// Users
+	{service.ErrUserExists, http.StatusConflict, "user_exists"},
+	{service.ErrEmailExists, http.StatusConflict, "email_exists"},
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{service.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{service.ErrInvalidTimeZone, http.StatusBadRequest, "invalid_time_zone"},
+	{service.ErrInvalidResetToken, http.StatusBadRequest, "invalid_reset_token"},
+
+	// Todos
+	{service.ErrTodoNotFound, http.StatusNotFound, "todo_not_found"},
+	{service.ErrUnauthorizedAccess, http.StatusForbidden, "forbidden"},
+	{service.ErrInvalidStatus, http.StatusBadRequest, "invalid_status"},
+	{service.ErrInvalidPriority, http.StatusBadRequest, "invalid_priority"},
+	{service.ErrInvalidTitle, http.StatusBadRequest, "invalid_title"},
+	{service.ErrInvalidDueDate, http.StatusBadRequest, "invalid_due_date"},
+	{service.ErrInvalidTag, http.StatusBadRequest, "invalid_tag"},
+	{service.ErrInvalidEstimate, http.StatusBadRequest, "invalid_estimate"},
+	{service.ErrInvalidRecurrence, http.StatusBadRequest, "invalid_recurrence"},
+	{service.ErrInvalidSort, http.StatusBadRequest, "invalid_sort"},
+	{service.ErrInvalidDueFilter, http.StatusBadRequest, "invalid_due_filter"},
+	{service.ErrInvalidMove, http.StatusBadRequest, "invalid_move"},
+	{service.ErrInvalidBulkOperation, http.StatusBadRequest, "invalid_bulk_operation"},
+	{service.ErrBulkRolledBack, http.StatusFailedDependency, "bulk_rolled_back"},
+	{service.ErrInvalidStatsRange, http.StatusBadRequest, "invalid_stats_range"},
+
+	// Workflows
+	{service.ErrInvalidTransition, http.StatusConflict, "invalid_transition"},
+	{service.ErrTodoBlocked, http.StatusConflict, "todo_blocked"},
+	{service.ErrInvalidWorkflow, http.StatusBadRequest, "invalid_workflow"},
+	{service.ErrWorkflowStatusInUse, http.StatusConflict, "workflow_status_in_use"},
+
+	// Custom fields
+	{service.ErrCustomFieldNotFound, http.StatusNotFound, "custom_field_not_found"},
+	{service.ErrCustomFieldExists, http.StatusConflict, "custom_field_exists"},
+	{service.ErrInvalidCustomFieldDefinition, http.StatusBadRequest, "invalid_custom_field_definition"},
+	{service.ErrInvalidCustomField, http.StatusBadRequest, "invalid_custom_field"},
+
+	// Dependencies
+	{service.ErrDependencyNotFound, http.StatusNotFound, "dependency_not_found"},
+	{service.ErrDependencyExists, http.StatusConflict, "dependency_exists"},
+	{service.ErrDependencyCycle, http.StatusConflict, "dependency_cycle"},
+
+	// Time tracking
+	{service.ErrTimeEntryNotFound, http.StatusNotFound, "time_entry_not_found"},
+	{service.ErrNoTimerRunning, http.StatusNotFound, "no_timer_running"},
+	{service.ErrTimerRunning, http.StatusConflict, "timer_running"},
+	{service.ErrInvalidTimeEntry, http.StatusBadRequest, "invalid_time_entry"},
+
+	// Saved views and templates
+	{service.ErrSavedViewNotFound, http.StatusNotFound, "saved_view_not_found"},
+	{service.ErrSavedViewExists, http.StatusConflict, "saved_view_exists"},
+	{service.ErrInvalidSavedView, http.StatusBadRequest, "invalid_saved_view"},
+	{service.ErrTemplateNotFound, http.StatusNotFound, "template_not_found"},
+	{service.ErrTemplateExists, http.StatusConflict, "template_exists"},
+	{service.ErrInvalidTemplate, http.StatusBadRequest, "invalid_template"},
+
+	// Imports and calendar feeds
+	{service.ErrUnsupportedImportFormat, http.StatusBadRequest, "unsupported_import_format"},
+	{service.ErrInvalidImportFile, http.StatusBadRequest, "invalid_import_file"},
+	{service.ErrInvalidImportMapping, http.StatusBadRequest, "invalid_import_mapping"},
+	{service.ErrImportJobNotFound, http.StatusNotFound, "import_job_not_found"},
+	{service.ErrCalendarFeedNotFound, http.StatusNotFound, "calendar_feed_not_found"},
+	{service.ErrInvalidCalendarType, http.StatusBadRequest, "invalid_calendar_type"},
+
+	// Webhooks
+	{service.ErrWebhookNotFound, http.StatusNotFound, "webhook_not_found"},
+	{service.ErrWebhookDeliveryNotFound, http.StatusNotFound, "webhook_delivery_not_found"},
+	{service.ErrInvalidWebhook, http.StatusBadRequest, "invalid_webhook"},
+	{service.ErrWebhookURLNotAllowed, http.StatusBadRequest, "webhook_url_not_allowed"},
+
+	{gorm.ErrRecordNotFound, http.StatusNotFound, "not_found"},
+}
+
+// Lookup returns the status and code of a service error. Unknown errors are
+// internal errors.
+func Lookup(err error) (int, string) {
+	m := lookup(err)
+	return m.status, m.code
+}
+
+func lookup(err error) mapping {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return m
+		}
+	}
+	return mapping{status: http.StatusInternalServerError, code: CodeInternal}
+}
+
+// Detail describes a service error in the locale of the request. Details a
+// service wraps around its sentinel, like the offending value, are kept
+// as they are; unknown errors get a generic message so internals do not leak.
+func Detail(c *gin.Context, err error) string {
+	return DetailIn(i18n.FromContext(c), err)
+}
+
+// DetailIn describes a service error in locale, for errors that are not
+// written to a gin context
+func DetailIn(locale i18n.Locale, err error) string {
+	m := lookup(err)
+	detail := i18n.T(locale, "error."+m.code, nil)
+	if m.err != nil {
+		if suffix, ok := strings.CutPrefix(err.Error(), m.err.Error()); ok {
+			detail += suffix
+		}
+	}
+	return detail
+}
+
+// Error aborts with the problem of a service error. Unknown errors are logged
+// with message.
+func Error(c *gin.Context, err error, message string) {
+	m := lookup(err)
+	if m.code == CodeInternal {
+		log.Printf("%s %s: %s: %v", c.Request.Method, c.Request.URL.Path, message, err)
+	}
+	Write(c, newProblem(c, m.status, m.code, Detail(c, err), nil))
+	c.Abort()
+}
@@ -0,0 +1,138 @@
+// Package problem writes error responses as RFC 7807 problem details
+// (application/problem+json, or the problem media type of the negotiated
+// format). Every problem carries a stable code clients can
+// switch on; service errors get their status and code from one table in
+// errors.go instead of per handler errors.Is chains. Titles, details and field
+// messages come from the i18n catalog in the locale of the request.
+package problem
+
+import (
+	"net/http"
+	"strconv"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/i18n"
+	"rest-api/internal/render"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of problem responses in JSON
+const ContentType = "application/problem+json"
+
+// Codes of problems that are not caused by a service error
+const (
+	CodeUnauthorized         = "unauthorized"
+	CodeMissingToken         = "missing_token"
+	CodeMalformedToken       = "malformed_token"
+	CodeInvalidToken         = "invalid_token"
+	CodeValidationFailed     = "validation_failed"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeNotAcceptable        = "not_acceptable"
+	CodeUnreadableBody       = "unreadable_body"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeInvalidID            = "invalid_id"
+	CodeInvalidIdempotency   = "invalid_idempotency_key"
+	CodeIdempotencyMismatch  = "idempotency_key_reused"
+	CodeRequestInProgress    = "request_in_progress"
+	CodeResponseMismatch     = "response_mismatch"
+	CodeInternal             = "internal_error"
+)
+
+// Codes of field errors
+const (
+	FieldRequired      = "required"
+	FieldInvalidType   = "invalid_type"
+	FieldInvalidJSON   = "invalid_json"
+	FieldInvalidBody   = "invalid_body"
+	FieldInvalidValue  = "invalid_value"
+	FieldInvalidChoice = "invalid_choice"
+	FieldInvalidFormat = "invalid_format"
+	FieldTooShort      = "too_short"
+	FieldTooLong       = "too_long"
+	FieldTooSmall      = "too_small"
+	FieldTooLarge      = "too_large"
+	FieldTooFew        = "too_few_items"
+	FieldTooMany       = "too_many_items"
+
+	// Only reported when a response does not match the OpenAPI document
+	FieldUndocumentedStatus    = "undocumented_status"
+	FieldUndocumentedMediaType = "undocumented_media_type"
+)
+
+// Codes returns the codes of every problem, for catalog checks
+func Codes() []string {
+	codes := []string{
+		CodeUnauthorized, CodeMissingToken, CodeMalformedToken, CodeInvalidToken,
+		CodeValidationFailed, CodeUnsupportedMediaType, CodeNotAcceptable, CodeUnreadableBody,
+		CodeRequestTooLarge, CodeInvalidID, CodeInvalidIdempotency, CodeIdempotencyMismatch, CodeRequestInProgress,
+		CodeResponseMismatch, CodeInternal,
+	}
+	for _, m := range mappings {
+		codes = append(codes, m.code)
+	}
+	return codes
+}
+
+// FieldCodes returns the codes of every field error, for catalog checks
+func FieldCodes() []string {
+	return []string{
+		FieldRequired, FieldInvalidType, FieldInvalidJSON, FieldInvalidBody,
+		FieldInvalidValue, FieldInvalidChoice, FieldInvalidFormat, FieldTooShort, FieldTooLong,
+		FieldTooSmall, FieldTooLarge, FieldTooFew, FieldTooMany,
+		FieldUndocumentedStatus, FieldUndocumentedMediaType,
+	}
+}
+
+// New creates a problem for the request of c, described in its locale
+func New(c *gin.Context, status int, code string, errs ...dto.FieldError) dto.Problem {
+	return newProblem(c, status, code, i18n.T(i18n.FromContext(c), "error."+code, nil), errs)
+}
+
+// newProblem creates a problem with a detail that is already translated
+func newProblem(c *gin.Context, status int, code, detail string, errs []dto.FieldError) dto.Problem {
+	locale := i18n.FromContext(c)
+	title := http.StatusText(status)
+	if key := "status." + strconv.Itoa(status); i18n.Has(locale, key) {
+		title = i18n.T(locale, key, nil)
+	}
+	for i := range errs {
+		errs[i].Message = Message(locale, errs[i])
+	}
+
+	return dto.Problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   errs,
+	}
+}
+
+// Message writes a field error in locale. Errors about the whole body, like a
+// missing one, have a message of their own.
+func Message(locale i18n.Locale, err dto.FieldError) string {
+	key := "field." + err.Code
+	if err.Field == "" && i18n.Has(i18n.Default, key+".body") {
+		key += ".body"
+	}
+	return i18n.T(locale, key, err.Params)
+}
+
+// Write writes a problem response without aborting the handler chain
+func Write(c *gin.Context, p dto.Problem) {
+	render.Problem(c, p.Status, p)
+}
+
+// Abort writes a problem response and stops the handler chain
+func Abort(c *gin.Context, status int, code string, errs ...dto.FieldError) {
+	Write(c, New(c, status, code, errs...))
+	c.Abort()
+}
+
+// Unauthorized aborts a request that reached a handler without a user
+func Unauthorized(c *gin.Context) {
+	Abort(c, http.StatusUnauthorized, CodeUnauthorized)
+}
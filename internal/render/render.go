@@ -0,0 +1,387 @@
+// Package render writes responses in the format a client accepts: JSON,
+// MessagePack, CBOR or XML, picked from the Accept header by the negotiation
+// middleware. Responses are encoded from their JSON form, so every format
+// carries the same fields and values, times included as RFC 3339 strings.
+// Request bodies in these formats are decoded by the OpenAPI validator, which
+// hands them to the handlers as JSON.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+// formatKey is the gin context key of the negotiated format
+const formatKey = "render.format"
+
+// Format is an encoding of responses and request bodies
+type Format struct {
+	Name        string
+	MediaType   string // media type of responses
+	ProblemType string // media type of problem responses
+	suffix      string // media types ending in it are in this format
+	marshal     func(v any, root string) ([]byte, error)
+	decode      func(data []byte) (any, error)
+}
+
+// Formats of responses and request bodies
+var (
+	JSON = &Format{
+		Name:        "JSON",
+		MediaType:   "application/json",
+		ProblemType: "application/problem+json",
+		suffix:      "json",
+		marshal:     func(v any, _ string) ([]byte, error) { return json.Marshal(v) },
+		decode:      decodeJSON,
+	}
+	MsgPack = &Format{
+		Name:        "MessagePack",
+		MediaType:   "application/msgpack",
+		ProblemType: "application/problem+msgpack",
+		suffix:      "msgpack",
+		marshal:     marshalCodec(msgpackHandle),
+		decode:      decodeCodec(msgpackHandle),
+	}
+	CBOR = &Format{
+		Name:        "CBOR",
+		MediaType:   "application/cbor",
+		ProblemType: "application/problem+cbor",
+		suffix:      "cbor",
+		marshal:     marshalCodec(cborHandle),
+		decode:      decodeCodec(cborHandle),
+	}
+	XML = &Format{
+		Name:        "XML",
+		MediaType:   "application/xml",
+		ProblemType: "application/problem+xml",
+		suffix:      "xml",
+		marshal:     marshalXML,
+	}
+)
+
+// Formats lists the formats in the order of preference when a client
+// accepts several equally
+var Formats = []*Format{JSON, MsgPack, CBOR, XML}
+
+// ErrUntyped is returned when decoding XML without a schema; XML has no
+// types, see ParseXML
+var ErrUntyped = errors.New("XML bodies are decoded by schema")
+
+var (
+	mapType = reflect.TypeOf(map[string]any(nil))
+
+	msgpackHandle = func() *codec.MsgpackHandle {
+		h := &codec.MsgpackHandle{WriteExt: true}
+		h.RawToString = true
+		h.MapType = mapType
+		h.Canonical = true
+		return h
+	}()
+
+	cborHandle = func() *codec.CborHandle {
+		h := &codec.CborHandle{SkipUnexpectedTags: true}
+		h.MapType = mapType
+		h.Canonical = true
+		return h
+	}()
+)
+
+// Matches reports whether a media type is in the format, like
+// application/problem+json or application/x-msgpack
+func (f *Format) Matches(mediaType string) bool {
+	return strings.HasSuffix(strings.ToLower(mediaType), f.suffix)
+}
+
+// Decode decodes a body into the values encoding/json decodes into with
+// UseNumber: maps, slices, strings, json.Number, booleans and nil
+func (f *Format) Decode(data []byte) (any, error) {
+	if f.decode == nil {
+		return nil, ErrUntyped
+	}
+	return f.decode(data)
+}
+
+// ForContentType returns the format of a request body
+func ForContentType(contentType string) (*Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	for _, f := range Formats {
+		if f.Matches(mediaType) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// mediaRange is a media range of an Accept header
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept returns the media ranges of an Accept header, leaving out the
+// ones that cannot be parsed
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+	return ranges
+}
+
+// quality returns the quality the most specific matching range gives a
+// media type, and whether a range matches it
+func quality(ranges []mediaRange, matches func(mediaType string) bool, mainType string) (float64, bool) {
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		kind, sub, _ := strings.Cut(r.mediaType, "/")
+		s := -1
+		switch {
+		case kind == "*" && sub == "*":
+			s = 0
+		case sub == "*":
+			if kind == mainType {
+				s = 1
+			}
+		case matches(r.mediaType):
+			s = 2
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q, specificity >= 0
+}
+
+// Negotiate picks the format of the response to an Accept header. Without
+// one, or with none of the formats acceptable, the format is JSON; ok is
+// false in the latter case.
+func Negotiate(accept string) (format *Format, ok bool) {
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return JSON, true
+	}
+	best := 0.0
+	for _, f := range Formats {
+		if q, _ := quality(ranges, f.Matches, "application"); q > best {
+			format, best = f, q
+		}
+	}
+	if format == nil {
+		return JSON, false
+	}
+	return format, true
+}
+
+// Acceptable reports whether an Accept header accepts a media type, for the
+// responses of operations that are not in one of the formats
+func Acceptable(accept, mediaType string) bool {
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return true
+	}
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	q, _ := quality(ranges, func(r string) bool { return strings.EqualFold(r, mediaType) }, mainType)
+	return q > 0
+}
+
+// Use sets the format of the responses to a request
+func Use(c *gin.Context, f *Format) {
+	c.Set(formatKey, f)
+}
+
+// FromContext returns the format of the responses to a request, JSON unless
+// the negotiation middleware picked another
+func FromContext(c *gin.Context) *Format {
+	if f, ok := c.Get(formatKey); ok {
+		return f.(*Format)
+	}
+	return JSON
+}
+
+// Render writes a response in the format of the request
+func Render(c *gin.Context, status int, v any) {
+	f := FromContext(c)
+	if f == JSON {
+		c.JSON(status, v)
+		return
+	}
+	c.Render(status, encoded{format: f, contentType: f.MediaType, root: "response", value: v})
+}
+
+// Problem writes an RFC 7807 problem in the format of the request, as
+// application/problem+json, application/problem+xml and so on
+func Problem(c *gin.Context, status int, v any) {
+	f := FromContext(c)
+	if f == JSON {
+		// gin keeps a Content-Type that is already set
+		c.Header("Content-Type", f.ProblemType)
+		c.JSON(status, v)
+		return
+	}
+	c.Render(status, encoded{format: f, contentType: f.ProblemType, root: "problem", value: v})
+}
+
+// encoded renders a value in a format other than JSON
+type encoded struct {
+	format      *Format
+	contentType string
+	root        string // element of XML documents
+	value       any
+}
+
+func (r encoded) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	data, err := r.format.marshal(r.value, r.root)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (r encoded) WriteContentType(w http.ResponseWriter) {
+	contentType := r.contentType
+	if r.format == XML {
+		contentType += "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+}
+
+// marshalCodec returns the encoder of a binary format. Values are encoded
+// from their JSON form, with whole numbers as integers.
+func marshalCodec(h codec.Handle) func(v any, root string) ([]byte, error) {
+	return func(v any, _ string) ([]byte, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		var out []byte
+		err = codec.NewEncoderBytes(&out, h).Encode(fromJSON(value))
+		return out, err
+	}
+}
+
+// decodeCodec returns the decoder of a binary format
+func decodeCodec(h codec.Handle) func(data []byte) (any, error) {
+	return func(data []byte) (any, error) {
+		decoder := codec.NewDecoderBytes(data, h)
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if decoder.NumBytesRead() < len(data) {
+			return nil, fmt.Errorf("unexpected data after the %s value", h.Name())
+		}
+		return toJSON(value)
+	}
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers exact
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+// fromJSON converts the numbers of a decoded JSON value to integers where
+// they are whole and to floats otherwise
+func fromJSON(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if n, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	case []any:
+		for i, item := range value {
+			value[i] = fromJSON(item)
+		}
+	case map[string]any:
+		for key, item := range value {
+			value[key] = fromJSON(item)
+		}
+	}
+	return value
+}
+
+// toJSON converts a value decoded by a codec to the values of decodeJSON
+func toJSON(value any) (any, error) {
+	switch value := value.(type) {
+	case nil, bool, string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case int64:
+		return json.Number(strconv.FormatInt(value, 10)), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(value, 10)), nil
+	case float32:
+		return toJSON(float64(value))
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("%v is not a JSON number", value)
+		}
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64)), nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case []any:
+		for i, item := range value {
+			converted, err := toJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+		return value, nil
+	case map[string]any:
+		for key, item := range value {
+			converted, err := toJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = converted
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported value of type %T", value)
+}
@@ -0,0 +1,183 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// XML documents map JSON values to elements: object members are child
+// elements named after them, array items are <i> elements, null is an empty
+// element with nil="true". Members whose name is not an XML name are <entry>
+// elements with the name in a key attribute. Problems are in the RFC 7807
+// namespace.
+const (
+	xmlItem      = "i"
+	xmlEntry     = "entry"
+	xmlNil       = "nil"
+	xmlKey       = "key"
+	xmlProblemNS = "urn:ietf:rfc:7807"
+)
+
+// Element is an element of an XML body. Name is the member name of <entry>
+// elements.
+type Element struct {
+	Name     string
+	Nil      bool
+	Text     string
+	Children []*Element
+}
+
+// marshalXML encodes a value from its JSON form, keeping the order of the
+// object members
+func marshalXML(v any, root string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var out bytes.Buffer
+	out.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&out)
+	start := xml.StartElement{Name: xml.Name{Local: root}}
+	if root == "problem" {
+		start.Attr = []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: xmlProblemNS}}
+	}
+	if err := writeXML(encoder, decoder, start); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// writeXML writes the next JSON value of decoder as the element start
+func writeXML(encoder *xml.Encoder, decoder *json.Decoder, start xml.StartElement) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	var text string
+	switch token := token.(type) {
+	case nil:
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: xmlNil}, Value: "true"})
+		return encoder.EncodeElement("", start)
+	case json.Delim:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for decoder.More() {
+			child := xml.StartElement{Name: xml.Name{Local: xmlItem}}
+			if token == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				child = memberElement(key.(string))
+			}
+			if err := writeXML(encoder, decoder, child); err != nil {
+				return err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+		return encoder.EncodeToken(start.End())
+	case string:
+		text = token
+	case json.Number:
+		text = token.String()
+	case bool:
+		text = strconv.FormatBool(token)
+	}
+	return encoder.EncodeElement(text, start)
+}
+
+// memberElement returns the element of an object member
+func memberElement(name string) xml.StartElement {
+	if isXMLName(name) {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: xmlEntry},
+		Attr: []xml.Attr{{Name: xml.Name{Local: xmlKey}, Value: name}},
+	}
+}
+
+// isXMLName reports whether a member name can be an element name. Names
+// reserved by XML or used for items and entries cannot.
+func isXMLName(name string) bool {
+	if name == "" || name == xmlItem || name == xmlEntry || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ParseXML parses an XML body into its root element. Attributes other than
+// nil and the key of entries are ignored, as are namespaces.
+func ParseXML(data []byte) (*Element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root *Element
+	var open []*Element
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if root != nil && len(open) == 0 {
+				return nil, errors.New("unexpected element after the root element")
+			}
+			element := &Element{Name: token.Name.Local}
+			for _, attr := range token.Attr {
+				switch {
+				case attr.Name.Local == xmlNil:
+					element.Nil = attr.Value == "true"
+				case attr.Name.Local == xmlKey && element.Name == xmlEntry:
+					element.Name = attr.Value
+				}
+			}
+			if len(open) > 0 {
+				parent := open[len(open)-1]
+				parent.Children = append(parent.Children, element)
+			} else {
+				root = element
+			}
+			open = append(open, element)
+		case xml.EndElement:
+			open = open[:len(open)-1]
+		case xml.CharData:
+			if len(open) > 0 {
+				open[len(open)-1].Text += string(token)
+			} else if len(bytes.TrimSpace(token)) > 0 {
+				return nil, errors.New("unexpected text outside the root element")
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
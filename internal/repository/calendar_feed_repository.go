@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// CalendarFeedRepository handles calendar feed data access
+type CalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository instance
+func NewCalendarFeedRepository(db *gorm.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// Create creates a new calendar feed
+func (r *CalendarFeedRepository) Create(feed *model.CalendarFeed) error {
+	return r.db.Create(feed).Error
+}
+
+// Update updates a calendar feed
+func (r *CalendarFeedRepository) Update(feed *model.CalendarFeed) error {
+	return r.db.Save(feed).Error
+}
+
+// FindByUserID finds the feed of a user, returns nil when not found
+func (r *CalendarFeedRepository) FindByUserID(userID uint) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+	err := r.db.Where("user_id = ?", userID).First(&feed).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// FindByToken finds a feed by its secret token, returns nil when not found
+func (r *CalendarFeedRepository) FindByToken(token string) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+	err := r.db.Where("token = ?", token).First(&feed).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository handles custom field definition data access
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository creates a new custom field repository instance
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+// Create creates a new custom field
+func (r *CustomFieldRepository) Create(field *model.CustomField) error {
+	return r.db.Create(field).Error
+}
+
+// Update updates a custom field
+func (r *CustomFieldRepository) Update(field *model.CustomField) error {
+	return r.db.Save(field).Error
+}
+
+// FindByUserID finds the custom fields of a user ordered by position
+func (r *CustomFieldRepository) FindByUserID(userID uint) ([]model.CustomField, error) {
+	var fields []model.CustomField
+	err := r.db.Where("user_id = ?", userID).Order("position, id").Find(&fields).Error
+	return fields, err
+}
+
+// FindByIDAndUserID finds a custom field of a user, returns nil when not found
+func (r *CustomFieldRepository) FindByIDAndUserID(id, userID uint) (*model.CustomField, error) {
+	var field model.CustomField
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&field).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &field, nil
+}
+
+// ExistsByUserIDAndKey checks if a user already has a field with the key
+func (r *CustomFieldRepository) ExistsByUserIDAndKey(userID uint, key string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.CustomField{}).Where("user_id = ? AND key = ?", userID, key).Count(&count).Error
+	return count > 0, err
+}
+
+// CountByUserID counts the custom fields of a user
+func (r *CustomFieldRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.CustomField{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Delete deletes a custom field together with its values on todos
+func (r *CustomFieldRepository) Delete(field *model.CustomField) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("field_id = ?", field.ID).Delete(&model.TodoFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(field).Error
+	})
+}
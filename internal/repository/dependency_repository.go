@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DependencyRepository handles todo dependency data access
+type DependencyRepository struct {
+	db *gorm.DB
+}
+
+// NewDependencyRepository creates a new dependency repository instance
+func NewDependencyRepository(db *gorm.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// Transaction runs fn with a repository bound to a single database transaction.
+// The transaction is rolled back when fn returns an error.
+func (r *DependencyRepository) Transaction(fn func(txRepo *DependencyRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DependencyRepository{db: tx})
+	})
+}
+
+// LockUser locks the row of a user until the transaction ends, so that
+// concurrent changes to the dependencies of the user run one after another
+func (r *DependencyRepository) LockUser(userID uint) error {
+	var user model.User
+	return r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&user, userID).Error
+}
+
+// Create creates a new dependency
+func (r *DependencyRepository) Create(dependency *model.TodoDependency) error {
+	return r.db.Create(dependency).Error
+}
+
+// Delete deletes the dependency of todoID on blockedByID, returns false when
+// there was none
+func (r *DependencyRepository) Delete(todoID, blockedByID uint) (bool, error) {
+	result := r.db.Where("todo_id = ? AND blocked_by_id = ?", todoID, blockedByID).Delete(&model.TodoDependency{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Exists checks if todoID already depends on blockedByID
+func (r *DependencyRepository) Exists(todoID, blockedByID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.TodoDependency{}).
+		Where("todo_id = ? AND blocked_by_id = ?", todoID, blockedByID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindByUserID finds the dependencies of a user between todos that are not deleted
+func (r *DependencyRepository) FindByUserID(userID uint) ([]model.TodoDependency, error) {
+	var dependencies []model.TodoDependency
+	err := r.db.
+		Joins("JOIN todos blocked ON blocked.id = todo_dependencies.todo_id AND blocked.deleted_at IS NULL").
+		Joins("JOIN todos blocker ON blocker.id = todo_dependencies.blocked_by_id AND blocker.deleted_at IS NULL").
+		Where("todo_dependencies.user_id = ?", userID).
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+// FindBlockers finds the todos that todoID depends on
+func (r *DependencyRepository) FindBlockers(todoID uint) ([]model.Todo, error) {
+	var todos []model.Todo
+	err := r.db.
+		Joins("JOIN todo_dependencies ON todo_dependencies.blocked_by_id = todos.id").
+		Where("todo_dependencies.todo_id = ?", todoID).
+		Order("todos.id").
+		Find(&todos).Error
+	return todos, err
+}
+
+// FindBlocked finds the todos that depend on todoID
+func (r *DependencyRepository) FindBlocked(todoID uint) ([]model.Todo, error) {
+	var todos []model.Todo
+	err := r.db.
+		Joins("JOIN todo_dependencies ON todo_dependencies.todo_id = todos.id").
+		Where("todo_dependencies.blocked_by_id = ?", todoID).
+		Order("todos.id").
+		Find(&todos).Error
+	return todos, err
+}
+
+// FindByTodoIDs finds the dependencies touching any of todoIDs, as the
+// blocked todo or as its blocker. Dependencies on deleted todos are skipped.
+func (r *DependencyRepository) FindByTodoIDs(todoIDs []uint) ([]model.TodoDependency, error) {
+	var dependencies []model.TodoDependency
+	if len(todoIDs) == 0 {
+		return dependencies, nil
+	}
+	err := r.db.
+		Joins("JOIN todos blocked ON blocked.id = todo_dependencies.todo_id AND blocked.deleted_at IS NULL").
+		Joins("JOIN todos blocker ON blocker.id = todo_dependencies.blocked_by_id AND blocker.deleted_at IS NULL").
+		Where("todo_dependencies.todo_id IN ? OR todo_dependencies.blocked_by_id IN ?", todoIDs, todoIDs).
+		Order("todo_dependencies.id").
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+// FindBlockedTodoIDs returns which of todoIDs have at least one open blocker
+func (r *DependencyRepository) FindBlockedTodoIDs(todoIDs []uint) ([]uint, error) {
+	var ids []uint
+	if len(todoIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&model.TodoDependency{}).
+		Joins("JOIN todos blocker ON blocker.id = todo_dependencies.blocked_by_id").
+		Where("todo_dependencies.todo_id IN ?", todoIDs).
+		Where("blocker.completed_at IS NULL AND blocker.deleted_at IS NULL").
+		Distinct().
+		Pluck("todo_dependencies.todo_id", &ids).Error
+	return ids, err
+}
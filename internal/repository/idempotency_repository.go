@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyRepository handles stored idempotent responses
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository instance
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// FindByUserAndKey finds a stored key for a user, returns nil when not found
+func (r *IdempotencyRepository) FindByUserAndKey(userID uint, key string) (*model.IdempotencyKey, error) {
+	var record model.IdempotencyKey
+	err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Create stores a new key; fails when the key already exists for the user
+func (r *IdempotencyRepository) Create(record *model.IdempotencyKey) error {
+	return r.db.Create(record).Error
+}
+
+// SaveResponse stores the response of the original request
+func (r *IdempotencyRepository) SaveResponse(record *model.IdempotencyKey) error {
+	return r.db.Model(record).Updates(map[string]interface{}{
+		"status_code": record.StatusCode,
+		"headers":     record.Headers,
+		"body":        record.Body,
+	}).Error
+}
+
+// Delete removes a stored key
+func (r *IdempotencyRepository) Delete(id uint) error {
+	return r.db.Delete(&model.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired removes all keys expired before the given time
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&model.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository handles import job data access
+type ImportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates a new import job repository instance
+func NewImportJobRepository(db *gorm.DB) *ImportJobRepository {
+	return &ImportJobRepository{db: db}
+}
+
+// Create creates a new import job
+func (r *ImportJobRepository) Create(job *model.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+// Update saves the progress of an import job
+func (r *ImportJobRepository) Update(job *model.ImportJob) error {
+	return r.db.Save(job).Error
+}
+
+// FindByIDAndUserID finds an import job of a user, returns nil when not found
+func (r *ImportJobRepository) FindByIDAndUserID(id, userID uint) (*model.ImportJob, error) {
+	var job model.ImportJob
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FailStale marks queued and running jobs without progress since before as
+// failed, returning how many were marked
+func (r *ImportJobRepository) FailStale(before, now time.Time) (int64, error) {
+	result := r.db.Model(&model.ImportJob{}).
+		Where("status IN ? AND updated_at < ?", []string{model.ImportJobQueued, model.ImportJobRunning}, before).
+		Updates(map[string]interface{}{
+			"status":      model.ImportJobFailed,
+			"finished_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
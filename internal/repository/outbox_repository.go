@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository handles outbox message data access
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Create writes a message unless one with its dedup key exists. It reports
+// whether the message was written.
+func (r *OutboxRepository) Create(message *model.OutboxMessage) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dedup_key"}},
+		DoNothing: true,
+	}).Create(message)
+	return result.RowsAffected == 1, result.Error
+}
+
+// Update updates a message
+func (r *OutboxRepository) Update(message *model.OutboxMessage) error {
+	return r.db.Save(message).Error
+}
+
+// ClaimDue finds up to limit pending messages due at now and claims them
+// until leaseUntil, like WebhookRepository.ClaimDueDeliveries, so relays
+// of several replicas do not publish a message at the same time
+func (r *OutboxRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]model.OutboxMessage, error) {
+	var due []model.OutboxMessage
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", model.OutboxPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := due[:0]
+	for _, message := range due {
+		result := r.db.Model(&model.OutboxMessage{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", message.ID, model.OutboxPending, message.NextAttemptAt).
+			Update("next_attempt_at", leaseUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			message.NextAttemptAt = &leaseUntil
+			claimed = append(claimed, message)
+		}
+	}
+	return claimed, nil
+}
+
+// DeletePublishedBefore deletes the messages published before a time
+func (r *OutboxRepository) DeletePublishedBefore(before time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND published_at < ?", model.OutboxPublished, before).Delete(&model.OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
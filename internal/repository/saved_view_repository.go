@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SavedViewRepository handles saved view data access
+type SavedViewRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedViewRepository creates a new saved view repository instance
+func NewSavedViewRepository(db *gorm.DB) *SavedViewRepository {
+	return &SavedViewRepository{db: db}
+}
+
+// Create creates a new saved view
+func (r *SavedViewRepository) Create(view *model.SavedView) error {
+	return r.db.Create(view).Error
+}
+
+// Update updates a saved view
+func (r *SavedViewRepository) Update(view *model.SavedView) error {
+	return r.db.Save(view).Error
+}
+
+// Delete deletes a saved view
+func (r *SavedViewRepository) Delete(view *model.SavedView) error {
+	return r.db.Delete(view).Error
+}
+
+// FindByUserID finds the saved views of a user ordered by name
+func (r *SavedViewRepository) FindByUserID(userID uint) ([]model.SavedView, error) {
+	var views []model.SavedView
+	err := r.db.Where("user_id = ?", userID).Order("name, id").Find(&views).Error
+	return views, err
+}
+
+// FindByIDAndUserID finds a saved view of a user, returns nil when not found
+func (r *SavedViewRepository) FindByIDAndUserID(id, userID uint) (*model.SavedView, error) {
+	var view model.SavedView
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&view).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &view, nil
+}
+
+// ExistsByUserIDAndName checks if another view of a user already has the name
+func (r *SavedViewRepository) ExistsByUserIDAndName(userID uint, name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.SavedView{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountByUserID counts the saved views of a user
+func (r *SavedViewRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.SavedView{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// TemplateRepository handles template data access
+type TemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository creates a new template repository instance
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// Create creates a new template
+func (r *TemplateRepository) Create(template *model.TodoTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// Update updates a template
+func (r *TemplateRepository) Update(template *model.TodoTemplate) error {
+	return r.db.Save(template).Error
+}
+
+// Delete deletes a template
+func (r *TemplateRepository) Delete(template *model.TodoTemplate) error {
+	return r.db.Delete(template).Error
+}
+
+// FindByUserID finds the templates of a user ordered by name
+func (r *TemplateRepository) FindByUserID(userID uint) ([]model.TodoTemplate, error) {
+	var templates []model.TodoTemplate
+	err := r.db.Where("user_id = ?", userID).Order("name, id").Find(&templates).Error
+	return templates, err
+}
+
+// FindByIDAndUserID finds a template of a user, returns nil when not found
+func (r *TemplateRepository) FindByIDAndUserID(id, userID uint) (*model.TodoTemplate, error) {
+	var template model.TodoTemplate
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ExistsByUserIDAndName checks if another template of a user already has the name
+func (r *TemplateRepository) ExistsByUserIDAndName(userID uint, name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.TodoTemplate{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountByUserID counts the templates of a user
+func (r *TemplateRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.TodoTemplate{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// TimeEntryRepository handles time entry data access
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+// TimeEntryFilter narrows time entries, zero values match everything
+type TimeEntryFilter struct {
+	TodoID uint
+	// From and To are inclusive YYYY-MM-DD days in the user's time zone
+	From string
+	To   string
+}
+
+// TodoTimeTotal is the tracked time of one todo
+type TodoTimeTotal struct {
+	TodoID          uint
+	Title           string
+	EstimateMinutes *int
+	Seconds         int64
+}
+
+// DayTimeTotal is the tracked time of one day
+type DayTimeTotal struct {
+	Day     string
+	Seconds int64
+}
+
+// NewTimeEntryRepository creates a new time entry repository instance
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+// Create creates a new time entry
+func (r *TimeEntryRepository) Create(entry *model.TimeEntry) error {
+	return r.db.Omit("Todo").Create(entry).Error
+}
+
+// Update updates a time entry
+func (r *TimeEntryRepository) Update(entry *model.TimeEntry) error {
+	return r.db.Omit("Todo").Save(entry).Error
+}
+
+// Delete deletes a time entry
+func (r *TimeEntryRepository) Delete(entry *model.TimeEntry) error {
+	return r.db.Delete(entry).Error
+}
+
+// FindByIDAndUserID finds a time entry of a user, returns nil when not found
+func (r *TimeEntryRepository) FindByIDAndUserID(id, userID uint) (*model.TimeEntry, error) {
+	var entry model.TimeEntry
+	err := r.db.Preload("Todo").Where("id = ? AND user_id = ?", id, userID).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindRunningByUserID finds the running timer of a user, returns nil when none runs
+func (r *TimeEntryRepository) FindRunningByUserID(userID uint) (*model.TimeEntry, error) {
+	var entry model.TimeEntry
+	err := r.db.Preload("Todo").Where("user_id = ? AND ended_at IS NULL", userID).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindByFilter finds the time entries of a user, newest first
+func (r *TimeEntryRepository) FindByFilter(userID uint, filter TimeEntryFilter) ([]model.TimeEntry, error) {
+	var entries []model.TimeEntry
+	err := r.filterQuery(userID, filter).Preload("Todo").
+		Order("time_entries.started_at DESC").Order("time_entries.id DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// SumByTodo totals the finished time entries of a user per todo
+func (r *TimeEntryRepository) SumByTodo(userID uint, filter TimeEntryFilter) ([]TodoTimeTotal, error) {
+	var totals []TodoTimeTotal
+	err := r.filterQuery(userID, filter).
+		Select("time_entries.todo_id, todos.title, todos.estimate_minutes, SUM(time_entries.duration_seconds) AS seconds").
+		Joins("JOIN todos ON todos.id = time_entries.todo_id").
+		Where("time_entries.ended_at IS NOT NULL").
+		Group("time_entries.todo_id, todos.title, todos.estimate_minutes").
+		Order("seconds DESC").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// SumByDay totals the finished time entries of a user per day
+func (r *TimeEntryRepository) SumByDay(userID uint, filter TimeEntryFilter) ([]DayTimeTotal, error) {
+	var totals []DayTimeTotal
+	err := r.filterQuery(userID, filter).
+		Select("time_entries.day, SUM(time_entries.duration_seconds) AS seconds").
+		Where("time_entries.ended_at IS NOT NULL").
+		Group("time_entries.day").
+		Order("time_entries.day").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// filterQuery builds the query for the time entries of a user matching filter
+func (r *TimeEntryRepository) filterQuery(userID uint, filter TimeEntryFilter) *gorm.DB {
+	query := r.db.Model(&model.TimeEntry{}).Where("time_entries.user_id = ?", userID)
+
+	if filter.TodoID != 0 {
+		query = query.Where("time_entries.todo_id = ?", filter.TodoID)
+	}
+	if filter.From != "" {
+		query = query.Where("time_entries.day >= ?", filter.From)
+	}
+	if filter.To != "" {
+		query = query.Where("time_entries.day <= ?", filter.To)
+	}
+
+	return query
+}
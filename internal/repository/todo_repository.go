@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"rest-api/internal/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TodoRepository handles todo data access
@@ -11,11 +15,112 @@ type TodoRepository struct {
 	db *gorm.DB
 }
 
+// DueCutoff is the current moment as seen by a user. Timed due dates are
+// compared with Now, all-day due dates with Today (the user's current date
+// stored as midnight UTC).
+type DueCutoff struct {
+	Now   time.Time
+	Today time.Time
+}
+
+// DueWindow is a range of days in the user's time zone. Timed due dates
+// match from the instant From up to To, all-day due dates from FromDay up
+// to ToDay (dates stored as midnight UTC).
+type DueWindow struct {
+	From    time.Time
+	To      time.Time
+	FromDay time.Time
+	ToDay   time.Time
+}
+
+// TodoFilter holds optional conditions for listing a user's todos
+type TodoFilter struct {
+	Status   string
+	Priority string
+	// Tags matches todos carrying all of these tag names
+	Tags []string
+	// Text is a lower-case LIKE pattern matched against title and description
+	Text string
+	// Overdue matches todos past their due date that are not done
+	Overdue bool
+	// Cutoff decides what is overdue, defaults to the current UTC time
+	Cutoff DueCutoff
+	// HasDueDate matches only todos with a due date
+	HasDueDate bool
+	// NoDueDate matches only todos without a due date
+	NoDueDate bool
+	// Due matches todos due within the window
+	Due *DueWindow
+	// Open matches only todos that are not completed
+	Open bool
+	// Fields matches custom field values, all conditions must hold
+	Fields []FieldCondition
+	// Sort orders the result, defaults to newest first
+	Sort TodoSort
+	// Limit caps the number of todos found, 0 finds all of them
+	Limit int
+	// Offset skips the first todos found
+	Offset int
+}
+
+// FieldCondition matches todos whose value of a custom field equals Value.
+// Column is the typed value column (text_value, number_value or date_value).
+// With Contains, Value is a LIKE pattern matched against the text column.
+type FieldCondition struct {
+	FieldID  uint
+	Column   string
+	Value    interface{}
+	Contains bool
+}
+
+// TodoSort orders todos by a todos column, or by the value of a custom field
+// when FieldID is set. Column must be a trusted column name. The zero value
+// orders by manual position.
+type TodoSort struct {
+	Column  string
+	FieldID uint
+	Desc    bool
+}
+
 // NewTodoRepository creates a new todo repository instance
 func NewTodoRepository(db *gorm.DB) *TodoRepository {
 	return &TodoRepository{db: db}
 }
 
+// Transaction runs fn with a repository bound to a single database transaction.
+// The transaction is rolled back when fn returns an error.
+func (r *TodoRepository) Transaction(fn func(txRepo *TodoRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&TodoRepository{db: tx})
+	})
+}
+
+// Outbox returns an outbox repository bound to the same database or
+// transaction, for messages to be written together with todo changes
+func (r *TodoRepository) Outbox() *OutboxRepository {
+	return &OutboxRepository{db: r.db}
+}
+
+// Users returns a user repository bound to the same database or transaction
+func (r *TodoRepository) Users() *UserRepository {
+	return &UserRepository{db: r.db}
+}
+
+// Workflows returns a workflow repository bound to the same database or transaction
+func (r *TodoRepository) Workflows() *WorkflowRepository {
+	return &WorkflowRepository{db: r.db}
+}
+
+// CustomFields returns a custom field repository bound to the same database or transaction
+func (r *TodoRepository) CustomFields() *CustomFieldRepository {
+	return &CustomFieldRepository{db: r.db}
+}
+
+// Dependencies returns a dependency repository bound to the same database or transaction
+func (r *TodoRepository) Dependencies() *DependencyRepository {
+	return &DependencyRepository{db: r.db}
+}
+
 // Create creates a new todo
 func (r *TodoRepository) Create(todo *model.Todo) error {
 	return r.db.Create(todo).Error
@@ -24,40 +129,303 @@ func (r *TodoRepository) Create(todo *model.Todo) error {
 // FindByID finds a todo by ID
 func (r *TodoRepository) FindByID(id uint) (*model.Todo, error) {
 	var todo model.Todo
-	err := r.db.First(&todo, id).Error
+	err := r.preload(r.db).First(&todo, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &todo, nil
 }
 
+// FindByIDs finds the todos of a user with the given IDs
+func (r *TodoRepository) FindByIDs(userID uint, ids []uint) ([]model.Todo, error) {
+	var todos []model.Todo
+	if len(ids) == 0 {
+		return todos, nil
+	}
+	err := r.preload(r.db).Where("user_id = ? AND id IN ?", userID, ids).Order("id").Find(&todos).Error
+	return todos, err
+}
+
 // FindByUserID finds all todos for a specific user
 func (r *TodoRepository) FindByUserID(userID uint) ([]model.Todo, error) {
 	var todos []model.Todo
-	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&todos).Error
+	err := r.preload(r.db).Where("user_id = ?", userID).Order("created_at DESC").Find(&todos).Error
 	return todos, err
 }
 
 // FindByUserIDWithFilters finds todos with filters (status, priority)
 func (r *TodoRepository) FindByUserIDWithFilters(userID uint, status, priority string) ([]model.Todo, error) {
+	return r.FindByFilter(userID, TodoFilter{Status: status, Priority: priority})
+}
+
+// FindByFilter finds todos of a user matching the given filter
+func (r *TodoRepository) FindByFilter(userID uint, filter TodoFilter) ([]model.Todo, error) {
+	var todos []model.Todo
+	query := r.sorted(r.preload(r.filterQuery(userID, filter)), filter.Sort)
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	err := query.Find(&todos).Error
+	return todos, err
+}
+
+// FindBoundaryPosition returns the lowest position of a user's todos, or
+// the highest with last. Returns "" when the user has no positioned todo.
+func (r *TodoRepository) FindBoundaryPosition(userID uint, last bool) (string, error) {
+	order := "position"
+	if last {
+		order = "position DESC"
+	}
+
+	var positions []string
+	err := r.db.Model(&model.Todo{}).
+		Where("user_id = ? AND position IS NOT NULL AND position <> ''", userID).
+		Order(order).Limit(1).
+		Pluck("position", &positions).Error
+	if err != nil || len(positions) == 0 {
+		return "", err
+	}
+	return positions[0], nil
+}
+
+// FindAdjacent finds the todo of a user directly before (or, with after,
+// directly after) todo in position order, skipping excludeID. Returns nil
+// when there is none.
+func (r *TodoRepository) FindAdjacent(userID uint, todo *model.Todo, after bool, excludeID uint) (*model.Todo, error) {
+	comparison, direction := "<", "DESC"
+	if after {
+		comparison, direction = ">", "ASC"
+	}
+
+	var adjacent model.Todo
+	err := r.db.
+		Where("user_id = ? AND id <> ?", userID, excludeID).
+		Where("(position "+comparison+" ? OR (position = ? AND id "+comparison+" ?))", todo.Position, todo.Position, todo.ID).
+		Order("position " + direction).Order("id " + direction).
+		First(&adjacent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &adjacent, nil
+}
+
+// UpdatePosition sets the position of a todo
+func (r *TodoRepository) UpdatePosition(todo *model.Todo) error {
+	return r.db.Model(todo).UpdateColumn("position", todo.Position).Error
+}
+
+// FindIDsByPosition returns the IDs of a user's todos in position order
+func (r *TodoRepository) FindIDsByPosition(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.Todo{}).Where("user_id = ?", userID).
+		Order("position IS NULL").Order("position").Order("id").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// SetPositions gives the todo ids[i] the position positions[i]
+func (r *TodoRepository) SetPositions(ids []uint, positions []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&model.Todo{}).Where("id = ?", id).UpdateColumn("position", positions[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// preload loads the associations returned with a todo
+func (r *TodoRepository) preload(query *gorm.DB) *gorm.DB {
+	return query.Preload("Tags").Preload("FieldValues.Field")
+}
+
+// sorted orders a todo query, by the manual position unless sort names
+// another column
+func (r *TodoRepository) sorted(query *gorm.DB, sort TodoSort) *gorm.DB {
+	if sort.Column == "" {
+		sort = TodoSort{Column: "position"}
+	}
+	order := "todos." + sort.Column
+	if sort.FieldID != 0 {
+		query = query.Select("todos.*").
+			Joins("LEFT JOIN todo_field_values sort_value ON sort_value.todo_id = todos.id AND sort_value.field_id = ?", sort.FieldID)
+		order = "sort_value." + sort.Column
+	}
+	direction := " ASC"
+	if sort.Desc {
+		direction = " DESC"
+	}
+	// Todos without a value come last in both directions
+	return query.Order(order + " IS NULL").Order(order + direction).Order("todos.id" + direction)
+}
+
+// StreamByFilter calls fn with the todos of a user matching the filter, in
+// the order of FindByFilter, batchSize todos at a time with their tags and
+// field values. Rows are read one at a time instead of loading them all
+// into memory, so it must not run within a transaction.
+func (r *TodoRepository) StreamByFilter(userID uint, filter TodoFilter, batchSize int, fn func(todos []model.Todo) error) error {
+	rows, err := r.sorted(r.filterQuery(userID, filter).Model(&model.Todo{}), filter.Sort).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make([]model.Todo, 0, batchSize)
+	for rows.Next() {
+		var todo model.Todo
+		if err := r.db.ScanRows(rows, &todo); err != nil {
+			return err
+		}
+		batch = append(batch, todo)
+		if len(batch) < batchSize {
+			continue
+		}
+		if err := r.streamBatch(batch, fn); err != nil {
+			return err
+		}
+		batch = make([]model.Todo, 0, batchSize)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+	return r.streamBatch(batch, fn)
+}
+
+// streamBatch loads the tags and field values of streamed todos and passes
+// them to fn
+func (r *TodoRepository) streamBatch(todos []model.Todo, fn func(todos []model.Todo) error) error {
+	ids := make([]uint, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	var loaded []model.Todo
+	if err := r.preload(r.db).Where("id IN ?", ids).Find(&loaded).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint]*model.Todo, len(loaded))
+	for i := range loaded {
+		byID[loaded[i].ID] = &loaded[i]
+	}
+	for i := range todos {
+		if todo, ok := byID[todos[i].ID]; ok {
+			todos[i].Tags = todo.Tags
+			todos[i].FieldValues = todo.FieldValues
+		}
+	}
+	return fn(todos)
+}
+
+// filterQuery builds the query selecting todos of a user matching the filter
+func (r *TodoRepository) filterQuery(userID uint, filter TodoFilter) *gorm.DB {
 	query := r.db.Where("user_id = ?", userID)
 
-	if status != "" {
-		query = query.Where("status = ?", status)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
 	}
 
-	if priority != "" {
-		query = query.Where("priority = ?", priority)
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
 	}
 
-	var todos []model.Todo
-	err := query.Order("created_at DESC").Find(&todos).Error
-	return todos, err
+	if filter.Overdue {
+		cutoff := filter.Cutoff
+		if cutoff.Now.IsZero() {
+			now := time.Now().UTC()
+			cutoff = DueCutoff{Now: now, Today: now.Truncate(24 * time.Hour)}
+		}
+		query = query.Where("completed_at IS NULL").
+			Where("(due_all_day AND due_date < ?) OR (NOT due_all_day AND due_date < ?)", cutoff.Today, cutoff.Now)
+	}
+
+	if filter.HasDueDate {
+		query = query.Where("due_date IS NOT NULL")
+	}
+
+	if filter.NoDueDate {
+		query = query.Where("due_date IS NULL")
+	}
+
+	if due := filter.Due; due != nil {
+		query = query.Where("(due_all_day AND due_date >= ? AND due_date < ?) OR (NOT due_all_day AND due_date >= ? AND due_date < ?)",
+			due.FromDay, due.ToDay, due.From, due.To)
+	}
+
+	for _, tag := range filter.Tags {
+		query = query.Where("EXISTS (SELECT 1 FROM todo_tags tt JOIN tags ON tags.id = tt.tag_id WHERE tt.todo_id = todos.id AND tags.name = ?)", tag)
+	}
+
+	if filter.Text != "" {
+		query = query.Where("(LOWER(title) LIKE ? ESCAPE '\\' OR LOWER(description) LIKE ? ESCAPE '\\')", filter.Text, filter.Text)
+	}
+
+	if filter.Open {
+		query = query.Where("completed_at IS NULL")
+	}
+
+	for _, condition := range filter.Fields {
+		operator := " = ?"
+		if condition.Contains {
+			operator = " LIKE ? ESCAPE '\\'"
+		}
+		query = query.Where("EXISTS (SELECT 1 FROM todo_field_values fv WHERE fv.todo_id = todos.id AND fv.field_id = ? AND fv."+condition.Column+operator+")",
+			condition.FieldID, condition.Value)
+	}
+
+	return query
 }
 
-// Update updates a todo
+// Update updates a todo. Tags and custom field values are saved with
+// ReplaceTags and ReplaceFieldValues.
 func (r *TodoRepository) Update(todo *model.Todo) error {
-	return r.db.Save(todo).Error
+	return r.db.Omit(clause.Associations).Save(todo).Error
+}
+
+// ReplaceFieldValues replaces the custom field values of a todo with todo.FieldValues
+func (r *TodoRepository) ReplaceFieldValues(todo *model.Todo) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("todo_id = ?", todo.ID).Delete(&model.TodoFieldValue{}).Error; err != nil {
+			return err
+		}
+		if len(todo.FieldValues) == 0 {
+			return nil
+		}
+		for i := range todo.FieldValues {
+			todo.FieldValues[i].ID = 0
+			todo.FieldValues[i].TodoID = todo.ID
+		}
+		return tx.Omit("Field").Create(&todo.FieldValues).Error
+	})
+}
+
+// FindOrCreateTags returns the tags of a user with the given names, creating the missing ones
+func (r *TodoRepository) FindOrCreateTags(userID uint, names []string) ([]model.Tag, error) {
+	tags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		tag := model.Tag{UserID: userID, Name: name}
+		if err := r.db.Where("user_id = ? AND name = ?", userID, name).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// ReplaceTags replaces the tags attached to a todo
+func (r *TodoRepository) ReplaceTags(todo *model.Todo, tags []model.Tag) error {
+	return r.db.Model(todo).Association("Tags").Replace(tags)
 }
 
 // Delete soft deletes a todo
@@ -65,6 +433,22 @@ func (r *TodoRepository) Delete(id uint) error {
 	return r.db.Delete(&model.Todo{}, id).Error
 }
 
+// DeleteByUserIDAndIDs soft deletes the given todos owned by a user
+func (r *TodoRepository) DeleteByUserIDAndIDs(userID uint, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("user_id = ? AND id IN ?", userID, ids).Delete(&model.Todo{})
+	return result.RowsAffected, result.Error
+}
+
+// FindStatusesByUserID returns the distinct statuses used by a user's todos
+func (r *TodoRepository) FindStatusesByUserID(userID uint) ([]string, error) {
+	var statuses []string
+	err := r.db.Model(&model.Todo{}).Where("user_id = ?", userID).Distinct().Pluck("status", &statuses).Error
+	return statuses, err
+}
+
 // ExistsByID checks if a todo exists by ID
 func (r *TodoRepository) ExistsByID(id uint) (bool, error) {
 	var count int64
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"time"
+
+	"rest-api/internal/model"
+)
+
+// GroupCount is the number of todos sharing one value of a column
+type GroupCount struct {
+	Value string
+	Count int64
+}
+
+// CompletionTotals counts the todos created within a range and how many of
+// them are completed by now
+type CompletionTotals struct {
+	Created   int64
+	Completed int64
+}
+
+// CycleTime describes how long todos completed within a range took, from
+// being started (or created when never started) to being completed
+type CycleTime struct {
+	Count          int64
+	AverageSeconds float64
+	MedianSeconds  float64
+}
+
+// DayCount is the number of todos completed on one day
+type DayCount struct {
+	Day   string
+	Count int64
+}
+
+// Streak is a run of consecutive days with at least one completed todo
+type Streak struct {
+	StartDay string
+	EndDay   string
+	Days     int64
+}
+
+// CountGroupedBy counts the todos of a user per value of a trusted todos column
+func (r *TodoRepository) CountGroupedBy(userID uint, column string) ([]GroupCount, error) {
+	var counts []GroupCount
+	err := r.db.Model(&model.Todo{}).
+		Select(column+" AS value, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group(column).
+		Order("count DESC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountByFilter counts the todos of a user matching the given filter
+func (r *TodoRepository) CountByFilter(userID uint, filter TodoFilter) (int64, error) {
+	var count int64
+	err := r.filterQuery(userID, filter).Model(&model.Todo{}).Count(&count).Error
+	return count, err
+}
+
+// CountCompletion counts the todos of a user created in [from, to) and how
+// many of them are completed
+func (r *TodoRepository) CountCompletion(userID uint, from, to time.Time) (CompletionTotals, error) {
+	var totals CompletionTotals
+	err := r.db.Model(&model.Todo{}).
+		Select("COUNT(*) AS created, COUNT(completed_at) AS completed").
+		Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// MeasureCycleTime returns the average and median cycle time of the todos of
+// a user completed in [from, to)
+func (r *TodoRepository) MeasureCycleTime(userID uint, from, to time.Time) (CycleTime, error) {
+	const seconds = "EXTRACT(EPOCH FROM completed_at - COALESCE(started_at, created_at))::float8"
+
+	var cycle CycleTime
+	err := r.db.Model(&model.Todo{}).
+		Select("COUNT(*) AS count, "+
+			"COALESCE(AVG("+seconds+"), 0) AS average_seconds, "+
+			"COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "+seconds+"), 0) AS median_seconds").
+		Where("user_id = ? AND completed_at >= ? AND completed_at < ?", userID, from, to).
+		Scan(&cycle).Error
+	return cycle, err
+}
+
+// CountCompletedPerDay counts the todos of a user completed on each day from
+// firstDay to lastDay (inclusive YYYY-MM-DD in timeZone), including days
+// without completions. from and to are the UTC bounds of those days.
+func (r *TodoRepository) CountCompletedPerDay(userID uint, timeZone, firstDay, lastDay string, from, to time.Time) ([]DayCount, error) {
+	var counts []DayCount
+	err := r.db.Raw(`
+		SELECT to_char(days.day, 'YYYY-MM-DD') AS day, COALESCE(done.count, 0) AS count
+		FROM generate_series(?::date, ?::date, interval '1 day') AS days(day)
+		LEFT JOIN (
+			SELECT (completed_at AT TIME ZONE ?)::date AS day, COUNT(*) AS count
+			FROM todos
+			WHERE user_id = ? AND deleted_at IS NULL AND completed_at >= ? AND completed_at < ?
+			GROUP BY 1
+		) done ON done.day = days.day::date
+		ORDER BY days.day`,
+		firstDay, lastDay, timeZone, userID, from, to).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// FindCompletionStreak returns the latest run of consecutive days (in
+// timeZone) on which a user completed todos, or the longest run with longest.
+// Returns nil when the user never completed a todo.
+func (r *TodoRepository) FindCompletionStreak(userID uint, timeZone string, longest bool) (*Streak, error) {
+	order := "end_day DESC"
+	if longest {
+		order = "days DESC, end_day DESC"
+	}
+
+	// Consecutive days keep the same difference to their row number
+	var streaks []Streak
+	err := r.db.Raw(`
+		WITH completed_days AS (
+			SELECT DISTINCT (completed_at AT TIME ZONE ?)::date AS day
+			FROM todos
+			WHERE user_id = ? AND deleted_at IS NULL AND completed_at IS NOT NULL
+		), runs AS (
+			SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS run
+			FROM completed_days
+		)
+		SELECT to_char(MIN(day), 'YYYY-MM-DD') AS start_day, to_char(MAX(day), 'YYYY-MM-DD') AS end_day, COUNT(*) AS days
+		FROM runs
+		GROUP BY run
+		ORDER BY `+order+`
+		LIMIT 1`,
+		timeZone, userID).
+		Scan(&streaks).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(streaks) == 0 {
+		return nil, nil
+	}
+	return &streaks[0], nil
+}
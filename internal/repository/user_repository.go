@@ -17,6 +17,20 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// Transaction runs fn with a repository bound to a single database transaction.
+// The transaction is rolled back when fn returns an error.
+func (r *UserRepository) Transaction(fn func(txRepo *UserRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&UserRepository{db: tx})
+	})
+}
+
+// Outbox returns an outbox repository bound to the same database or
+// transaction, for messages to be written together with user changes
+func (r *UserRepository) Outbox() *OutboxRepository {
+	return &OutboxRepository{db: r.db}
+}
+
 // create to db
 func (r *UserRepository) Create(user *model.User) error {
 	return r.db.Create(user).Error
@@ -35,6 +49,16 @@ func (r *UserRepository) FindByID(id uint) (*model.User, error) {
 	return &user, nil
 }
 
+// FindByIDs finds the users with the given IDs, skipping unknown ones
+func (r *UserRepository) FindByIDs(ids []uint) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 // FindByUsername retrieves user by username
 func (r *UserRepository) FindByUsername(username string) (*model.User, error) {
 	var user model.User
@@ -71,6 +95,13 @@ func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&model.User{}, id).Error
 }
 
+// ExistsByID checks if a user with the ID exists
+func (r *UserRepository) ExistsByID(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
 // ExistsByUsername checks if username already exists
 func (r *UserRepository) ExistsByUsername(username string) (bool, error) {
 	var count int64
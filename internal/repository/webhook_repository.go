@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles webhook and delivery data access
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create creates a new webhook
+func (r *WebhookRepository) Create(webhook *model.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// Update updates a webhook
+func (r *WebhookRepository) Update(webhook *model.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete deletes a webhook with its deliveries
+func (r *WebhookRepository) Delete(webhook *model.Webhook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(webhook).Error
+	})
+}
+
+// FindByUserID finds the webhooks of a user
+func (r *WebhookRepository) FindByUserID(userID uint) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	err := r.db.Where("user_id = ?", userID).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// FindActiveByUserID finds the active webhooks of a user
+func (r *WebhookRepository) FindActiveByUserID(userID uint) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	err := r.db.Where("user_id = ? AND active = ?", userID, true).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// FindByID finds a webhook by ID, returns nil when not found
+func (r *WebhookRepository) FindByID(id uint) (*model.Webhook, error) {
+	var webhook model.Webhook
+	err := r.db.First(&webhook, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// FindByIDAndUserID finds a webhook of a user, returns nil when not found
+func (r *WebhookRepository) FindByIDAndUserID(id, userID uint) (*model.Webhook, error) {
+	var webhook model.Webhook
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// CountByUserID counts the webhooks of a user
+func (r *WebhookRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Webhook{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// CreateDelivery queues a delivery
+func (r *WebhookRepository) CreateDelivery(delivery *model.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// UpdateDelivery updates a delivery
+func (r *WebhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
+
+// HasDelivery reports whether a delivery of an event was queued for a webhook
+func (r *WebhookRepository) HasDelivery(webhookID uint, eventID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.WebhookDelivery{}).Where("webhook_id = ? AND event_id = ?", webhookID, eventID).Count(&count).Error
+	return count > 0, err
+}
+
+// FindDeliveryByID finds a delivery of a webhook, returns nil when not found
+func (r *WebhookRepository) FindDeliveryByID(id, webhookID uint) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := r.db.Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveriesByWebhookID finds the latest deliveries of a webhook, newest first
+func (r *WebhookRepository) FindDeliveriesByWebhookID(webhookID uint, limit int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).Order("id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries finds up to limit pending deliveries due at now and
+// claims them until leaseUntil by moving their next attempt. A delivery is
+// only claimed by the worker whose update finds it unchanged, so workers of
+// several replicas do not attempt it twice; a claim of a worker that stops
+// expires with the lease.
+func (r *WebhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]model.WebhookDelivery, error) {
+	var due []model.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := due[:0]
+	for _, delivery := range due {
+		result := r.db.Model(&model.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, model.WebhookDeliveryPending, delivery.NextAttemptAt).
+			Update("next_attempt_at", leaseUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			delivery.NextAttemptAt = &leaseUntil
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
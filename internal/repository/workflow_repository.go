@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"errors"
+
+	"rest-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// WorkflowRepository handles workflow data access
+type WorkflowRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowRepository creates a new workflow repository instance
+func NewWorkflowRepository(db *gorm.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// FindByUserID finds the workflow of a user, returns nil when not found
+func (r *WorkflowRepository) FindByUserID(userID uint) (*model.Workflow, error) {
+	var workflow model.Workflow
+	err := r.db.Where("user_id = ?", userID).First(&workflow).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// Save creates or updates a workflow
+func (r *WorkflowRepository) Save(workflow *model.Workflow) error {
+	return r.db.Save(workflow).Error
+}
+
+// DeleteByUserID deletes the workflow of a user
+func (r *WorkflowRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&model.Workflow{}).Error
+}
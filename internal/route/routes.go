@@ -11,10 +11,29 @@ func SetupRoutes(
 	userHandler *handler.UserHandler,
 	healthHandler *handler.HealthHandler,
 	todoHandler *handler.TodoHandler,
+	importHandler *handler.ImportHandler,
+	calendarHandler *handler.CalendarHandler,
+	workflowHandler *handler.WorkflowHandler,
+	customFieldHandler *handler.CustomFieldHandler,
+	dependencyHandler *handler.DependencyHandler,
+	timeEntryHandler *handler.TimeEntryHandler,
+	savedViewHandler *handler.SavedViewHandler,
+	templateHandler *handler.TemplateHandler,
+	graphqlHandler *handler.GraphQLHandler,
+	eventHandler *handler.EventHandler,
+	webhookHandler *handler.WebhookHandler,
+	docsHandler *handler.DocsHandler,
 ) {
 	// Check health
 	router.GET("/health", healthHandler.HealthCheck)
 
+	// OpenAPI document and docs UI (/docs redirects to /docs/)
+	router.GET("/openapi.json", docsHandler.OpenAPI)
+	router.GET("/docs/*filepath", docsHandler.Docs)
+
+	// GraphQL over the same services as the REST API
+	router.POST("/graphql", graphqlHandler.Query)
+
 	//API V1 Group
 	v1 := router.Group("/api/v1")
 	{
@@ -38,10 +57,105 @@ func SetupRoutes(
 		todos := v1.Group("todos")
 		{
 			todos.GET("", todoHandler.GetAll)
+			todos.GET("/export", todoHandler.Export)
+			todos.GET("/next", dependencyHandler.Next)
 			todos.GET("/:id", todoHandler.GetByID)
 			todos.POST("", todoHandler.Create)
+			todos.POST("/bulk", todoHandler.Bulk)
+			todos.POST("/quick", todoHandler.QuickAdd)
+			todos.POST("/import", importHandler.Import)
+			todos.GET("/import/:id", importHandler.GetJob)
 			todos.PUT(":id", todoHandler.Update)
 			todos.DELETE(":id", todoHandler.Delete)
+			todos.POST("/:id/move", todoHandler.Move)
+			todos.POST("/:id/timer/start", timeEntryHandler.Start)
+			todos.POST("/:id/time-entries", timeEntryHandler.Create)
+			todos.GET("/:id/dependencies", dependencyHandler.List)
+			todos.POST("/:id/dependencies", dependencyHandler.Add)
+			todos.DELETE("/:id/dependencies/:blocker_id", dependencyHandler.Remove)
+		}
+
+		// Todo changes as Server-Sent Events or over a WebSocket
+		events := v1.Group("/events")
+		{
+			events.GET("", eventHandler.Stream)
+			events.GET("/ws", eventHandler.Socket)
+		}
+
+		// Time tracking, one running timer per user
+		timeEntries := v1.Group("/time-entries")
+		{
+			timeEntries.GET("", timeEntryHandler.List)
+			timeEntries.GET("/current", timeEntryHandler.Current)
+			timeEntries.GET("/summary", timeEntryHandler.Summary)
+			timeEntries.POST("/stop", timeEntryHandler.Stop)
+			timeEntries.DELETE("/:id", timeEntryHandler.Delete)
+		}
+
+		// Kanban board grouped by workflow status
+		v1.GET("/board", todoHandler.Board)
+
+		// Productivity stats of the user's workspace
+		v1.GET("/stats", todoHandler.Stats)
+
+		// Saved and built-in todo list views
+		views := v1.Group("/views")
+		{
+			views.GET("", savedViewHandler.List)
+			views.POST("", savedViewHandler.Create)
+			views.GET("/:id", savedViewHandler.Get)
+			views.GET("/:id/todos", savedViewHandler.Todos)
+			views.PUT("/:id", savedViewHandler.Update)
+			views.DELETE("/:id", savedViewHandler.Delete)
+		}
+
+		// Todo templates for repeatable checklists
+		templates := v1.Group("/templates")
+		{
+			templates.GET("", templateHandler.List)
+			templates.POST("", templateHandler.Create)
+			templates.GET("/:id", templateHandler.Get)
+			templates.PUT("/:id", templateHandler.Update)
+			templates.DELETE("/:id", templateHandler.Delete)
+			templates.POST("/:id/instantiate", templateHandler.Instantiate)
+		}
+
+		// Webhooks receiving todo events, with their delivery log
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.GET("", webhookHandler.List)
+			webhooks.POST("", webhookHandler.Create)
+			webhooks.GET("/:id", webhookHandler.Get)
+			webhooks.PUT("/:id", webhookHandler.Update)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.POST("/:id/ping", webhookHandler.Ping)
+			webhooks.GET("/:id/deliveries", webhookHandler.Deliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+		}
+
+		// Status workflow of the user's workspace
+		workflow := v1.Group("/workflow")
+		{
+			workflow.GET("", workflowHandler.Get)
+			workflow.PUT("", workflowHandler.Update)
+			workflow.DELETE("", workflowHandler.Reset)
+		}
+
+		// Custom field definitions of the user's workspace
+		customFields := v1.Group("/custom-fields")
+		{
+			customFields.GET("", customFieldHandler.List)
+			customFields.POST("", customFieldHandler.Create)
+			customFields.PUT("/:id", customFieldHandler.Update)
+			customFields.DELETE("/:id", customFieldHandler.Delete)
+		}
+
+		// Calendar feed (the feed itself is authenticated by its secret token)
+		calendar := v1.Group("/calendar")
+		{
+			calendar.GET("/feed", calendarHandler.GetFeed)
+			calendar.POST("/feed/regenerate", calendarHandler.RegenerateFeed)
+			calendar.GET("/:token", calendarHandler.Feed)
 		}
 	}
 }
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/rpc/todov1"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// authServer implements todov1.AuthServiceServer with the auth service
+type authServer struct {
+	todov1.UnimplementedAuthServiceServer
+	authService *service.AuthService
+}
+
+func (s *authServer) Register(ctx context.Context, in *todov1.RegisterRequest) (*todov1.RegisterResponse, error) {
+	req := dto.RegisterRequest{
+		Username: in.GetUsername(),
+		Email:    in.GetEmail(),
+		Password: in.GetPassword(),
+		FullName: in.GetFullName(),
+		TimeZone: in.GetTimeZone(),
+		Locale:   in.GetLocale(),
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	user, err := s.authService.Register(req)
+	if err != nil {
+		return nil, serviceError(ctx, "Register", err)
+	}
+	return &todov1.RegisterResponse{User: toUser(user)}, nil
+}
+
+func (s *authServer) Login(ctx context.Context, in *todov1.LoginRequest) (*todov1.LoginResponse, error) {
+	req := dto.LoginRequest{Username: in.GetUsername(), Password: in.GetPassword()}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	login, err := s.authService.Login(req)
+	if err != nil {
+		return nil, serviceError(ctx, "Login", err)
+	}
+	return &todov1.LoginResponse{Token: login.Token, User: toUser(&login.User)}, nil
+}
+
+func (s *authServer) GetProfile(ctx context.Context, in *todov1.GetProfileRequest) (*todov1.GetProfileResponse, error) {
+	user, err := s.authService.GetProfile(currentUser(ctx))
+	if err != nil {
+		return nil, serviceError(ctx, "GetProfile", err)
+	}
+	return &todov1.GetProfileResponse{User: toUser(user)}, nil
+}
+
+func (s *authServer) UpdateProfile(ctx context.Context, in *todov1.UpdateProfileRequest) (*todov1.UpdateProfileResponse, error) {
+	req := dto.UserUpdateRequest{
+		Email:    in.GetEmail(),
+		FullName: in.GetFullName(),
+		TimeZone: in.GetTimeZone(),
+		Locale:   in.GetLocale(),
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	user, err := s.authService.UpdateProfile(currentUser(ctx), req)
+	if err != nil {
+		return nil, serviceError(ctx, "UpdateProfile", err)
+	}
+	return &todov1.UpdateProfileResponse{User: toUser(user)}, nil
+}
+
+// toUser converts a user response to its message
+func toUser(user *dto.UserResponse) *todov1.User {
+	return &todov1.User{
+		Id:        uint64(user.ID),
+		Username:  user.Username,
+		Email:     user.Email,
+		FullName:  user.FullName,
+		TimeZone:  user.TimeZone,
+		Locale:    user.Locale,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the API
+const errorDomain = "todo.v1"
+
+// statusCodes maps the HTTP status of a problem to a gRPC code
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:  codes.InvalidArgument,
+	http.StatusFailedDependency:     codes.Aborted,
+	http.StatusInternalServerError:  codes.Internal,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+}
+
+// CodeOf returns the gRPC code of a problem code with an HTTP status.
+// Conflicts with an existing resource, like user_exists, are AlreadyExists;
+// other conflicts break a rule the current state imposes.
+func CodeOf(httpStatus int, code string) codes.Code {
+	if httpStatus == http.StatusConflict && strings.HasSuffix(code, "_exists") {
+		return codes.AlreadyExists
+	}
+	if grpcCode, ok := statusCodes[httpStatus]; ok {
+		return grpcCode
+	}
+	return codes.Unknown
+}
+
+// newStatus creates an error with the message of a problem code in locale
+func newStatus(locale i18n.Locale, grpcCode codes.Code, code string) error {
+	return withInfo(status.New(grpcCode, i18n.T(locale, "error."+code, nil)), code).Err()
+}
+
+// serviceError converts an error of a service. Unknown errors are logged and
+// described generically, like in REST responses.
+func serviceError(ctx context.Context, method string, err error) error {
+	httpStatus, code := problem.Lookup(err)
+	if code == problem.CodeInternal {
+		log.Printf("gRPC %s: %v", method, err)
+	}
+	st := status.New(CodeOf(httpStatus, code), problem.DetailIn(localeOf(ctx), err))
+	return withInfo(st, code).Err()
+}
+
+// invalidArgument converts the validation error of a request, listing the
+// invalid fields as BadRequest field violations
+func invalidArgument(ctx context.Context, err error) error {
+	locale := localeOf(ctx)
+	badRequest := &errdetails.BadRequest{}
+	for _, fe := range problem.FieldErrors("body", err) {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field,
+			Description: problem.Message(locale, fe),
+		})
+	}
+
+	st := withInfo(status.New(codes.InvalidArgument, i18n.T(locale, "error."+problem.CodeValidationFailed, nil)), problem.CodeValidationFailed)
+	if detailed, err := st.WithDetails(badRequest); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// withInfo adds the problem code to a status as the reason of an ErrorInfo
+func withInfo(st *status.Status, code string) *status.Status {
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: errorDomain})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
@@ -0,0 +1,88 @@
+// Package rpc serves the todo and auth operations over gRPC, next to the
+// REST API. The methods call the same services as the REST handlers and
+// service errors are mapped to gRPC status codes, with the stable code of
+// the matching REST problem as the reason of an ErrorInfo detail.
+package rpc
+
+//go:generate buf generate --template ../../proto/buf.gen.yaml ../../proto
+
+import (
+	"context"
+	"strings"
+
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/rpc/todov1"
+	"rest-api/internal/service"
+	"rest-api/internal/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+// publicMethods can be called without a token
+var publicMethods = map[string]bool{
+	todov1.AuthService_Register_FullMethodName: true,
+	todov1.AuthService_Login_FullMethodName:    true,
+}
+
+// NewServer creates a gRPC server with the todo and auth services, token
+// authentication and server reflection
+func NewServer(todoService *service.TodoService, authService *service.AuthService) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(authInterceptor))
+	todov1.RegisterAuthServiceServer(server, &authServer{authService: authService})
+	todov1.RegisterTodoServiceServer(server, &todoServer{todoService: todoService})
+	reflection.Register(server)
+	return server
+}
+
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	localeKey
+)
+
+// authInterceptor negotiates the locale of messages from the accept-language
+// metadata and, except for public methods, checks the bearer token of the
+// authorization metadata like the REST auth middleware
+func authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	locale := i18n.Negotiate(strings.Join(md.Get("accept-language"), ","))
+	ctx = context.WithValue(ctx, localeKey, locale)
+
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, newStatus(locale, codes.Unauthenticated, problem.CodeMissingToken)
+	}
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, newStatus(locale, codes.Unauthenticated, problem.CodeMalformedToken)
+	}
+	claims, err := utils.ValidateToken(parts[1])
+	if err != nil {
+		return nil, newStatus(locale, codes.Unauthenticated, problem.CodeInvalidToken)
+	}
+
+	return handler(context.WithValue(ctx, userIDKey, claims.UserID), req)
+}
+
+// currentUser returns the ID of the authenticated user
+func currentUser(ctx context.Context) uint {
+	id, _ := ctx.Value(userIDKey).(uint)
+	return id
+}
+
+// localeOf returns the locale of messages negotiated for the call
+func localeOf(ctx context.Context) i18n.Locale {
+	if locale, ok := ctx.Value(localeKey).(i18n.Locale); ok {
+		return locale
+	}
+	return i18n.Default
+}
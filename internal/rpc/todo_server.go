@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/problem"
+	"rest-api/internal/rpc/todov1"
+	"rest-api/internal/service"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is the number of todos ListTodos returns without a limit
+const defaultPageSize = 20
+
+// priorities maps the priority enum to the priorities of the services
+var priorities = map[todov1.Priority]string{
+	todov1.Priority_PRIORITY_LOW:    "low",
+	todov1.Priority_PRIORITY_MEDIUM: "medium",
+	todov1.Priority_PRIORITY_HIGH:   "high",
+}
+
+// todoServer implements todov1.TodoServiceServer with the todo service
+type todoServer struct {
+	todov1.UnimplementedTodoServiceServer
+	todoService *service.TodoService
+}
+
+func (s *todoServer) CreateTodo(ctx context.Context, in *todov1.CreateTodoRequest) (*todov1.CreateTodoResponse, error) {
+	req := dto.CreateTodoRequest{
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		Status:      in.GetStatus(),
+		Priority:    priorities[in.GetPriority()],
+		DueDate:     in.GetDueDate(),
+		Tags:        in.GetTags(),
+		Recurrence:  in.GetRecurrence(),
+	}
+	if in.EstimateMinutes != nil {
+		minutes := int(in.GetEstimateMinutes())
+		req.EstimateMinutes = &minutes
+	}
+	if in.CustomFields != nil {
+		req.CustomFields = in.CustomFields.AsMap()
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	userID := currentUser(ctx)
+	todo, err := s.todoService.CreateTodo(userID, req)
+	if err != nil {
+		return nil, serviceError(ctx, "CreateTodo", err)
+	}
+	return &todov1.CreateTodoResponse{Todo: toTodo(todo, s.todoService.UserLocation(userID))}, nil
+}
+
+func (s *todoServer) GetTodo(ctx context.Context, in *todov1.GetTodoRequest) (*todov1.GetTodoResponse, error) {
+	todoID, err := parseID(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	userID := currentUser(ctx)
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, serviceError(ctx, "GetTodo", err)
+	}
+	return &todov1.GetTodoResponse{Todo: toTodo(todo, s.todoService.UserLocation(userID))}, nil
+}
+
+func (s *todoServer) ListTodos(ctx context.Context, in *todov1.ListTodosRequest) (*todov1.ListTodosResponse, error) {
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	page := struct {
+		Limit  int `json:"limit" binding:"min=1,max=100"`
+		Offset int `json:"offset" binding:"min=0"`
+	}{limit, int(in.GetOffset())}
+	if err := binding.Validator.ValidateStruct(&page); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	query := dto.TodoListQuery{
+		Status:   in.GetStatus(),
+		Priority: priorities[in.GetPriority()],
+		Tags:     in.GetTags(),
+		Due:      in.GetDue(),
+		Query:    in.GetQ(),
+		Open:     in.GetOpen(),
+		Sort:     in.GetSort(),
+	}
+	userID := currentUser(ctx)
+	todos, total, err := s.todoService.ListTodosPage(userID, query, page.Limit, page.Offset)
+	if err != nil {
+		return nil, serviceError(ctx, "ListTodos", err)
+	}
+
+	loc := s.todoService.UserLocation(userID)
+	response := &todov1.ListTodosResponse{Todos: make([]*todov1.Todo, len(todos)), TotalCount: total}
+	for i := range todos {
+		response.Todos[i] = toTodo(&todos[i], loc)
+	}
+	return response, nil
+}
+
+func (s *todoServer) UpdateTodo(ctx context.Context, in *todov1.UpdateTodoRequest) (*todov1.UpdateTodoResponse, error) {
+	todoID, err := parseID(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	req := dto.UpdateTodoRequest{
+		Title:       in.Title,
+		Description: in.Description,
+		Status:      in.Status,
+		DueDate:     in.DueDate,
+		Recurrence:  in.Recurrence,
+	}
+	if priority, ok := priorities[in.GetPriority()]; ok {
+		req.Priority = &priority
+	}
+	if in.Tags != nil {
+		tags := in.Tags.GetTags()
+		req.Tags = &tags
+	}
+	if in.EstimateMinutes != nil {
+		minutes := int(in.GetEstimateMinutes())
+		req.EstimateMinutes = &minutes
+	}
+	if in.CustomFields != nil {
+		req.CustomFields = in.CustomFields.AsMap()
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	userID := currentUser(ctx)
+	todo, err := s.todoService.UpdateTodo(todoID, userID, req)
+	if err != nil {
+		return nil, serviceError(ctx, "UpdateTodo", err)
+	}
+	return &todov1.UpdateTodoResponse{Todo: toTodo(todo, s.todoService.UserLocation(userID))}, nil
+}
+
+func (s *todoServer) DeleteTodo(ctx context.Context, in *todov1.DeleteTodoRequest) (*todov1.DeleteTodoResponse, error) {
+	todoID, err := parseID(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.todoService.DeleteTodo(todoID, currentUser(ctx)); err != nil {
+		return nil, serviceError(ctx, "DeleteTodo", err)
+	}
+	return &todov1.DeleteTodoResponse{}, nil
+}
+
+// parseID checks a todo ID of a request
+func parseID(ctx context.Context, id uint64) (uint, error) {
+	if id == 0 || uint64(uint(id)) != id {
+		return 0, newStatus(localeOf(ctx), codes.InvalidArgument, problem.CodeInvalidID)
+	}
+	return uint(id), nil
+}
+
+// toTodo converts a todo to its message, rendering due dates in the user's
+// time zone like the REST responses
+func toTodo(todo *model.Todo, loc *time.Location) *todov1.Todo {
+	message := &todov1.Todo{
+		Id:          uint64(todo.ID),
+		Title:       todo.Title,
+		Description: todo.Description,
+		Status:      todo.Status,
+		Blocked:     todo.Blocked,
+		Tags:        make([]string, len(todo.Tags)),
+		Recurrence:  todo.Recurrence,
+		Position:    todo.Position,
+		StartedAt:   timestamp(todo.StartedAt),
+		CompletedAt: timestamp(todo.CompletedAt),
+		UserId:      uint64(todo.UserID),
+		CreatedAt:   timestamppb.New(todo.CreatedAt),
+		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
+	}
+	for value, name := range priorities {
+		if name == todo.Priority {
+			message.Priority = value
+		}
+	}
+	for i, tag := range todo.Tags {
+		message.Tags[i] = tag.Name
+	}
+	if todo.EstimateMinutes != nil {
+		minutes := int32(*todo.EstimateMinutes)
+		message.EstimateMinutes = &minutes
+	}
+
+	if len(todo.FieldValues) > 0 {
+		fields := make(map[string]interface{}, len(todo.FieldValues))
+		for i := range todo.FieldValues {
+			if field := todo.FieldValues[i].Field; field != nil {
+				fields[field.Key] = service.FieldValueJSON(&todo.FieldValues[i])
+			}
+		}
+		message.CustomFields = customFields(fields)
+	}
+
+	if todo.DueDate != nil {
+		now := time.Now()
+		message.DueDate = timestamppb.New(service.DueDateIn(todo, loc))
+		message.DueAllDay = todo.DueAllDay
+		message.DueToday = service.IsDueToday(todo, now, loc)
+		message.Overdue = service.IsOverdue(todo, now, loc)
+	}
+
+	return message
+}
+
+// customFields converts custom field values through their JSON form, which
+// also covers values that structpb does not take directly, like []string
+func customFields(fields map[string]interface{}) *structpb.Struct {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	message := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, message); err != nil {
+		return nil
+	}
+	return message
+}
+
+// timestamp converts an optional time
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"rest-api/internal/dto"
@@ -17,15 +19,22 @@ var (
 	ErrEmailExists        = errors.New("email already exists")
 	ErrInvalidCredentials = errors.New("invalid username or password")
 	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidTimeZone    = errors.New("invalid time zone, use an IANA name such as Asia/Jakarta")
+	ErrInvalidResetToken  = errors.New("reset token expired or invalid")
 )
 
 type AuthService struct {
 	userRepo *repository.UserRepository
+	outbox   *OutboxRelay
 }
 
-func NewAuthService(userRepo *repository.UserRepository) *AuthService {
+// NewAuthService creates a new auth service instance. Password reset emails
+// are written to the outbox and sent by outbox, which may be nil when
+// another process relays them.
+func NewAuthService(userRepo *repository.UserRepository, outbox *OutboxRelay) *AuthService {
 	return &AuthService{
 		userRepo: userRepo,
+		outbox:   outbox,
 	}
 }
 
@@ -49,7 +58,16 @@ func (service *AuthService) Register(req dto.RegisterRequest) (*dto.UserResponse
 		return nil, ErrEmailExists
 	}
 
-	// Business rule 3: New Username, New Email (Valid New User)
+	// Business rule 3: time zone must be a known IANA name
+	timeZone := req.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+
+	// Business rule 4: New Username, New Email (Valid New User)
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
@@ -61,6 +79,8 @@ func (service *AuthService) Register(req dto.RegisterRequest) (*dto.UserResponse
 		Email:    req.Email,
 		Password: hashedPassword,
 		Fullname: req.FullName,
+		TimeZone: timeZone,
+		Locale:   req.Locale,
 	}
 
 	// save to database via repository
@@ -114,6 +134,21 @@ func (s *AuthService) GetProfile(userID uint) (*dto.UserResponse, error) {
 	return s.toUserResponse(user), nil
 }
 
+// GetProfiles mendapatkan profile beberapa user sekaligus, key-nya user ID.
+// User yang tidak ditemukan tidak ada di map.
+func (s *AuthService) GetProfiles(userIDs []uint) (map[uint]*dto.UserResponse, error) {
+	users, err := s.userRepo.FindByIDs(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	profiles := make(map[uint]*dto.UserResponse, len(users))
+	for i := range users {
+		profiles[users[i].ID] = s.toUserResponse(&users[i])
+	}
+	return profiles, nil
+}
+
 // UpdateProfile mengupdate profile user
 func (s *AuthService) UpdateProfile(userID uint, req dto.UserUpdateRequest) (*dto.UserResponse, error) {
 	// Find existing user
@@ -142,6 +177,17 @@ func (s *AuthService) UpdateProfile(userID uint, req dto.UserUpdateRequest) (*dt
 		user.Fullname = req.FullName
 	}
 
+	if req.TimeZone != "" {
+		if _, err := time.LoadLocation(req.TimeZone); err != nil {
+			return nil, ErrInvalidTimeZone
+		}
+		user.TimeZone = req.TimeZone
+	}
+
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
+
 	// Save changes
 	if err := s.userRepo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
@@ -156,13 +202,17 @@ func (s *AuthService) toUserResponse(user *model.User) *dto.UserResponse {
 		Username:  user.Username,
 		Email:     user.Email,
 		FullName:  user.Fullname,
+		TimeZone:  user.TimeZone,
+		Locale:    user.Locale,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
 }
 
 // RequestPasswordReset initiates a password reset flow for the given email.
-// It generates a secure token, saves it with expiry, and sends a reset email.
+// It generates a secure token and saves it with expiry together with the
+// reset email in the outbox, so the email is sent if and only if the token
+// is saved.
 func (s *AuthService) RequestPasswordReset(email string, serverPort string) error {
 	user, err := s.userRepo.FindByEmail(email)
 	if err != nil {
@@ -183,18 +233,26 @@ func (s *AuthService) RequestPasswordReset(email string, serverPort string) erro
 	// expiry (1 hour)
 	expiry := time.Now().Add(1 * time.Hour)
 
-	// save token and expiry
-	if err := s.userRepo.SaveResetToken(user.ID, token, &expiry); err != nil {
-		return fmt.Errorf("failed to save reset token: %w", err)
-	}
-
 	// build reset link (API confirm endpoint expects POST; this link contains token)
 	resetLink := fmt.Sprintf("http://localhost:%s/api/v1/auth/reset-password/confirm?token=%s", serverPort, token)
 
-	// send email (placeholder)
-	if err := utils.SendResetEmail(user.Email, resetLink); err != nil {
-		return fmt.Errorf("failed to send reset email: %w", err)
+	// save token and expiry with the email, which the outbox relay sends
+	// once they are committed
+	err = s.userRepo.Transaction(func(txRepo *repository.UserRepository) error {
+		if err := txRepo.SaveResetToken(user.ID, token, &expiry); err != nil {
+			return fmt.Errorf("failed to save reset token: %w", err)
+		}
+		digest := sha256.Sum256([]byte(token))
+		email := PasswordResetEmail{Email: user.Email, Link: resetLink}
+		if err := writeOutbox(txRepo.Outbox(), TopicPasswordResetEmail, "password_reset:"+hex.EncodeToString(digest[:]), email); err != nil {
+			return fmt.Errorf("failed to queue reset email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	s.outbox.Notify()
 
 	return nil
 }
@@ -209,7 +267,7 @@ func (s *AuthService) ResetPassword(token, newPassword string) error {
 		return ErrUserNotFound
 	}
 	if user.ResetPasswordExpiry == nil || time.Now().After(*user.ResetPasswordExpiry) {
-		return errors.New("reset token expired or invalid")
+		return ErrInvalidResetToken
 	}
 
 	// hash new password
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+	"rest-api/internal/utils"
+)
+
+// Calendar component types rendered in a feed
+const (
+	CalendarTypeEvent = "event" // VEVENT, shown by most calendar apps
+	CalendarTypeTodo  = "todo"  // VTODO, shown by task-aware apps
+	CalendarTypeBoth  = "both"
+)
+
+var (
+	// ErrCalendarFeedNotFound is returned when no feed matches the token
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	// ErrInvalidCalendarType is returned when the component type is unknown
+	ErrInvalidCalendarType = errors.New("invalid calendar type, use event, todo or both")
+)
+
+// CalendarService handles iCalendar feeds of todos
+type CalendarService struct {
+	feedRepo     *repository.CalendarFeedRepository
+	todoRepo     *repository.TodoRepository
+	workflowRepo *repository.WorkflowRepository
+}
+
+// NewCalendarService creates a new calendar service instance
+func NewCalendarService(feedRepo *repository.CalendarFeedRepository, todoRepo *repository.TodoRepository, workflowRepo *repository.WorkflowRepository) *CalendarService {
+	return &CalendarService{
+		feedRepo:     feedRepo,
+		todoRepo:     todoRepo,
+		workflowRepo: workflowRepo,
+	}
+}
+
+// GetOrCreateFeed returns the feed of a user, creating it on first use
+func (s *CalendarService) GetOrCreateFeed(userID uint) (*model.CalendarFeed, error) {
+	feed, err := s.feedRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find calendar feed: %w", err)
+	}
+	if feed != nil {
+		return feed, nil
+	}
+
+	token, err := generateCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed = &model.CalendarFeed{UserID: userID, Token: token}
+	if err := s.feedRepo.Create(feed); err != nil {
+		return nil, fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// RegenerateFeed replaces the token of a user's feed, invalidating the old URL
+func (s *CalendarService) RegenerateFeed(userID uint) (*model.CalendarFeed, error) {
+	feed, err := s.GetOrCreateFeed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Token = token
+	if err := s.feedRepo.Update(feed); err != nil {
+		return nil, fmt.Errorf("failed to update calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// RenderFeed renders the todos with a due date of the feed owner as iCalendar.
+// status and priority optionally narrow the feed like the list endpoint.
+func (s *CalendarService) RenderFeed(token, calendarType, status, priority string) (string, error) {
+	if calendarType == "" {
+		calendarType = CalendarTypeEvent
+	}
+	if calendarType != CalendarTypeEvent && calendarType != CalendarTypeTodo && calendarType != CalendarTypeBoth {
+		return "", ErrInvalidCalendarType
+	}
+	if priority != "" && !isValidPriority(priority) {
+		return "", ErrInvalidPriority
+	}
+
+	feed, err := s.feedRepo.FindByToken(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to find calendar feed: %w", err)
+	}
+	if feed == nil {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	if status != "" {
+		workflow, _, err := loadWorkflow(s.workflowRepo, feed.UserID)
+		if err != nil {
+			return "", err
+		}
+		if !workflow.HasStatus(status) {
+			return "", ErrInvalidStatus
+		}
+	}
+
+	todos, err := s.todoRepo.FindByFilter(feed.UserID, repository.TodoFilter{
+		Status:     status,
+		Priority:   priority,
+		HasDueDate: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to find todos: %w", err)
+	}
+
+	calendar := utils.NewICalendar("Todos")
+	for i := range todos {
+		if calendarType == CalendarTypeEvent || calendarType == CalendarTypeBoth {
+			writeTodoEvent(calendar, &todos[i])
+		}
+		if calendarType == CalendarTypeTodo || calendarType == CalendarTypeBoth {
+			writeTodoTask(calendar, &todos[i])
+		}
+	}
+
+	return calendar.String(), nil
+}
+
+// writeTodoEvent writes a todo as a VEVENT at its due time, or all-day on its due date
+func writeTodoEvent(calendar *utils.ICalendar, todo *model.Todo) {
+	calendar.Begin("VEVENT")
+	calendar.Property("UID", fmt.Sprintf("todo-%d-event@rest-api", todo.ID))
+	calendar.Property("DTSTAMP", utils.ICalDateTime(todo.UpdatedAt))
+	if todo.DueAllDay {
+		calendar.Property("DTSTART;VALUE=DATE", utils.ICalDate(*todo.DueDate))
+		calendar.Property("DTEND;VALUE=DATE", utils.ICalDate(todo.DueDate.AddDate(0, 0, 1)))
+	} else {
+		calendar.Property("DTSTART", utils.ICalDateTime(*todo.DueDate))
+		calendar.Property("DURATION", "PT15M")
+	}
+	if todo.Recurrence != "" {
+		calendar.Property("RRULE", todo.Recurrence)
+	}
+	writeTodoCommon(calendar, todo)
+	if todo.CompletedAt != nil {
+		calendar.Property("TRANSP", "TRANSPARENT")
+	}
+	calendar.End("VEVENT")
+}
+
+// writeTodoTask writes a todo as a VTODO due on its due date
+func writeTodoTask(calendar *utils.ICalendar, todo *model.Todo) {
+	calendar.Begin("VTODO")
+	calendar.Property("UID", fmt.Sprintf("todo-%d@rest-api", todo.ID))
+	calendar.Property("DTSTAMP", utils.ICalDateTime(todo.UpdatedAt))
+	if todo.DueAllDay {
+		calendar.Property("DUE;VALUE=DATE", utils.ICalDate(*todo.DueDate))
+	} else {
+		calendar.Property("DUE", utils.ICalDateTime(*todo.DueDate))
+	}
+	calendar.Property("STATUS", icalTodoStatus(todo))
+	calendar.Property("PRIORITY", icalPriority(todo.Priority))
+	writeTodoCommon(calendar, todo)
+	calendar.End("VTODO")
+}
+
+// writeTodoCommon writes the properties shared by VEVENT and VTODO
+func writeTodoCommon(calendar *utils.ICalendar, todo *model.Todo) {
+	calendar.Text("SUMMARY", todo.Title)
+	if todo.Description != "" {
+		calendar.Text("DESCRIPTION", todo.Description)
+	}
+	calendar.Text("CATEGORIES", todo.Priority)
+	calendar.Property("CREATED", utils.ICalDateTime(todo.CreatedAt))
+	calendar.Property("LAST-MODIFIED", utils.ICalDateTime(todo.UpdatedAt))
+}
+
+// icalTodoStatus maps the progress of a todo to VTODO statuses
+func icalTodoStatus(todo *model.Todo) string {
+	switch {
+	case todo.CompletedAt != nil:
+		return "COMPLETED"
+	case todo.StartedAt != nil:
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icalPriority maps todo priorities to iCalendar priorities (1 highest, 9 lowest)
+func icalPriority(priority string) string {
+	switch priority {
+	case "high":
+		return "1"
+	case "low":
+		return "9"
+	default:
+		return "5"
+	}
+}
+
+// generateCalendarToken creates a random URL-safe feed token
+func generateCalendarToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
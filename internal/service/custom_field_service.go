@@ -0,0 +1,410 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// maxCustomFields limits the number of custom fields of a workspace
+const maxCustomFields = 50
+
+var (
+	// ErrCustomFieldNotFound is returned when a custom field is not found
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	// ErrCustomFieldExists is returned when the key is already used
+	ErrCustomFieldExists = errors.New("custom field key already exists")
+	// ErrInvalidCustomFieldDefinition is returned when a field definition is invalid
+	ErrInvalidCustomFieldDefinition = errors.New("invalid custom field definition")
+	// ErrInvalidCustomField is returned when a todo has an invalid custom field value
+	ErrInvalidCustomField = errors.New("invalid custom field value")
+)
+
+// customFieldKeyPattern limits keys to identifiers usable in cf.<key> query parameters
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+// CustomFieldService handles custom field definitions of a user's workspace
+type CustomFieldService struct {
+	fieldRepo *repository.CustomFieldRepository
+}
+
+// NewCustomFieldService creates a new custom field service instance
+func NewCustomFieldService(fieldRepo *repository.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{
+		fieldRepo: fieldRepo,
+	}
+}
+
+// ListFields returns the custom fields of a user
+func (s *CustomFieldService) ListFields(userID uint) ([]model.CustomField, error) {
+	return s.fieldRepo.FindByUserID(userID)
+}
+
+// CreateField creates a custom field definition
+func (s *CustomFieldService) CreateField(userID uint, req dto.CreateCustomFieldRequest) (*model.CustomField, error) {
+	if !customFieldKeyPattern.MatchString(req.Key) {
+		return nil, fmt.Errorf("%w: key must be lower-case letters, digits or _ and at most 30 characters", ErrInvalidCustomFieldDefinition)
+	}
+
+	exists, err := s.fieldRepo.ExistsByUserIDAndKey(userID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCustomFieldExists
+	}
+
+	count, err := s.fieldRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCustomFields {
+		return nil, fmt.Errorf("%w: at most %d custom fields are allowed", ErrInvalidCustomFieldDefinition, maxCustomFields)
+	}
+
+	field := &model.CustomField{
+		UserID:   userID,
+		Key:      req.Key,
+		Name:     req.Name,
+		Type:     req.Type,
+		Required: req.Required,
+		Position: req.Position,
+	}
+	if err := setFieldOptions(field, req.Options); err != nil {
+		return nil, err
+	}
+
+	if err := s.fieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// UpdateField updates the name, options, required flag or position of a
+// custom field. The key and type cannot change.
+func (s *CustomFieldService) UpdateField(fieldID, userID uint, req dto.UpdateCustomFieldRequest) (*model.CustomField, error) {
+	field, err := s.getField(fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		field.Name = *req.Name
+	}
+	if req.Options != nil {
+		if err := setFieldOptions(field, *req.Options); err != nil {
+			return nil, err
+		}
+	}
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	if err := s.fieldRepo.Update(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// DeleteField deletes a custom field and its values on all todos
+func (s *CustomFieldService) DeleteField(fieldID, userID uint) error {
+	field, err := s.getField(fieldID, userID)
+	if err != nil {
+		return err
+	}
+	return s.fieldRepo.Delete(field)
+}
+
+func (s *CustomFieldService) getField(fieldID, userID uint) (*model.CustomField, error) {
+	field, err := s.fieldRepo.FindByIDAndUserID(fieldID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if field == nil {
+		return nil, ErrCustomFieldNotFound
+	}
+	return field, nil
+}
+
+// setFieldOptions validates and stores the choices of a select field
+func setFieldOptions(field *model.CustomField, options []string) error {
+	if field.Type != model.CustomFieldSelect && field.Type != model.CustomFieldMultiSelect {
+		if len(options) > 0 {
+			return fmt.Errorf("%w: only select and multi_select fields have options", ErrInvalidCustomFieldDefinition)
+		}
+		field.Options = ""
+		return nil
+	}
+
+	if len(options) == 0 || len(options) > 100 {
+		return fmt.Errorf("%w: select fields need 1 to 100 options", ErrInvalidCustomFieldDefinition)
+	}
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option == "" || utf8.RuneCountInString(option) > 100 || seen[option] {
+			return fmt.Errorf("%w: options must be unique and 1-100 characters", ErrInvalidCustomFieldDefinition)
+		}
+		seen[option] = true
+	}
+
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	field.Options = string(encoded)
+	return nil
+}
+
+// FieldOptions returns the choices of a select or multi_select field
+func FieldOptions(field *model.CustomField) []string {
+	options := []string{}
+	if field.Options != "" {
+		json.Unmarshal([]byte(field.Options), &options)
+	}
+	return options
+}
+
+// findField returns the field with key from fields, or nil
+func findField(fields []model.CustomField, key string) *model.CustomField {
+	for i := range fields {
+		if fields[i].Key == key {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// newFieldValue validates a JSON decoded value against the type of a field
+// and builds the typed value to store
+func newFieldValue(field *model.CustomField, raw interface{}) (*model.TodoFieldValue, error) {
+	value := &model.TodoFieldValue{FieldID: field.ID, Field: field}
+	invalid := func(expected string) error {
+		return fmt.Errorf("%w: %s must be %s", ErrInvalidCustomField, field.Key, expected)
+	}
+
+	switch field.Type {
+	case model.CustomFieldText:
+		text, ok := raw.(string)
+		if !ok || utf8.RuneCountInString(text) > 1000 {
+			return nil, invalid("a string of at most 1000 characters")
+		}
+		value.TextValue = &text
+
+	case model.CustomFieldURL:
+		text, ok := raw.(string)
+		if !ok || len(text) > 2000 || !isValidFieldURL(text) {
+			return nil, invalid("an http or https URL")
+		}
+		value.TextValue = &text
+
+	case model.CustomFieldSelect:
+		text, ok := raw.(string)
+		if !ok || !containsString(FieldOptions(field), text) {
+			return nil, invalid("one of " + strings.Join(FieldOptions(field), ", "))
+		}
+		value.TextValue = &text
+
+	case model.CustomFieldMultiSelect:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, invalid("an array of options")
+		}
+		options := FieldOptions(field)
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			text, ok := item.(string)
+			if !ok || !containsString(options, text) {
+				return nil, invalid("an array of " + strings.Join(options, ", "))
+			}
+			if !containsString(selected, text) {
+				selected = append(selected, text)
+			}
+		}
+		encoded, _ := json.Marshal(selected)
+		text := string(encoded)
+		value.TextValue = &text
+
+	case model.CustomFieldNumber:
+		number, ok := raw.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, invalid("a number")
+		}
+		value.NumberValue = &number
+
+	case model.CustomFieldUser:
+		number, ok := raw.(float64)
+		if !ok || number < 1 || number != math.Trunc(number) {
+			return nil, invalid("a user ID")
+		}
+		value.NumberValue = &number
+
+	case model.CustomFieldDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a YYYY-MM-DD date")
+		}
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return nil, invalid("a YYYY-MM-DD date")
+		}
+		value.DateValue = &date
+
+	default:
+		return nil, fmt.Errorf("%w: %s has unknown type %s", ErrInvalidCustomField, field.Key, field.Type)
+	}
+
+	return value, nil
+}
+
+// FieldValueJSON returns a stored custom field value in its JSON form
+func FieldValueJSON(value *model.TodoFieldValue) interface{} {
+	if value.Field == nil {
+		return nil
+	}
+
+	switch value.Field.Type {
+	case model.CustomFieldMultiSelect:
+		selected := []string{}
+		if value.TextValue != nil {
+			json.Unmarshal([]byte(*value.TextValue), &selected)
+		}
+		return selected
+	case model.CustomFieldNumber:
+		if value.NumberValue != nil {
+			return *value.NumberValue
+		}
+	case model.CustomFieldUser:
+		if value.NumberValue != nil {
+			return uint(*value.NumberValue)
+		}
+	case model.CustomFieldDate:
+		if value.DateValue != nil {
+			return value.DateValue.UTC().Format("2006-01-02")
+		}
+	default:
+		if value.TextValue != nil {
+			return *value.TextValue
+		}
+	}
+	return nil
+}
+
+// applyFieldValues sets or clears (nil value) the custom fields in values on
+// a todo. With create, required fields missing from values are rejected.
+func applyFieldValues(todo *model.Todo, values map[string]interface{}, fields []model.CustomField, create bool) error {
+	for key, raw := range values {
+		field := findField(fields, key)
+		if field == nil {
+			return fmt.Errorf("%w: unknown field %s", ErrInvalidCustomField, key)
+		}
+
+		kept := todo.FieldValues[:0]
+		for _, existing := range todo.FieldValues {
+			if existing.FieldID != field.ID {
+				kept = append(kept, existing)
+			}
+		}
+		todo.FieldValues = kept
+
+		if raw == nil {
+			continue
+		}
+		value, err := newFieldValue(field, raw)
+		if err != nil {
+			return err
+		}
+		todo.FieldValues = append(todo.FieldValues, *value)
+	}
+
+	for i := range fields {
+		if !fields[i].Required {
+			continue
+		}
+		if _, given := values[fields[i].Key]; !given && !create {
+			continue
+		}
+		if !hasFieldValue(todo, fields[i].ID) {
+			return fmt.Errorf("%w: %s is required", ErrInvalidCustomField, fields[i].Key)
+		}
+	}
+
+	return nil
+}
+
+func hasFieldValue(todo *model.Todo, fieldID uint) bool {
+	for _, value := range todo.FieldValues {
+		if value.FieldID == fieldID {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldCondition builds the repository condition filtering a custom field by
+// a query parameter value
+func fieldCondition(field *model.CustomField, text string) (repository.FieldCondition, error) {
+	condition := repository.FieldCondition{FieldID: field.ID, Column: "text_value", Value: text}
+	invalid := fmt.Errorf("%w: cannot filter %s by %q", ErrInvalidCustomField, field.Key, text)
+
+	switch field.Type {
+	case model.CustomFieldNumber, model.CustomFieldUser:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return condition, invalid
+		}
+		condition.Column, condition.Value = "number_value", number
+	case model.CustomFieldDate:
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return condition, invalid
+		}
+		condition.Column, condition.Value = "date_value", date
+	case model.CustomFieldMultiSelect:
+		// multi_select values are JSON arrays, match the quoted option
+		encoded, _ := json.Marshal(text)
+		condition.Value = containsPattern(string(encoded))
+		condition.Contains = true
+	}
+
+	return condition, nil
+}
+
+// fieldSortColumn returns the value column a custom field sorts by
+func fieldSortColumn(field *model.CustomField) string {
+	switch field.Type {
+	case model.CustomFieldNumber, model.CustomFieldUser:
+		return "number_value"
+	case model.CustomFieldDate:
+		return "date_value"
+	default:
+		return "text_value"
+	}
+}
+
+func isValidFieldURL(text string) bool {
+	parsed, err := url.ParseRequestURI(text)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
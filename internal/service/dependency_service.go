@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"sort"
+
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+var (
+	// ErrDependencyCycle is returned when a dependency would make todos block each other
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+	// ErrDependencyExists is returned when the dependency is already recorded
+	ErrDependencyExists = errors.New("dependency already exists")
+	// ErrDependencyNotFound is returned when a dependency to remove does not exist
+	ErrDependencyNotFound = errors.New("dependency not found")
+)
+
+// priorityRank orders priorities from most to least urgent
+var priorityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// DependencyService handles blocks / blocked-by relations between todos
+type DependencyService struct {
+	depRepo     *repository.DependencyRepository
+	todoService *TodoService
+}
+
+// NewDependencyService creates a new dependency service instance
+func NewDependencyService(depRepo *repository.DependencyRepository, todoService *TodoService) *DependencyService {
+	return &DependencyService{
+		depRepo:     depRepo,
+		todoService: todoService,
+	}
+}
+
+// AddDependency records that todoID cannot start before blockedByID is
+// finished. Both todos must belong to the user and the new link must not
+// close a cycle.
+func (s *DependencyService) AddDependency(todoID, blockedByID, userID uint) error {
+	if todoID == blockedByID {
+		return ErrDependencyCycle
+	}
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return err
+	}
+	if _, err := s.todoService.GetTodoByID(blockedByID, userID); err != nil {
+		return err
+	}
+
+	// The cycle check must see every link created before this one: two
+	// requests adding a->b and b->a at the same time would both pass it
+	return s.depRepo.Transaction(func(txRepo *repository.DependencyRepository) error {
+		if err := txRepo.LockUser(userID); err != nil {
+			return err
+		}
+
+		exists, err := txRepo.Exists(todoID, blockedByID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrDependencyExists
+		}
+
+		dependencies, err := txRepo.FindByUserID(userID)
+		if err != nil {
+			return err
+		}
+		if reachesBlocker(dependencies, blockedByID, todoID) {
+			return ErrDependencyCycle
+		}
+
+		return txRepo.Create(&model.TodoDependency{
+			UserID:      userID,
+			TodoID:      todoID,
+			BlockedByID: blockedByID,
+		})
+	})
+}
+
+// RemoveDependency removes the dependency of todoID on blockedByID
+func (s *DependencyService) RemoveDependency(todoID, blockedByID, userID uint) error {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return err
+	}
+
+	deleted, err := s.depRepo.Delete(todoID, blockedByID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDependencyNotFound
+	}
+	return nil
+}
+
+// GetDependencies returns the todos blocking todoID and the todos it blocks
+func (s *DependencyService) GetDependencies(todoID, userID uint) (blockers, blocked []model.Todo, err error) {
+	if _, err := s.todoService.GetTodoByID(todoID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	if blockers, err = s.depRepo.FindBlockers(todoID); err != nil {
+		return nil, nil, err
+	}
+	if blocked, err = s.depRepo.FindBlocked(todoID); err != nil {
+		return nil, nil, err
+	}
+	return blockers, blocked, nil
+}
+
+// GetDependenciesOf returns the blockers and the blocked todos of each of
+// todoIDs, keyed by todo ID, with two queries for all of them
+func (s *DependencyService) GetDependenciesOf(todoIDs []uint, userID uint) (blockers, blocked map[uint][]model.Todo, err error) {
+	dependencies, err := s.depRepo.FindByTodoIDs(todoIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var relatedIDs []uint
+	for _, dependency := range dependencies {
+		relatedIDs = append(relatedIDs, dependency.TodoID, dependency.BlockedByID)
+	}
+	todos, err := s.todoService.todoRepo.FindByIDs(userID, relatedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	refs := make([]*model.Todo, len(todos))
+	for i := range todos {
+		refs[i] = &todos[i]
+	}
+	if err := s.todoService.markBlocked(refs); err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uint]model.Todo, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+
+	blockers = make(map[uint][]model.Todo)
+	blocked = make(map[uint][]model.Todo)
+	for _, dependency := range dependencies {
+		// Todos of other users are skipped by FindByIDs
+		blocker, ok := byID[dependency.BlockedByID]
+		dependent, ok2 := byID[dependency.TodoID]
+		if !ok || !ok2 {
+			continue
+		}
+		blockers[dependency.TodoID] = append(blockers[dependency.TodoID], blocker)
+		blocked[dependency.BlockedByID] = append(blocked[dependency.BlockedByID], dependent)
+	}
+	return blockers, blocked, nil
+}
+
+// NextTodos returns the open todos of a user in topological order: every
+// todo comes after its open blockers. Among the todos that are ready at the
+// same time, higher priority and earlier due dates come first. With
+// readyOnly, only the todos that are not blocked are returned.
+func (s *DependencyService) NextTodos(userID uint, readyOnly bool) ([]model.Todo, error) {
+	todos, err := s.todoService.findTodos(userID, repository.TodoFilter{Open: true})
+	if err != nil {
+		return nil, err
+	}
+	if readyOnly {
+		ready := todos[:0]
+		for _, todo := range todos {
+			if !todo.Blocked {
+				ready = append(ready, todo)
+			}
+		}
+		sort.SliceStable(ready, func(i, j int) bool { return todoBefore(&ready[i], &ready[j]) })
+		return ready, nil
+	}
+
+	dependencies, err := s.depRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[uint]int, len(todos))
+	for i, todo := range todos {
+		index[todo.ID] = i
+	}
+
+	// Only links between open todos constrain the order
+	waiting := make([]int, len(todos))
+	unblocks := make(map[uint][]uint)
+	for _, dependency := range dependencies {
+		blocked, ok := index[dependency.TodoID]
+		if _, open := index[dependency.BlockedByID]; !ok || !open {
+			continue
+		}
+		waiting[blocked]++
+		unblocks[dependency.BlockedByID] = append(unblocks[dependency.BlockedByID], dependency.TodoID)
+	}
+
+	var ready []*model.Todo
+	for i := range todos {
+		if waiting[i] == 0 {
+			ready = append(ready, &todos[i])
+		}
+	}
+
+	ordered := make([]model.Todo, 0, len(todos))
+	for len(ready) > 0 {
+		sort.SliceStable(ready, func(i, j int) bool { return todoBefore(ready[i], ready[j]) })
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, *next)
+
+		for _, id := range unblocks[next.ID] {
+			i := index[id]
+			waiting[i]--
+			if waiting[i] == 0 {
+				ready = append(ready, &todos[i])
+			}
+		}
+	}
+
+	// Links closed before cycle checks were atomic can leave todos that never
+	// become ready; they still belong in the list
+	if len(ordered) < len(todos) {
+		var leftovers []*model.Todo
+		for i := range todos {
+			if waiting[i] > 0 {
+				leftovers = append(leftovers, &todos[i])
+			}
+		}
+		sort.SliceStable(leftovers, func(i, j int) bool { return todoBefore(leftovers[i], leftovers[j]) })
+		for _, todo := range leftovers {
+			ordered = append(ordered, *todo)
+		}
+	}
+
+	return ordered, nil
+}
+
+// reachesBlocker reports whether target is reachable from start by following
+// blocked-by links, i.e. whether start (transitively) waits for target
+func reachesBlocker(dependencies []model.TodoDependency, start, target uint) bool {
+	blockers := make(map[uint][]uint)
+	for _, dependency := range dependencies {
+		blockers[dependency.TodoID] = append(blockers[dependency.TodoID], dependency.BlockedByID)
+	}
+
+	visited := map[uint]bool{start: true}
+	stack := []uint{start}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == target {
+			return true
+		}
+		for _, blocker := range blockers[current] {
+			if !visited[blocker] {
+				visited[blocker] = true
+				stack = append(stack, blocker)
+			}
+		}
+	}
+	return false
+}
+
+// todoBefore orders todos by priority, then due date (todos without one
+// last), then creation
+func todoBefore(a, b *model.Todo) bool {
+	if priorityRank[a.Priority] != priorityRank[b.Priority] {
+		return priorityRank[a.Priority] < priorityRank[b.Priority]
+	}
+	if (a.DueDate == nil) != (b.DueDate == nil) {
+		return a.DueDate != nil
+	}
+	if a.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+		return a.DueDate.Before(*b.DueDate)
+	}
+	return a.ID < b.ID
+}
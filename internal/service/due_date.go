@@ -0,0 +1,131 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// localDueLayouts are due date-times without an offset, read in the user's time zone
+var localDueLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseDueDate parses a due date given as YYYY-MM-DD (all-day), RFC 3339, or a
+// local date-time without offset interpreted in loc. Timed values are returned
+// in UTC; all-day values as midnight UTC of that calendar date.
+func ParseDueDate(value string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+
+	if instant, err := time.Parse(time.RFC3339, value); err == nil {
+		return instant.UTC(), false, nil
+	}
+
+	for _, layout := range localDueLayouts {
+		if local, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return local.UTC(), false, nil
+		}
+	}
+
+	return time.Time{}, false, ErrInvalidDueDate
+}
+
+// LoadLocation resolves an IANA time zone name, falling back to UTC
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DueCutoff returns the moment now as seen by a user in loc, used to decide
+// which todos are overdue
+func DueCutoff(now time.Time, loc *time.Location) repository.DueCutoff {
+	local := now.In(loc)
+	return repository.DueCutoff{
+		Now:   now.UTC(),
+		Today: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// applyDueFilter narrows filter to the todos of a named due range, relative
+// to the day now falls on in loc
+func applyDueFilter(filter *repository.TodoFilter, due string, now time.Time, loc *time.Location) error {
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	switch due {
+	case "overdue":
+		filter.Overdue = true
+		filter.Cutoff = DueCutoff(now, loc)
+	case "none":
+		filter.NoDueDate = true
+	case "today":
+		filter.Due = dueWindow(today, 0, 1)
+	case "tomorrow":
+		filter.Due = dueWindow(today, 1, 2)
+	case "this_week":
+		filter.Due = dueWindow(startOfWeek(today), 0, 7)
+	case "next_7_days":
+		filter.Due = dueWindow(today, 0, 7)
+	case "next_30_days":
+		filter.Due = dueWindow(today, 0, 30)
+	default:
+		return ErrInvalidDueFilter
+	}
+	return nil
+}
+
+// dueWindow returns the days from day+from up to day+to, day being a local midnight
+func dueWindow(day time.Time, from, to int) *repository.DueWindow {
+	start, end := day.AddDate(0, 0, from), day.AddDate(0, 0, to)
+	return &repository.DueWindow{
+		From:    start.UTC(),
+		To:      end.UTC(),
+		FromDay: time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		ToDay:   time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// IsOverdue reports whether an open todo is past its due date in the user's time zone
+func IsOverdue(todo *model.Todo, now time.Time, loc *time.Location) bool {
+	if todo.DueDate == nil || todo.CompletedAt != nil {
+		return false
+	}
+	cutoff := DueCutoff(now, loc)
+	if todo.DueAllDay {
+		return todo.DueDate.Before(cutoff.Today)
+	}
+	return todo.DueDate.Before(cutoff.Now)
+}
+
+// IsDueToday reports whether a todo is due on the user's current calendar date
+func IsDueToday(todo *model.Todo, now time.Time, loc *time.Location) bool {
+	if todo.DueDate == nil {
+		return false
+	}
+	today := DueCutoff(now, loc).Today
+	return DueDateIn(todo, loc).Format("2006-01-02") == today.Format("2006-01-02")
+}
+
+// DueDateIn renders a due date in loc. All-day dates keep their calendar date.
+func DueDateIn(todo *model.Todo, loc *time.Location) time.Time {
+	due := *todo.DueDate
+	if todo.DueAllDay {
+		return time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, loc)
+	}
+	return due.In(loc)
+}
@@ -0,0 +1,538 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// Supported import formats
+const (
+	ImportFormatCSV     = "csv"
+	ImportFormatJSON    = "json" // our own export format, Todoist and Trello are detected automatically
+	ImportFormatTodoist = "todoist"
+	ImportFormatTrello  = "trello"
+)
+
+// ImportSyncLimit is the number of rows above which imports run as a background job
+const ImportSyncLimit = 200
+
+const (
+	// importMaxErrors caps the number of row errors kept per import
+	importMaxErrors = 500
+	// importProgressEvery is the number of rows processed between job progress updates
+	importProgressEvery = 50
+	// importStaleAfter is how long a job can go without saving progress
+	// before it is considered lost with the process that ran it
+	importStaleAfter = 10 * time.Minute
+	// importSweepInterval is how often jobs are checked for staleness
+	importSweepInterval = time.Minute
+)
+
+var (
+	// ErrUnsupportedImportFormat is returned when the import format is unknown
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
+	// ErrInvalidImportFile is returned when the uploaded file cannot be parsed
+	ErrInvalidImportFile = errors.New("invalid import file")
+	// ErrInvalidImportMapping is returned when the CSV column mapping does not match the file
+	ErrInvalidImportMapping = errors.New("invalid column mapping")
+	// ErrImportJobNotFound is returned when import job is not found
+	ErrImportJobNotFound = errors.New("import job not found")
+)
+
+// importFields are the todo fields a CSV column can be mapped to
+var importFields = []string{"title", "description", "status", "priority", "due_date"}
+
+// ImportRow is one todo read from an import file
+type ImportRow struct {
+	Row     int
+	Request dto.CreateTodoRequest
+}
+
+// ImportService handles importing todos from files
+type ImportService struct {
+	todoService *TodoService
+	jobRepo     *repository.ImportJobRepository
+}
+
+// NewImportService creates a new import service instance
+func NewImportService(todoService *TodoService, jobRepo *repository.ImportJobRepository) *ImportService {
+	return &ImportService{
+		todoService: todoService,
+		jobRepo:     jobRepo,
+	}
+}
+
+// ParseImport reads todos from r. mapping maps todo fields to CSV column
+// headers and is only used for CSV; columns default to the field names.
+func (s *ImportService) ParseImport(format string, r io.Reader, mapping map[string]string) ([]ImportRow, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseCSVImport(r, mapping)
+	case ImportFormatJSON, ImportFormatTodoist, ImportFormatTrello:
+		return parseJSONImport(format, r)
+	default:
+		return nil, ErrUnsupportedImportFormat
+	}
+}
+
+// ImportTodos validates every row with the CreateTodo rules and, unless dryRun
+// is set, creates the valid ones
+func (s *ImportService) ImportTodos(userID uint, rows []ImportRow, dryRun bool) *dto.ImportResultResponse {
+	result := &dto.ImportResultResponse{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Errors: []dto.ImportRowError{},
+	}
+
+	settings, err := s.todoService.settings(userID)
+	if err != nil {
+		result.Failed = len(rows)
+		result.Errors = append(result.Errors, dto.ImportRowError{Error: err.Error()})
+		return result
+	}
+
+	for _, row := range rows {
+		var err error
+		if dryRun {
+			err = validateCreateTodo(row.Request, settings)
+		} else {
+			_, err = s.todoService.createTodoInTransaction(userID, row.Request, settings)
+		}
+
+		if err != nil {
+			result.Failed++
+			if len(result.Errors) < importMaxErrors {
+				result.Errors = append(result.Errors, dto.ImportRowError{Row: row.Row, Error: err.Error()})
+			}
+			continue
+		}
+
+		result.Valid++
+		if dryRun {
+			result.Preview = append(result.Preview, row.Request)
+		} else {
+			result.Created++
+		}
+	}
+
+	return result
+}
+
+// StartImportJob stores a new import job and imports the rows in the background
+func (s *ImportService) StartImportJob(userID uint, format string, rows []ImportRow) (*model.ImportJob, error) {
+	job := &model.ImportJob{
+		UserID: userID,
+		Format: format,
+		Status: model.ImportJobQueued,
+		Total:  len(rows),
+		Errors: "[]",
+	}
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	jobCopy := *job
+	go s.runImportJob(&jobCopy, rows)
+
+	return job, nil
+}
+
+// GetImportJob retrieves an import job of a user
+func (s *ImportService) GetImportJob(jobID, userID uint) (*model.ImportJob, error) {
+	job, err := s.jobRepo.FindByIDAndUserID(jobID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import job: %w", err)
+	}
+	if job == nil {
+		return nil, ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+// FailInterruptedJobs marks the jobs left queued or running by a process
+// that stopped as failed. Jobs of other replicas keep saving progress and
+// are left alone.
+func (s *ImportService) FailInterruptedJobs() (int64, error) {
+	now := time.Now()
+	count, err := s.jobRepo.FailStale(now.Add(-importStaleAfter), now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark interrupted import jobs: %w", err)
+	}
+	return count, nil
+}
+
+// Run fails interrupted jobs until ctx is done: at once, for the jobs of the
+// previous process, and then periodically, for jobs of replicas that
+// stopped and jobs that were not yet stale when this process started
+func (s *ImportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(importSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.FailInterruptedJobs(); err != nil {
+			log.Printf("Failed to check interrupted import jobs: %v", err)
+		} else if count > 0 {
+			log.Printf("Marked %d interrupted import jobs as failed", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runImportJob creates the rows of a job, saving progress periodically
+func (s *ImportService) runImportJob(job *model.ImportJob, rows []ImportRow) {
+	rowErrors := []dto.ImportRowError{}
+
+	saveJob := func() {
+		encoded, _ := json.Marshal(rowErrors)
+		job.Errors = string(encoded)
+		if err := s.jobRepo.Update(job); err != nil {
+			log.Printf("Failed to update import job %d: %v", job.ID, err)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Import job %d panicked: %v", job.ID, r)
+			job.Status = model.ImportJobFailed
+			now := time.Now()
+			job.FinishedAt = &now
+			saveJob()
+		}
+	}()
+
+	job.Status = model.ImportJobRunning
+	saveJob()
+
+	settings, err := s.todoService.settings(job.UserID)
+	if err != nil {
+		log.Printf("Import job %d failed: %v", job.ID, err)
+		rowErrors = append(rowErrors, dto.ImportRowError{Error: err.Error()})
+		job.Status = model.ImportJobFailed
+		now := time.Now()
+		job.FinishedAt = &now
+		saveJob()
+		return
+	}
+
+	for i, row := range rows {
+		if _, err := s.todoService.createTodoInTransaction(job.UserID, row.Request, settings); err != nil {
+			job.Failed++
+			if len(rowErrors) < importMaxErrors {
+				rowErrors = append(rowErrors, dto.ImportRowError{Row: row.Row, Error: err.Error()})
+			}
+		} else {
+			job.Created++
+		}
+		job.Processed++
+
+		if (i+1)%importProgressEvery == 0 {
+			saveJob()
+		}
+	}
+
+	job.Status = model.ImportJobCompleted
+	now := time.Now()
+	job.FinishedAt = &now
+	saveJob()
+}
+
+// parseCSVImport reads a CSV file with a header row
+func parseCSVImport(r io.Reader, mapping map[string]string) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read CSV header: %v", ErrInvalidImportFile, err)
+	}
+
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		headerIndex[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for field := range mapping {
+		if !isImportField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidImportMapping, field)
+		}
+	}
+
+	// Resolve the column index of every field
+	columns := make(map[string]int, len(importFields))
+	for _, field := range importFields {
+		column, mapped := mapping[field]
+		if !mapped {
+			column = field
+		}
+		index, found := headerIndex[strings.ToLower(strings.TrimSpace(column))]
+		if !found {
+			if mapped {
+				return nil, fmt.Errorf("%w: column %q not found", ErrInvalidImportMapping, column)
+			}
+			continue
+		}
+		columns[field] = index
+	}
+	if _, found := columns["title"]; !found {
+		return nil, fmt.Errorf("%w: no column for title", ErrInvalidImportMapping)
+	}
+
+	value := func(record []string, field string) string {
+		index, found := columns[field]
+		if !found || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+
+		rows = append(rows, ImportRow{
+			Row: len(rows) + 1,
+			Request: normalizeImportRequest(dto.CreateTodoRequest{
+				Title:       value(record, "title"),
+				Description: value(record, "description"),
+				Status:      value(record, "status"),
+				Priority:    value(record, "priority"),
+				DueDate:     value(record, "due_date"),
+			}),
+		})
+	}
+
+	return rows, nil
+}
+
+// exportedTodo is one todo of our own JSON export
+type exportedTodo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Priority    string `json:"priority"`
+	DueDate     string `json:"due_date"`
+}
+
+// todoistItem is one task of a Todoist JSON export
+type todoistItem struct {
+	Content     string `json:"content"`
+	Description string `json:"description"`
+	Priority    int    `json:"priority"` // 4 is the most urgent
+	Checked     bool   `json:"checked"`
+	Due         *struct {
+		Date string `json:"date"`
+	} `json:"due"`
+}
+
+// trelloCard is one card of a Trello board JSON export
+type trelloCard struct {
+	Name        string `json:"name"`
+	Desc        string `json:"desc"`
+	Due         string `json:"due"`
+	DueComplete bool   `json:"dueComplete"`
+	Closed      bool   `json:"closed"`
+}
+
+// parseJSONImport reads our own export, a Todoist export or a Trello board export
+func parseJSONImport(format string, r io.Reader) ([]ImportRow, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	if format == ImportFormatJSON {
+		format = detectJSONImportFormat(raw)
+	}
+
+	var requests []dto.CreateTodoRequest
+	switch format {
+	case ImportFormatTodoist:
+		var items []todoistItem
+		if err := unmarshalImportList(raw, "items", &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			req := dto.CreateTodoRequest{
+				Title:       item.Content,
+				Description: item.Description,
+				Priority:    todoistPriority(item.Priority),
+			}
+			if item.Checked {
+				req.Status = "completed"
+			}
+			if item.Due != nil {
+				req.DueDate = item.Due.Date
+			}
+			requests = append(requests, req)
+		}
+
+	case ImportFormatTrello:
+		var cards []trelloCard
+		if err := unmarshalImportList(raw, "cards", &cards); err != nil {
+			return nil, err
+		}
+		for _, card := range cards {
+			// Archived cards are not imported
+			if card.Closed {
+				continue
+			}
+			req := dto.CreateTodoRequest{
+				Title:       card.Name,
+				Description: card.Desc,
+				DueDate:     card.Due,
+			}
+			if card.DueComplete {
+				req.Status = "completed"
+			}
+			requests = append(requests, req)
+		}
+
+	default:
+		var todos []exportedTodo
+		if err := unmarshalImportList(raw, "todos", &todos); err != nil {
+			return nil, err
+		}
+		for _, todo := range todos {
+			requests = append(requests, dto.CreateTodoRequest{
+				Title:       todo.Title,
+				Description: todo.Description,
+				Status:      todo.Status,
+				Priority:    todo.Priority,
+				DueDate:     todo.DueDate,
+			})
+		}
+	}
+
+	rows := make([]ImportRow, len(requests))
+	for i, req := range requests {
+		rows[i] = ImportRow{Row: i + 1, Request: normalizeImportRequest(req)}
+	}
+	return rows, nil
+}
+
+// detectJSONImportFormat guesses which tool produced a JSON export
+func detectJSONImportFormat(raw json.RawMessage) string {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(raw, &object) == nil {
+		if _, ok := object["cards"]; ok {
+			return ImportFormatTrello
+		}
+		if _, ok := object["items"]; ok {
+			return ImportFormatTodoist
+		}
+		return ImportFormatJSON
+	}
+
+	var list []map[string]json.RawMessage
+	if json.Unmarshal(raw, &list) == nil && len(list) > 0 {
+		if _, ok := list[0]["content"]; ok {
+			return ImportFormatTodoist
+		}
+		if _, ok := list[0]["name"]; ok {
+			return ImportFormatTrello
+		}
+	}
+	return ImportFormatJSON
+}
+
+// unmarshalImportList decodes either a bare array or an object holding the array under key
+func unmarshalImportList(raw json.RawMessage, key string, target interface{}) error {
+	if err := json.Unmarshal(raw, target); err == nil {
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return fmt.Errorf("%w: expected an array or an object", ErrInvalidImportFile)
+	}
+	list, ok := object[key]
+	if !ok {
+		return fmt.Errorf("%w: missing %q list", ErrInvalidImportFile, key)
+	}
+	if err := json.Unmarshal(list, target); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	return nil
+}
+
+// normalizeImportRequest fills defaults and converts common spellings to our values
+func normalizeImportRequest(req dto.CreateTodoRequest) dto.CreateTodoRequest {
+	req.Title = strings.TrimSpace(req.Title)
+
+	status := strings.ToLower(strings.TrimSpace(req.Status))
+	switch status {
+	case "todo", "to do", "open", "new":
+		status = "pending"
+	case "in progress", "in-progress", "doing", "started":
+		status = "in_progress"
+	case "done", "closed", "complete", "finished":
+		status = "completed"
+	}
+	req.Status = status
+
+	priority := strings.ToLower(strings.TrimSpace(req.Priority))
+	if priority == "" {
+		priority = "medium"
+	}
+	req.Priority = priority
+
+	req.DueDate = normalizeImportDate(req.DueDate)
+	return req
+}
+
+// normalizeImportDate converts RFC 3339 timestamps at local midnight, as
+// written for all-day todos by other tools, to YYYY-MM-DD. Other values are
+// left for ParseDueDate, which reports unknown formats.
+func normalizeImportDate(value string) string {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		if parsed.Hour() == 0 && parsed.Minute() == 0 && parsed.Second() == 0 && parsed.Nanosecond() == 0 {
+			return parsed.Format("2006-01-02")
+		}
+	}
+	return value
+}
+
+// todoistPriority maps Todoist priorities (1 normal .. 4 urgent) to ours
+func todoistPriority(priority int) string {
+	switch {
+	case priority >= 4:
+		return "high"
+	case priority >= 2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func isImportField(field string) bool {
+	for _, name := range importFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
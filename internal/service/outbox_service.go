@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/event"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// Outbox topics
+const (
+	// TopicTodoEvent messages hold a TodoEventMessage of a todo change
+	TopicTodoEvent = "todo.event"
+	// TopicPasswordResetEmail messages hold a PasswordResetEmail
+	TopicPasswordResetEmail = "email.password_reset"
+)
+
+const (
+	// outboxMaxAttempts is the number of attempts before a message is dead
+	outboxMaxAttempts = 20
+	// outboxRetryBase is the wait after the first failed attempt, doubling
+	// after each further one up to outboxRetryMax
+	outboxRetryBase = 5 * time.Second
+	outboxRetryMax  = 30 * time.Minute
+	// outboxLease is how long a claimed message is left to its relay before
+	// another relay may publish it
+	outboxLease = time.Minute
+	// outboxBatchSize is the number of messages claimed at once
+	outboxBatchSize = 50
+	// outboxPollInterval is how often the relay looks for messages written
+	// by other replicas and retries due
+	outboxPollInterval = 2 * time.Second
+)
+
+// OutboxHandler publishes the messages of a topic. A message is handled at
+// least once: when the relay stops before recording the outcome it is
+// handled again, so handlers use its dedup key, or an ID in its payload,
+// to skip side effects that already happened.
+type OutboxHandler func(message *model.OutboxMessage) error
+
+// OutboxRelay publishes the messages of the outbox to their handlers. Every
+// replica runs one; each message is claimed by one relay at a time.
+type OutboxRelay struct {
+	outboxRepo *repository.OutboxRepository
+	handlers   map[string]OutboxHandler
+	wake       chan struct{}
+}
+
+// NewOutboxRelay creates a new outbox relay instance. Handlers are
+// registered with Handle before it runs.
+func NewOutboxRelay(outboxRepo *repository.OutboxRepository) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		handlers:   make(map[string]OutboxHandler),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Handle registers the handler of a topic
+func (r *OutboxRelay) Handle(topic string, handler OutboxHandler) {
+	r.handlers[topic] = handler
+}
+
+// Notify wakes the relay up for messages committed in this process
+func (r *OutboxRelay) Notify() {
+	if r == nil {
+		return
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes due messages until ctx is done
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.RelayDue(time.Now().UTC())
+			if err != nil {
+				log.Printf("Failed to relay outbox messages: %v", err)
+			}
+			if err != nil || n < outboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// RelayDue publishes a batch of the messages due at now, in the order they
+// were written, and returns their number
+func (r *OutboxRelay) RelayDue(now time.Time) (int, error) {
+	messages, err := r.outboxRepo.ClaimDue(now, now.Add(outboxLease), outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range messages {
+		message := &messages[i]
+		r.relay(message, now)
+		if err := r.outboxRepo.Update(message); err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+// Purge deletes the messages published before a time. Their dedup keys no
+// longer keep the same side effect from being written again.
+func (r *OutboxRelay) Purge(before time.Time) (int64, error) {
+	return r.outboxRepo.DeletePublishedBefore(before)
+}
+
+// relay hands a message to the handler of its topic and records the
+// outcome. Failed messages are retried with exponential backoff until they
+// run out of attempts and are dead.
+func (r *OutboxRelay) relay(message *model.OutboxMessage, now time.Time) {
+	message.Attempts++
+
+	var err error
+	if handler, ok := r.handlers[message.Topic]; ok {
+		err = handler(message)
+	} else {
+		err = fmt.Errorf("no handler for topic %q", message.Topic)
+	}
+
+	switch {
+	case err == nil:
+		message.Status = model.OutboxPublished
+		message.PublishedAt = &now
+		message.NextAttemptAt = nil
+		message.Error = ""
+	case message.Attempts >= outboxMaxAttempts:
+		log.Printf("Outbox message %d (%s) is dead after %d attempts: %v", message.ID, message.Topic, message.Attempts, err)
+		message.Status = model.OutboxDead
+		message.NextAttemptAt = nil
+		message.Error = err.Error()
+	default:
+		next := now.Add(retryBackoff(message.Attempts, outboxRetryBase, outboxRetryMax))
+		message.NextAttemptAt = &next
+		message.Error = err.Error()
+	}
+}
+
+// writeOutbox writes a message to the outbox through outboxRepo, which is
+// bound to the transaction of the change the message belongs to. A message
+// with the same dedup key is only written once.
+func writeOutbox(outboxRepo *repository.OutboxRepository, topic, dedupKey string, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	_, err = outboxRepo.Create(&model.OutboxMessage{
+		Topic:         topic,
+		DedupKey:      dedupKey,
+		Payload:       string(encoded),
+		Status:        model.OutboxPending,
+		NextAttemptAt: &now,
+	})
+	return err
+}
+
+// TodoEventMessage is the payload of a todo event in the outbox: the event
+// and the todo as the change left it, nil for deletions. Messages written
+// before the todo was kept only hold the event.
+type TodoEventMessage struct {
+	event.Event
+	Todo *dto.TodoResponse `json:"todo,omitempty"`
+}
+
+// RelayTodoEvents returns the handler of todo events. An event is queued
+// for webhooks first, which skip events they already queued, and then
+// published to the bus, which keeps its ID so streams can drop it when it
+// arrives twice.
+func RelayTodoEvents(webhooks *WebhookService, bus event.Publisher) OutboxHandler {
+	return func(message *model.OutboxMessage) error {
+		var m TodoEventMessage
+		if err := json.Unmarshal([]byte(message.Payload), &m); err != nil {
+			return err
+		}
+		if webhooks != nil {
+			if err := webhooks.Enqueue(m.Event, m.Todo); err != nil {
+				return err
+			}
+		}
+		if bus != nil {
+			bus.Publish(m.Event)
+		}
+		return nil
+	}
+}
+
+// PasswordResetEmail is the payload of a password reset email
+type PasswordResetEmail struct {
+	Email string `json:"email"`
+	Link  string `json:"link"`
+}
+
+// RelayPasswordResetEmails returns the handler of password reset emails,
+// sent with send
+func RelayPasswordResetEmails(send func(toEmail, resetLink string) error) OutboxHandler {
+	return func(message *model.OutboxMessage) error {
+		var email PasswordResetEmail
+		if err := json.Unmarshal([]byte(message.Payload), &email); err != nil {
+			return err
+		}
+		if email.Email == "" || email.Link == "" {
+			return errors.New("password reset email without recipient or link")
+		}
+		return send(email.Email, email.Link)
+	}
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickAdd is a todo parsed from a single line of text
+type QuickAdd struct {
+	Title      string
+	DueDate    *time.Time // UTC, midnight UTC of the date when DueAllDay
+	DueAllDay  bool
+	Priority   string
+	Tags       []string
+	Recurrence string
+}
+
+// Words understood by the quick-add parser, in English and Indonesian
+var (
+	quickPriorities = map[string]string{
+		"high": "high", "h": "high", "1": "high", "urgent": "high", "tinggi": "high", "penting": "high",
+		"medium": "medium", "m": "medium", "2": "medium", "normal": "medium", "sedang": "medium",
+		"low": "low", "l": "low", "3": "low", "rendah": "low",
+	}
+
+	quickWeekdays = map[string]time.Weekday{
+		"monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday, "thursday": time.Thursday,
+		"friday": time.Friday, "saturday": time.Saturday, "sunday": time.Sunday,
+		"senin": time.Monday, "selasa": time.Tuesday, "rabu": time.Wednesday, "kamis": time.Thursday,
+		"jumat": time.Friday, "jum'at": time.Friday, "sabtu": time.Saturday, "ahad": time.Sunday,
+	}
+
+	quickMonths = map[string]time.Month{
+		"january": time.January, "jan": time.January, "januari": time.January,
+		"february": time.February, "feb": time.February, "februari": time.February,
+		"march": time.March, "mar": time.March, "maret": time.March,
+		"april": time.April, "apr": time.April,
+		"may": time.May, "mei": time.May,
+		"june": time.June, "jun": time.June, "juni": time.June,
+		"july": time.July, "jul": time.July, "juli": time.July,
+		"august": time.August, "aug": time.August, "agustus": time.August, "agu": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October, "oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November, "nopember": time.November,
+		"december": time.December, "dec": time.December, "desember": time.December, "des": time.December,
+	}
+
+	// quickUnits maps duration words to recurrence frequencies
+	quickUnits = map[string]string{
+		"day": FreqDaily, "days": FreqDaily, "hari": FreqDaily,
+		"week": FreqWeekly, "weeks": FreqWeekly, "minggu": FreqWeekly, "pekan": FreqWeekly,
+		"month": FreqMonthly, "months": FreqMonthly, "bulan": FreqMonthly,
+		"year": FreqYearly, "years": FreqYearly, "tahun": FreqYearly,
+	}
+
+	quickFrequencies = map[string]string{
+		"daily": FreqDaily, "harian": FreqDaily,
+		"weekly": FreqWeekly, "mingguan": FreqWeekly,
+		"monthly": FreqMonthly, "bulanan": FreqMonthly,
+		"yearly": FreqYearly, "annually": FreqYearly, "tahunan": FreqYearly,
+	}
+
+	quickDateLeads = map[string]bool{"on": true, "by": true, "due": true, "pada": true, "tanggal": true, "tgl": true}
+	quickTimeLeads = map[string]bool{"at": true, "@": true, "jam": true, "pukul": true, "pkl": true}
+)
+
+// quickParser holds the state while scanning a quick-add line
+type quickParser struct {
+	words []string // lower-cased words without trailing punctuation
+	now   time.Time
+	today time.Time // midnight of the current date in the user's time zone
+
+	date       *time.Time
+	hour       int
+	minute     int
+	hasTime    bool
+	recurrence *Recurrence
+	result     QuickAdd
+}
+
+// ParseQuickAdd parses a line like "Send invoice tomorrow 5pm !high #finance every month"
+// into a todo. Dates and times are read in loc relative to now; words that are
+// not recognised become the title.
+func ParseQuickAdd(text string, now time.Time, loc *time.Location) (*QuickAdd, error) {
+	original := strings.Fields(text)
+	local := now.In(loc)
+	p := &quickParser{
+		words: make([]string, len(original)),
+		now:   local,
+		today: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc),
+	}
+	for i, word := range original {
+		p.words[i] = strings.TrimRight(strings.ToLower(word), ",;.?")
+	}
+
+	var title []string
+	for i := 0; i < len(p.words); {
+		n := p.matchTag(i)
+		if n == 0 {
+			n = p.matchPriority(i)
+		}
+		if n == 0 && p.recurrence == nil {
+			n = p.matchRecurrence(i)
+		}
+		if n == 0 && p.date == nil {
+			n = p.matchLead(i, quickDateLeads, p.matchDate)
+		}
+		if n == 0 && !p.hasTime {
+			n = p.matchLead(i, quickTimeLeads, p.matchTime)
+		}
+		if n == 0 {
+			title = append(title, original[i])
+			n = 1
+		}
+		i += n
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	if !isValidTitle(p.result.Title) {
+		return nil, ErrInvalidTitle
+	}
+	if p.result.Priority == "" {
+		p.result.Priority = "medium"
+	}
+	if p.recurrence != nil {
+		p.result.Recurrence = p.recurrence.String()
+	}
+	p.resolveDueDate(loc)
+
+	return &p.result, nil
+}
+
+// resolveDueDate combines the parsed date, time and recurrence into the due date
+func (p *quickParser) resolveDueDate(loc *time.Location) {
+	date := p.date
+	if date == nil && p.recurrence != nil {
+		first := p.today
+		if len(p.recurrence.ByDay) > 0 {
+			for !p.recurrence.hasDay(first.Weekday()) {
+				first = first.AddDate(0, 0, 1)
+			}
+		}
+		date = &first
+	}
+
+	if p.hasTime {
+		day := p.today
+		if date != nil {
+			day = *date
+		}
+		due := time.Date(day.Year(), day.Month(), day.Day(), p.hour, p.minute, 0, 0, loc)
+		if date == nil && !due.After(p.now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		due = due.UTC()
+		p.result.DueDate = &due
+		return
+	}
+
+	if date != nil {
+		due := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		p.result.DueDate = &due
+		p.result.DueAllDay = true
+	}
+}
+
+// word returns the word at i, or "" past the end
+func (p *quickParser) word(i int) string {
+	if i < len(p.words) {
+		return p.words[i]
+	}
+	return ""
+}
+
+// matchLead matches an optional leading word ("on", "at", "jam", ...) followed by match
+func (p *quickParser) matchLead(i int, leads map[string]bool, match func(i int) int) int {
+	if n := match(i); n > 0 {
+		return n
+	}
+	if leads[p.word(i)] {
+		if n := match(i + 1); n > 0 {
+			return n + 1
+		}
+	}
+	return 0
+}
+
+func (p *quickParser) matchTag(i int) int {
+	word := p.word(i)
+	if len(word) < 2 || word[0] != '#' {
+		return 0
+	}
+	p.result.Tags = append(p.result.Tags, word[1:])
+	return 1
+}
+
+func (p *quickParser) matchPriority(i int) int {
+	word := p.word(i)
+	if len(word) < 2 || word[0] != '!' {
+		return 0
+	}
+	priority, ok := quickPriorities[word[1:]]
+	if !ok {
+		return 0
+	}
+	p.result.Priority = priority
+	return 1
+}
+
+// matchRecurrence matches "daily", "every 2 weeks", "every monday", "every weekday",
+// "setiap bulan", "tiap hari senin", ...
+func (p *quickParser) matchRecurrence(i int) int {
+	if freq, ok := quickFrequencies[p.word(i)]; ok {
+		p.recurrence = &Recurrence{Freq: freq, Interval: 1}
+		return 1
+	}
+
+	switch p.word(i) {
+	case "every", "each", "setiap", "tiap":
+	default:
+		return 0
+	}
+
+	n := 1
+	interval := 1
+	if p.word(i+n) == "other" {
+		interval = 2
+		n++
+	} else if number, err := strconv.Atoi(p.word(i + n)); err == nil && number > 0 {
+		interval = number
+		n++
+	}
+
+	word := p.word(i + n)
+	if interval == 1 && word == "hari" {
+		// "setiap hari senin" is weekly, "setiap hari kerja" is every weekday
+		if day, ok := indonesianWeekday(p.word(i + n + 1)); ok {
+			p.recurrence = &Recurrence{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{day}}
+			return n + 2
+		}
+		if p.word(i+n+1) == "kerja" {
+			p.recurrence = weekdayRecurrence()
+			return n + 2
+		}
+	}
+
+	if freq, ok := quickUnits[word]; ok {
+		p.recurrence = &Recurrence{Freq: freq, Interval: interval}
+		return n + 1
+	}
+	if interval > 1 {
+		return 0
+	}
+	if day, ok := quickWeekdays[word]; ok {
+		p.recurrence = &Recurrence{Freq: FreqWeekly, Interval: 1, ByDay: []time.Weekday{day}}
+		return n + 1
+	}
+	if word == "weekday" || word == "weekdays" {
+		p.recurrence = weekdayRecurrence()
+		return n + 1
+	}
+	return 0
+}
+
+func weekdayRecurrence() *Recurrence {
+	return &Recurrence{
+		Freq:     FreqWeekly,
+		Interval: 1,
+		ByDay:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+}
+
+// matchDate matches relative and absolute dates
+func (p *quickParser) matchDate(i int) int {
+	word, next := p.word(i), p.word(i+1)
+
+	switch {
+	case word == "today":
+		return p.setDate(p.today, 1)
+	case word == "hari" && next == "ini":
+		return p.setDate(p.today, 2)
+	case word == "tomorrow" || word == "tmr" || word == "besok":
+		return p.setDate(p.today.AddDate(0, 0, 1), 1)
+	case word == "lusa":
+		return p.setDate(p.today.AddDate(0, 0, 2), 1)
+	case word == "day" && next == "after" && p.word(i+2) == "tomorrow":
+		return p.setDate(p.today.AddDate(0, 0, 2), 3)
+	case (word == "next" && next == "week") || ((word == "minggu" || word == "pekan") && next == "depan"):
+		return p.setDate(p.nextWeekday(time.Monday, false), 2)
+	case (word == "next" && next == "month") || (word == "bulan" && next == "depan"):
+		return p.setDate(time.Date(p.today.Year(), p.today.Month()+1, 1, 0, 0, 0, 0, p.today.Location()), 2)
+	case (word == "next" && next == "year") || (word == "tahun" && next == "depan"):
+		return p.setDate(time.Date(p.today.Year()+1, time.January, 1, 0, 0, 0, 0, p.today.Location()), 2)
+	case word == "next":
+		if day, ok := quickWeekdays[next]; ok {
+			return p.setDate(p.nextWeekday(day, false), 2)
+		}
+	case word == "hari":
+		// "hari senin", "hari minggu"
+		if day, ok := indonesianWeekday(next); ok {
+			if p.word(i+2) == "depan" {
+				return p.setDate(p.nextWeekday(day, false), 3)
+			}
+			return p.setDate(p.nextWeekday(day, true), 2)
+		}
+	case word == "in" || word == "dalam":
+		// "in 3 days", "dalam 2 minggu"
+		if number, err := strconv.Atoi(next); err == nil {
+			if freq, ok := quickUnits[p.word(i+2)]; ok {
+				return p.setDate(p.addUnits(freq, number), 3)
+			}
+		}
+	}
+
+	if day, ok := quickWeekdays[word]; ok {
+		if next == "depan" {
+			return p.setDate(p.nextWeekday(day, false), 2)
+		}
+		return p.setDate(p.nextWeekday(day, true), 1)
+	}
+
+	if number, err := strconv.Atoi(word); err == nil {
+		// "3 hari lagi"
+		if freq, ok := quickUnits[next]; ok && p.word(i+2) == "lagi" {
+			return p.setDate(p.addUnits(freq, number), 3)
+		}
+		// "1 may", "17 agustus 2025"
+		if month, ok := quickMonths[next]; ok {
+			return p.matchDayMonth(number, month, i+2, 2)
+		}
+	}
+
+	// "may 1", "may 1, 2025"
+	if month, ok := quickMonths[word]; ok {
+		if day, err := strconv.Atoi(next); err == nil {
+			return p.matchDayMonth(day, month, i+2, 2)
+		}
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", word, p.today.Location()); err == nil {
+		return p.setDate(date, 1)
+	}
+
+	return 0
+}
+
+// matchDayMonth sets a day of month with an optional year at i. Without a year
+// the next occurrence of that date is used.
+func (p *quickParser) matchDayMonth(day int, month time.Month, i, n int) int {
+	year := p.today.Year()
+	explicitYear := false
+	if y, err := strconv.Atoi(p.word(i)); err == nil && y >= 1970 && y <= 9999 {
+		year = y
+		explicitYear = true
+		n++
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.today.Location())
+	if date.Day() != day {
+		return 0
+	}
+	if !explicitYear && date.Before(p.today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return p.setDate(date, n)
+}
+
+// matchTime matches "5pm", "5:30 pm", "17:00", "noon", and "jam 5 sore" style times
+func (p *quickParser) matchTime(i int) int {
+	word := p.word(i)
+	lead := i > 0 && quickTimeLeads[p.word(i-1)]
+
+	if word == "noon" || word == "midday" {
+		return p.setTime(12, 0, 1)
+	}
+
+	clock, suffix := word, ""
+	for _, s := range []string{"am", "pm"} {
+		if strings.HasSuffix(word, s) {
+			clock, suffix = strings.TrimSuffix(word, s), s
+		}
+	}
+	n := 1
+	if suffix == "" {
+		switch p.word(i + 1) {
+		case "am", "pm", "pagi", "siang", "sore", "malam":
+			suffix = p.word(i + 1)
+			n = 2
+		}
+	}
+
+	if lead || suffix != "" {
+		// "17.00" is only a time after "jam"/"pukul" or with am/pm
+		clock = strings.Replace(clock, ".", ":", 1)
+	}
+	hourText, minuteText, hasMinute := strings.Cut(clock, ":")
+	hour, err := strconv.Atoi(hourText)
+	if err != nil || hourText == "" {
+		return 0
+	}
+	minute := 0
+	if hasMinute {
+		if len(minuteText) != 2 {
+			return 0
+		}
+		if minute, err = strconv.Atoi(minuteText); err != nil || minute > 59 {
+			return 0
+		}
+	}
+	if !hasMinute && suffix == "" && !lead {
+		// a bare number is not a time
+		return 0
+	}
+
+	switch suffix {
+	case "am", "pagi":
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		hour %= 12
+	case "pm", "sore", "malam":
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		if hour != 12 {
+			hour += 12
+		} else if suffix == "malam" {
+			hour = 0
+		}
+	case "siang":
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		if hour < 6 {
+			hour += 12
+		}
+	}
+	if hour > 23 {
+		return 0
+	}
+
+	return p.setTime(hour, minute, n)
+}
+
+func (p *quickParser) setDate(date time.Time, n int) int {
+	p.date = &date
+	return n
+}
+
+func (p *quickParser) setTime(hour, minute, n int) int {
+	p.hour, p.minute, p.hasTime = hour, minute, true
+	return n
+}
+
+// nextWeekday returns the next date falling on day, today included when allowToday
+func (p *quickParser) nextWeekday(day time.Weekday, allowToday bool) time.Time {
+	offset := (int(day) - int(p.today.Weekday()) + 7) % 7
+	if offset == 0 && !allowToday {
+		offset = 7
+	}
+	return p.today.AddDate(0, 0, offset)
+}
+
+// addUnits returns today moved forward by number days, weeks, months or years
+func (p *quickParser) addUnits(freq string, number int) time.Time {
+	switch freq {
+	case FreqDaily:
+		return p.today.AddDate(0, 0, number)
+	case FreqWeekly:
+		return p.today.AddDate(0, 0, 7*number)
+	case FreqMonthly:
+		return addMonthsClamped(p.today, number)
+	default:
+		return addMonthsClamped(p.today, 12*number)
+	}
+}
+
+// indonesianWeekday resolves a weekday after "hari", where "minggu" means Sunday
+func indonesianWeekday(word string) (time.Weekday, bool) {
+	if word == "minggu" {
+		return time.Sunday, true
+	}
+	day, ok := quickWeekdays[word]
+	return day, ok
+}
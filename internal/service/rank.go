@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are the digits of position ranks in ascending order. Ranks are
+// compared as plain strings, so inserting between two todos never requires
+// renumbering the others.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ErrRankOrder is returned when no rank fits between two ranks
+var ErrRankOrder = errors.New("ranks are not in ascending order")
+
+// RankBetween returns a rank that sorts after before and ahead of after. An
+// empty before means the start of the list, an empty after its end. Ranks
+// never end with the zero digit, which keeps room between any two of them.
+func RankBetween(before, after string) (string, error) {
+	if !isValidRank(before) || !isValidRank(after) || (after != "" && before >= after) {
+		return "", ErrRankOrder
+	}
+	return rankMidpoint(before, after), nil
+}
+
+// rankMidpoint returns a rank between a and b, where a < b or b is empty
+// (meaning past the last rank)
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix and find a midpoint of the remainders
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + rankMidpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	digitA := strings.IndexByte(rankDigits, rankDigitAt(a, 0))
+	digitB := len(rankDigits)
+	if b != "" {
+		digitB = strings.IndexByte(rankDigits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(rankDigits[(digitA+digitB+1)/2])
+	}
+	// The first digits are adjacent
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(rankDigits[digitA]) + rankMidpoint(suffix(a, 1), "")
+}
+
+// SequentialRanks returns n ascending ranks of equal length, used to
+// renumber a list whose ranks ran out of order
+func SequentialRanks(n int) []string {
+	width := 1
+	for capacity := len(rankDigits) - 1; capacity < n; capacity *= len(rankDigits) {
+		width++
+	}
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		digits := make([]byte, width)
+		value := i + 1
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%len(rankDigits)]
+			value /= len(rankDigits)
+		}
+		// A trailing digit keeps ranks from ending with zero
+		ranks[i] = string(digits) + "i"
+	}
+	return ranks
+}
+
+// rankDigitAt returns the digit at i, zero past the end of the rank
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+func suffix(rank string, n int) string {
+	if n >= len(rank) {
+		return ""
+	}
+	return rank[n:]
+}
+
+func isValidRank(rank string) bool {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(rank, rankDigits[:1])
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies, a subset of RFC 5545 RRULE
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// ErrInvalidRecurrence is returned when a recurrence rule is not supported
+var ErrInvalidRecurrence = errors.New("invalid recurrence, use an RRULE like FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR")
+
+// rruleDays maps RRULE weekday codes to weekdays
+var rruleDays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is a parsed recurrence rule. Only FREQ, INTERVAL, BYDAY (weekly
+// rules), BYMONTHDAY (monthly and yearly rules) and BYMONTH (yearly rules)
+// are supported, with a single month day and month.
+type Recurrence struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int        // 0 when the day of the due date is used
+	ByMonth    time.Month // 0 when the month of the due date is used
+}
+
+// ParseRecurrence parses an RRULE value such as FREQ=MONTHLY or
+// FREQ=WEEKLY;BYDAY=MO,TH. A leading "RRULE:" is accepted.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	recurrence := &Recurrence{Interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, ErrInvalidRecurrence
+		}
+
+		switch key {
+		case "FREQ":
+			if value != FreqDaily && value != FreqWeekly && value != FreqMonthly && value != FreqYearly {
+				return nil, ErrInvalidRecurrence
+			}
+			recurrence.Freq = value
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > 365 {
+				return nil, ErrInvalidRecurrence
+			}
+			recurrence.Interval = interval
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > 31 {
+				return nil, ErrInvalidRecurrence
+			}
+			recurrence.ByMonthDay = day
+		case "BYMONTH":
+			month, err := strconv.Atoi(value)
+			if err != nil || month < 1 || month > 12 {
+				return nil, ErrInvalidRecurrence
+			}
+			recurrence.ByMonth = time.Month(month)
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := rruleDays[code]
+				if !ok {
+					return nil, ErrInvalidRecurrence
+				}
+				recurrence.ByDay = append(recurrence.ByDay, day)
+			}
+		default:
+			return nil, ErrInvalidRecurrence
+		}
+	}
+
+	if recurrence.Freq == "" ||
+		(len(recurrence.ByDay) > 0 && recurrence.Freq != FreqWeekly) ||
+		(recurrence.ByMonthDay > 0 && recurrence.Freq != FreqMonthly && recurrence.Freq != FreqYearly) ||
+		(recurrence.ByMonth > 0 && recurrence.Freq != FreqYearly) {
+		return nil, ErrInvalidRecurrence
+	}
+
+	return recurrence, nil
+}
+
+// String formats the recurrence as a canonical RRULE value
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, code := range []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"} {
+			if r.hasDay(rruleDays[code]) {
+				codes = append(codes, code)
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonth > 0 {
+		parts = append(parts, "BYMONTH="+strconv.Itoa(int(r.ByMonth)))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Anchored returns the rule with the day, and for yearly rules the month, of
+// due pinned as BYMONTHDAY and BYMONTH. Occurrences are clamped to the end
+// of shorter months, so the due date of the next todo no longer carries the
+// day: without the anchor, monthly todos from January 31 would move to the
+// 28th for good after February.
+func (r *Recurrence) Anchored(due time.Time, allDay bool, loc *time.Location) *Recurrence {
+	anchored := *r
+	if r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		return &anchored
+	}
+	local := localDue(due, allDay, loc)
+	if anchored.ByMonthDay == 0 {
+		anchored.ByMonthDay = local.Day()
+	}
+	if r.Freq == FreqYearly && anchored.ByMonth == 0 {
+		anchored.ByMonth = local.Month()
+	}
+	return &anchored
+}
+
+// Next returns the occurrence after due. All-day dates stay midnight UTC of
+// their calendar date; timed dates keep their wall-clock time in loc.
+// Monthly and yearly occurrences fall on BYMONTHDAY, or the last day of
+// shorter months; see Anchored.
+func (r *Recurrence) Next(due time.Time, allDay bool, loc *time.Location) time.Time {
+	local := localDue(due, allDay, loc)
+
+	var next time.Time
+	switch r.Freq {
+	case FreqDaily:
+		next = local.AddDate(0, 0, r.Interval)
+	case FreqWeekly:
+		next = r.nextWeekly(local)
+	case FreqMonthly:
+		next = r.nextMonthly(local)
+	default:
+		next = r.nextYearly(local)
+	}
+
+	if allDay {
+		return time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return next.UTC()
+}
+
+// nextWeekly finds the next BYDAY weekday, skipping weeks by INTERVAL
+func (r *Recurrence) nextWeekly(local time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return local.AddDate(0, 0, 7*r.Interval)
+	}
+
+	start := startOfWeek(local)
+	for offset := 1; ; offset++ {
+		candidate := local.AddDate(0, 0, offset)
+		weeks := int(startOfWeek(candidate).Sub(start).Hours()+12) / (7 * 24)
+		if weeks%r.Interval == 0 && r.hasDay(candidate.Weekday()) {
+			return candidate
+		}
+	}
+}
+
+// nextMonthly finds the next BYMONTHDAY, in the month of local when it is
+// still ahead and otherwise INTERVAL months later
+func (r *Recurrence) nextMonthly(local time.Time) time.Time {
+	day := r.ByMonthDay
+	if day == 0 {
+		day = local.Day()
+	}
+	next := dateClamped(local, local.Year(), local.Month(), day)
+	if !next.After(local) {
+		next = dateClamped(local, local.Year(), local.Month()+time.Month(r.Interval), day)
+	}
+	return next
+}
+
+// nextYearly finds the next BYMONTH and BYMONTHDAY, in the year of local when
+// it is still ahead and otherwise INTERVAL years later
+func (r *Recurrence) nextYearly(local time.Time) time.Time {
+	month, day := r.ByMonth, r.ByMonthDay
+	if month == 0 {
+		month = local.Month()
+	}
+	if day == 0 {
+		day = local.Day()
+	}
+	next := dateClamped(local, local.Year(), month, day)
+	if !next.After(local) {
+		next = dateClamped(local, local.Year()+r.Interval, month, day)
+	}
+	return next
+}
+
+func (r *Recurrence) hasDay(day time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// startOfWeek returns midnight of the Monday starting the week of t
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// addMonthsClamped adds months keeping the day, clamped to the last day of the month
+func addMonthsClamped(t time.Time, months int) time.Time {
+	return dateClamped(t, t.Year(), t.Month()+time.Month(months), t.Day())
+}
+
+// localDue returns due as the calendar date and wall-clock time the rule
+// applies to
+func localDue(due time.Time, allDay bool, loc *time.Location) time.Time {
+	if allDay {
+		return due.UTC()
+	}
+	return due.In(loc)
+}
+
+// dateClamped returns day of month in year at the clock time of t, clamped
+// to the last day of the month. Months past December roll into later years.
+func dateClamped(t time.Time, year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
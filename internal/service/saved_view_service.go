@@ -0,0 +1,231 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// maxSavedViews limits the number of saved views of a workspace
+const maxSavedViews = 50
+
+var (
+	// ErrSavedViewNotFound is returned when a saved view is not found
+	ErrSavedViewNotFound = errors.New("saved view not found")
+	// ErrSavedViewExists is returned when the view name is already used
+	ErrSavedViewExists = errors.New("a saved view with this name already exists")
+	// ErrInvalidSavedView is returned when a saved view is invalid
+	ErrInvalidSavedView = errors.New("invalid saved view")
+)
+
+// View is a saved view of a user or one of the built-in views, which have a
+// key instead of an ID
+type View struct {
+	ID        uint
+	Key       string
+	Name      string
+	Filter    dto.TodoListQuery
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BuiltIn reports whether the view is one of the built-in views
+func (v *View) BuiltIn() bool {
+	return v.Key != ""
+}
+
+// builtinViews are offered to every user before their saved views
+var builtinViews = []View{
+	{Key: "today", Name: "Today", Filter: dto.TodoListQuery{Due: "today", Open: true, Sort: "due_date"}},
+	{Key: "upcoming", Name: "Upcoming", Filter: dto.TodoListQuery{Due: "next_7_days", Open: true, Sort: "due_date"}},
+	{Key: "overdue", Name: "Overdue", Filter: dto.TodoListQuery{Due: "overdue", Sort: "due_date"}},
+}
+
+// SavedViewService handles saved todo list filters
+type SavedViewService struct {
+	viewRepo    *repository.SavedViewRepository
+	todoService *TodoService
+}
+
+// NewSavedViewService creates a new saved view service instance
+func NewSavedViewService(viewRepo *repository.SavedViewRepository, todoService *TodoService) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:    viewRepo,
+		todoService: todoService,
+	}
+}
+
+// ListViews returns the built-in views followed by the saved views of a user
+func (s *SavedViewService) ListViews(userID uint) ([]View, error) {
+	saved, err := s.viewRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := append([]View{}, builtinViews...)
+	for i := range saved {
+		view, err := toView(&saved[i])
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+	return views, nil
+}
+
+// GetView returns a view of a user by ID, or a built-in view by key
+func (s *SavedViewService) GetView(userID uint, ref string) (*View, error) {
+	for i := range builtinViews {
+		if builtinViews[i].Key == ref {
+			view := builtinViews[i]
+			return &view, nil
+		}
+	}
+
+	viewID, err := strconv.ParseUint(ref, 10, 32)
+	if err != nil {
+		return nil, ErrSavedViewNotFound
+	}
+	saved, err := s.getSavedView(uint(viewID), userID)
+	if err != nil {
+		return nil, err
+	}
+	return toView(saved)
+}
+
+// ViewTodos evaluates a view for a user, relative due ranges against today
+func (s *SavedViewService) ViewTodos(userID uint, ref string) ([]model.Todo, error) {
+	view, err := s.GetView(userID, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.todoService.ListTodos(userID, view.Filter)
+}
+
+// CreateView saves a named filter for a user
+func (s *SavedViewService) CreateView(userID uint, req dto.CreateSavedViewRequest) (*View, error) {
+	count, err := s.viewRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxSavedViews {
+		return nil, fmt.Errorf("%w: at most %d saved views are allowed", ErrInvalidSavedView, maxSavedViews)
+	}
+
+	saved := &model.SavedView{UserID: userID}
+	if err := s.setName(saved, req.Name); err != nil {
+		return nil, err
+	}
+	if err := s.setFilter(saved, req.Filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(saved); err != nil {
+		return nil, err
+	}
+	return toView(saved)
+}
+
+// UpdateView renames a saved view or replaces its filter
+func (s *SavedViewService) UpdateView(viewID, userID uint, req dto.UpdateSavedViewRequest) (*View, error) {
+	saved, err := s.getSavedView(viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if err := s.setName(saved, *req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Filter != nil {
+		if err := s.setFilter(saved, *req.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.viewRepo.Update(saved); err != nil {
+		return nil, err
+	}
+	return toView(saved)
+}
+
+// DeleteView deletes a saved view of a user
+func (s *SavedViewService) DeleteView(viewID, userID uint) error {
+	saved, err := s.getSavedView(viewID, userID)
+	if err != nil {
+		return err
+	}
+	return s.viewRepo.Delete(saved)
+}
+
+func (s *SavedViewService) getSavedView(viewID, userID uint) (*model.SavedView, error) {
+	saved, err := s.viewRepo.FindByIDAndUserID(viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return nil, ErrSavedViewNotFound
+	}
+	return saved, nil
+}
+
+// setName sets the name of a saved view, which must be unique per user and
+// differ from the built-in views
+func (s *SavedViewService) setName(saved *model.SavedView, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSavedView)
+	}
+	for _, builtin := range builtinViews {
+		if strings.EqualFold(builtin.Name, name) {
+			return ErrSavedViewExists
+		}
+	}
+
+	exists, err := s.viewRepo.ExistsByUserIDAndName(saved.UserID, name, saved.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrSavedViewExists
+	}
+
+	saved.Name = name
+	return nil
+}
+
+// setFilter validates a list query like the todo list does and stores it
+func (s *SavedViewService) setFilter(saved *model.SavedView, filter dto.TodoListQuery) error {
+	if err := s.todoService.ValidateListQuery(saved.UserID, filter); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return err
+	}
+	saved.Filter = string(encoded)
+	return nil
+}
+
+// toView decodes a saved view
+func toView(saved *model.SavedView) (*View, error) {
+	view := &View{
+		ID:        saved.ID,
+		Name:      saved.Name,
+		CreatedAt: saved.CreatedAt,
+		UpdatedAt: saved.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(saved.Filter), &view.Filter); err != nil {
+		return nil, fmt.Errorf("failed to decode saved view: %w", err)
+	}
+	return view, nil
+}
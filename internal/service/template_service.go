@@ -0,0 +1,335 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// maxTemplates limits the number of templates of a workspace
+const maxTemplates = 100
+
+// datePlaceholder is filled with the base date when a template is instantiated
+const datePlaceholder = "date"
+
+var (
+	// ErrTemplateNotFound is returned when a template is not found
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrTemplateExists is returned when the template name is already used
+	ErrTemplateExists = errors.New("a template with this name already exists")
+	// ErrInvalidTemplate is returned when a template or its instantiation is invalid
+	ErrInvalidTemplate = errors.New("invalid template")
+)
+
+// placeholderPattern matches {{name}} placeholders in item titles and descriptions
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z][A-Za-z0-9_]*)\s*\}\}`)
+
+// Template is a decoded todo template
+type Template struct {
+	ID          uint
+	Name        string
+	Description string
+	Items       []dto.TemplateItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Placeholders returns the placeholders the items use, in order of first
+// appearance, without the date placeholder which is always filled
+func (t *Template) Placeholders() []string {
+	placeholders := []string{}
+	seen := map[string]bool{datePlaceholder: true}
+	for _, item := range t.Items {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(item.Title+" "+item.Description, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				placeholders = append(placeholders, match[1])
+			}
+		}
+	}
+	return placeholders
+}
+
+// TemplateService handles todo templates
+type TemplateService struct {
+	templateRepo *repository.TemplateRepository
+	todoService  *TodoService
+}
+
+// NewTemplateService creates a new template service instance
+func NewTemplateService(templateRepo *repository.TemplateRepository, todoService *TodoService) *TemplateService {
+	return &TemplateService{
+		templateRepo: templateRepo,
+		todoService:  todoService,
+	}
+}
+
+// ListTemplates returns the templates of a user
+func (s *TemplateService) ListTemplates(userID uint) ([]Template, error) {
+	stored, err := s.templateRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, len(stored))
+	for i := range stored {
+		template, err := toTemplate(&stored[i])
+		if err != nil {
+			return nil, err
+		}
+		templates[i] = *template
+	}
+	return templates, nil
+}
+
+// GetTemplate returns a template of a user
+func (s *TemplateService) GetTemplate(templateID, userID uint) (*Template, error) {
+	stored, err := s.getStoredTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplate(stored)
+}
+
+// CreateTemplate creates a template from items. With a todo ID the todo is
+// copied as the first item, its due date becoming an offset of zero days.
+func (s *TemplateService) CreateTemplate(userID uint, req dto.CreateTemplateRequest) (*Template, error) {
+	count, err := s.templateRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxTemplates {
+		return nil, fmt.Errorf("%w: at most %d templates are allowed", ErrInvalidTemplate, maxTemplates)
+	}
+
+	items := req.Items
+	if req.TodoID != nil {
+		todo, err := s.todoService.GetTodoByID(*req.TodoID, userID)
+		if err != nil {
+			return nil, err
+		}
+		item := templateItemFromTodo(todo, s.todoService.UserLocation(userID))
+		items = append([]dto.TemplateItem{item}, items...)
+	}
+
+	stored := &model.TodoTemplate{UserID: userID, Description: req.Description}
+	if err := s.setName(stored, req.Name); err != nil {
+		return nil, err
+	}
+	if err := setTemplateItems(stored, items); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(stored); err != nil {
+		return nil, err
+	}
+	return toTemplate(stored)
+}
+
+// UpdateTemplate renames a template, changes its description or replaces its items
+func (s *TemplateService) UpdateTemplate(templateID, userID uint, req dto.UpdateTemplateRequest) (*Template, error) {
+	stored, err := s.getStoredTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if err := s.setName(stored, *req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		stored.Description = *req.Description
+	}
+	if req.Items != nil {
+		if err := setTemplateItems(stored, *req.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.templateRepo.Update(stored); err != nil {
+		return nil, err
+	}
+	return toTemplate(stored)
+}
+
+// DeleteTemplate deletes a template of a user. Todos created from it stay.
+func (s *TemplateService) DeleteTemplate(templateID, userID uint) error {
+	stored, err := s.getStoredTemplate(templateID, userID)
+	if err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(stored)
+}
+
+// Instantiate creates the todos of a template in one transaction. Due dates
+// are offsets from the base date, which defaults to today in the user's time
+// zone, and every placeholder except {{date}} needs a value.
+func (s *TemplateService) Instantiate(templateID, userID uint, req dto.InstantiateTemplateRequest) ([]*model.Todo, error) {
+	template, err := s.GetTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var base time.Time
+	if req.BaseDate == "" {
+		now := time.Now().In(s.todoService.UserLocation(userID))
+		base = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	} else if base, err = time.Parse("2006-01-02", req.BaseDate); err != nil {
+		return nil, fmt.Errorf("%w: base_date must be YYYY-MM-DD", ErrInvalidTemplate)
+	}
+
+	values := map[string]string{datePlaceholder: base.Format("2006-01-02")}
+	for _, placeholder := range template.Placeholders() {
+		value := strings.TrimSpace(req.Values[placeholder])
+		if value == "" {
+			return nil, fmt.Errorf("%w: no value for placeholder %s", ErrInvalidTemplate, placeholder)
+		}
+		values[placeholder] = value
+	}
+	fill := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return values[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+
+	reqs := make([]dto.CreateTodoRequest, len(template.Items))
+	for i, item := range template.Items {
+		reqs[i] = dto.CreateTodoRequest{
+			Title:           fill(item.Title),
+			Description:     fill(item.Description),
+			Priority:        item.Priority,
+			Tags:            item.Tags,
+			Recurrence:      item.Recurrence,
+			EstimateMinutes: item.EstimateMinutes,
+		}
+		if reqs[i].Priority == "" {
+			reqs[i].Priority = "medium"
+		}
+		if item.DueOffsetDays != nil {
+			reqs[i].DueDate = base.AddDate(0, 0, *item.DueOffsetDays).Format("2006-01-02")
+			if item.DueTime != "" {
+				reqs[i].DueDate += "T" + item.DueTime
+			}
+		}
+	}
+
+	return s.todoService.CreateTodos(userID, reqs)
+}
+
+func (s *TemplateService) getStoredTemplate(templateID, userID uint) (*model.TodoTemplate, error) {
+	stored, err := s.templateRepo.FindByIDAndUserID(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrTemplateNotFound
+	}
+	return stored, nil
+}
+
+// setName sets the name of a template, which must be unique per user
+func (s *TemplateService) setName(stored *model.TodoTemplate, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+
+	exists, err := s.templateRepo.ExistsByUserIDAndName(stored.UserID, name, stored.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrTemplateExists
+	}
+
+	stored.Name = name
+	return nil
+}
+
+// setTemplateItems validates the items of a template and stores them. Titles
+// are checked again after placeholders are filled in.
+func setTemplateItems(stored *model.TodoTemplate, items []dto.TemplateItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: at least one item or todo_id is required", ErrInvalidTemplate)
+	}
+
+	for i := range items {
+		item := &items[i]
+		if strings.TrimSpace(item.Title) == "" {
+			return fmt.Errorf("%w: item %d needs a title", ErrInvalidTemplate, i+1)
+		}
+		if item.Priority != "" && !isValidPriority(item.Priority) {
+			return ErrInvalidPriority
+		}
+		if item.DueTime != "" {
+			if item.DueOffsetDays == nil {
+				return fmt.Errorf("%w: item %d has a due_time without due_offset_days", ErrInvalidTemplate, i+1)
+			}
+			if _, err := time.Parse("15:04", item.DueTime); err != nil {
+				return fmt.Errorf("%w: item %d due_time must be HH:MM", ErrInvalidTemplate, i+1)
+			}
+		}
+		if item.EstimateMinutes != nil && !isValidEstimate(*item.EstimateMinutes) {
+			return ErrInvalidEstimate
+		}
+
+		tags, err := newTags(stored.UserID, item.Tags)
+		if err != nil {
+			return err
+		}
+		item.Tags = tagNames(tags)
+		if item.Recurrence, err = normalizeRecurrence(item.Recurrence); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	stored.Items = string(encoded)
+	return nil
+}
+
+// templateItemFromTodo copies a todo into a template item
+func templateItemFromTodo(todo *model.Todo, loc *time.Location) dto.TemplateItem {
+	item := dto.TemplateItem{
+		Title:           todo.Title,
+		Description:     todo.Description,
+		Priority:        todo.Priority,
+		Tags:            tagNames(todo.Tags),
+		Recurrence:      todo.Recurrence,
+		EstimateMinutes: todo.EstimateMinutes,
+	}
+	if todo.DueDate != nil {
+		offset := 0
+		item.DueOffsetDays = &offset
+		if !todo.DueAllDay {
+			item.DueTime = todo.DueDate.In(loc).Format("15:04")
+		}
+	}
+	return item
+}
+
+// toTemplate decodes a stored template
+func toTemplate(stored *model.TodoTemplate) (*Template, error) {
+	template := &Template{
+		ID:          stored.ID,
+		Name:        stored.Name,
+		Description: stored.Description,
+		CreatedAt:   stored.CreatedAt,
+		UpdatedAt:   stored.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(stored.Items), &template.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode template: %w", err)
+	}
+	return template, nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+var (
+	// ErrTimerRunning is returned when a user starts a timer while another one runs
+	ErrTimerRunning = errors.New("a timer is already running, stop it first")
+	// ErrNoTimerRunning is returned when there is no timer to stop
+	ErrNoTimerRunning = errors.New("no timer is running")
+	// ErrTimeEntryNotFound is returned when a time entry is not found
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	// ErrInvalidTimeEntry is returned when a manual entry or range is invalid
+	ErrInvalidTimeEntry = errors.New("invalid time entry, use RFC 3339 start times and YYYY-MM-DD dates")
+)
+
+// TimeSummary is the tracked time of a user over a date range, in total
+// (the whole workspace), per todo and per day
+type TimeSummary struct {
+	From         string
+	To           string
+	TotalSeconds int64
+	ByTodo       []repository.TodoTimeTotal
+	ByDay        []repository.DayTimeTotal
+}
+
+// TimeEntryService handles time tracking on todos
+type TimeEntryService struct {
+	entryRepo   *repository.TimeEntryRepository
+	todoService *TodoService
+}
+
+// NewTimeEntryService creates a new time entry service instance
+func NewTimeEntryService(entryRepo *repository.TimeEntryRepository, todoService *TodoService) *TimeEntryService {
+	return &TimeEntryService{
+		entryRepo:   entryRepo,
+		todoService: todoService,
+	}
+}
+
+// StartTimer starts a timer on a todo. A user can only run one timer at a time.
+func (s *TimeEntryService) StartTimer(todoID, userID uint, note string) (*model.TimeEntry, error) {
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := s.entryRepo.FindRunningByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, ErrTimerRunning
+	}
+
+	now := time.Now()
+	entry := &model.TimeEntry{
+		UserID:    userID,
+		TodoID:    todoID,
+		StartedAt: now,
+		Day:       now.In(s.todoService.UserLocation(userID)).Format("2006-01-02"),
+		Note:      note,
+	}
+	if err := s.entryRepo.Create(entry); err != nil {
+		// The running timer index rejects a timer started concurrently
+		if running, findErr := s.entryRepo.FindRunningByUserID(userID); findErr == nil && running != nil {
+			return nil, ErrTimerRunning
+		}
+		return nil, err
+	}
+
+	entry.Todo = todo
+	return entry, nil
+}
+
+// StopTimer stops the running timer of a user
+func (s *TimeEntryService) StopTimer(userID uint) (*model.TimeEntry, error) {
+	entry, err := s.entryRepo.FindRunningByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrNoTimerRunning
+	}
+
+	now := time.Now()
+	entry.EndedAt = &now
+	entry.DurationSeconds = int64(now.Sub(entry.StartedAt).Seconds())
+
+	if err := s.entryRepo.Update(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetRunningTimer returns the running timer of a user, nil when none runs
+func (s *TimeEntryService) GetRunningTimer(userID uint) (*model.TimeEntry, error) {
+	return s.entryRepo.FindRunningByUserID(userID)
+}
+
+// AddEntry records a finished entry of a given duration on a todo. Without a
+// start time the entry ends now.
+func (s *TimeEntryService) AddEntry(todoID, userID uint, req dto.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+	todo, err := s.todoService.GetTodoByID(todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	startedAt := time.Now().Add(-duration)
+	if req.StartedAt != "" {
+		if startedAt, err = time.Parse(time.RFC3339, req.StartedAt); err != nil {
+			return nil, ErrInvalidTimeEntry
+		}
+	}
+	startedAt = startedAt.UTC()
+	endedAt := startedAt.Add(duration)
+
+	entry := &model.TimeEntry{
+		UserID:          userID,
+		TodoID:          todoID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: int64(duration.Seconds()),
+		Day:             startedAt.In(s.todoService.UserLocation(userID)).Format("2006-01-02"),
+		Note:            req.Note,
+	}
+	if err := s.entryRepo.Create(entry); err != nil {
+		return nil, err
+	}
+
+	entry.Todo = todo
+	return entry, nil
+}
+
+// ListEntries returns the time entries of a user, optionally of one todo and
+// within an inclusive YYYY-MM-DD range
+func (s *TimeEntryService) ListEntries(userID uint, todoID uint, from, to string) ([]model.TimeEntry, error) {
+	if err := validateDayRange(from, to); err != nil {
+		return nil, err
+	}
+	return s.entryRepo.FindByFilter(userID, repository.TimeEntryFilter{TodoID: todoID, From: from, To: to})
+}
+
+// DeleteEntry deletes a time entry of a user
+func (s *TimeEntryService) DeleteEntry(entryID, userID uint) error {
+	entry, err := s.entryRepo.FindByIDAndUserID(entryID, userID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrTimeEntryNotFound
+	}
+	return s.entryRepo.Delete(entry)
+}
+
+// Summarize totals the finished time entries of a user within an inclusive
+// YYYY-MM-DD range, which defaults to the current week
+func (s *TimeEntryService) Summarize(userID uint, from, to string) (*TimeSummary, error) {
+	if from == "" && to == "" {
+		loc := s.todoService.UserLocation(userID)
+		monday := startOfWeek(time.Now().In(loc))
+		from, to = monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02")
+	}
+	if err := validateDayRange(from, to); err != nil {
+		return nil, err
+	}
+
+	filter := repository.TimeEntryFilter{From: from, To: to}
+	byTodo, err := s.entryRepo.SumByTodo(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	byDay, err := s.entryRepo.SumByDay(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TimeSummary{From: from, To: to, ByTodo: byTodo, ByDay: byDay}
+	for _, day := range byDay {
+		summary.TotalSeconds += day.Seconds
+	}
+	return summary, nil
+}
+
+// validateDayRange checks optional YYYY-MM-DD bounds
+func validateDayRange(from, to string) error {
+	for _, day := range []string{from, to} {
+		if day == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			return ErrInvalidTimeEntry
+		}
+	}
+	if from != "" && to != "" && from > to {
+		return ErrInvalidTimeEntry
+	}
+	return nil
+}
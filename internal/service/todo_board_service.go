@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/event"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// maxRankLength is the rank length after which a user's todos are
+// renumbered, well below the 64 characters of todos.position
+const maxRankLength = 32
+
+// ErrInvalidMove is returned when a move names both or invalid neighbours
+var ErrInvalidMove = errors.New("invalid move, give before_id or after_id of another todo")
+
+// BoardColumn is one status column of the board with its todos in position order
+type BoardColumn struct {
+	Status WorkflowStatus
+	Todos  []model.Todo
+}
+
+// GetBoard returns the todos of a user grouped by the statuses of the
+// workflow, in workflow order. Todos in a status the workflow no longer
+// defines get a column of their own at the end.
+func (s *TodoService) GetBoard(userID uint) ([]BoardColumn, error) {
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := s.findTodos(userID, repository.TodoFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]BoardColumn, len(settings.workflow.Statuses))
+	index := make(map[string]int, len(columns))
+	for i, status := range settings.workflow.Statuses {
+		columns[i] = BoardColumn{Status: status, Todos: []model.Todo{}}
+		index[status.Key] = i
+	}
+
+	for _, todo := range todos {
+		i, ok := index[todo.Status]
+		if !ok {
+			i = len(columns)
+			index[todo.Status] = i
+			columns = append(columns, BoardColumn{Status: WorkflowStatus{Key: todo.Status, Name: todo.Status}})
+		}
+		columns[i].Todos = append(columns[i].Todos, todo)
+	}
+
+	return columns, nil
+}
+
+// MoveTodo places a todo directly before or after another todo, or at the
+// end without either, and optionally moves it to another status following
+// the workflow rules of UpdateTodo
+func (s *TodoService) MoveTodo(todoID, userID uint, req dto.MoveTodoRequest) (*model.Todo, error) {
+	if req.BeforeID != nil && req.AfterID != nil {
+		return nil, ErrInvalidMove
+	}
+	anchorID := req.BeforeID
+	if anchorID == nil {
+		anchorID = req.AfterID
+	}
+	if anchorID != nil && *anchorID == todoID {
+		return nil, ErrInvalidMove
+	}
+
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved *model.Todo
+	err = s.inTransaction(func(txService *TodoService) error {
+
+		todo, err := txService.GetTodoByID(todoID, userID)
+		if err != nil {
+			return err
+		}
+		if anchorID != nil {
+			if _, err := txService.GetTodoByID(*anchorID, userID); err != nil {
+				return err
+			}
+		}
+
+		if req.Status != nil {
+			if todo, err = txService.updateTodo(todoID, userID, dto.UpdateTodoRequest{Status: req.Status}, settings); err != nil {
+				return err
+			}
+		}
+
+		todo.Position, err = txService.rankWithRenumber(userID, func() (string, string, error) {
+			return txService.moveBounds(userID, todoID, req)
+		})
+		if err != nil {
+			return err
+		}
+		if err := txService.todoRepo.UpdatePosition(todo); err != nil {
+			return err
+		}
+		// A status change already published the update
+		if req.Status == nil {
+			if err := txService.publish(event.TodoUpdated, todo, settings.loc); err != nil {
+				return err
+			}
+		}
+
+		moved = todo
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// moveBounds returns the positions a moved todo has to fit between
+func (s *TodoService) moveBounds(userID, todoID uint, req dto.MoveTodoRequest) (string, string, error) {
+	if req.BeforeID == nil && req.AfterID == nil {
+		last, err := s.todoRepo.FindBoundaryPosition(userID, true)
+		return last, "", err
+	}
+
+	after := req.AfterID != nil
+	anchorID := req.BeforeID
+	if after {
+		anchorID = req.AfterID
+	}
+	anchor, err := s.todoRepo.FindByID(*anchorID)
+	if err != nil {
+		return "", "", err
+	}
+	if anchor.Position == "" {
+		return "", "", ErrRankOrder
+	}
+
+	neighbour, err := s.todoRepo.FindAdjacent(userID, anchor, after, todoID)
+	if err != nil {
+		return "", "", err
+	}
+	position := ""
+	if neighbour != nil {
+		position = neighbour.Position
+	}
+
+	if after {
+		return anchor.Position, position, nil
+	}
+	return position, anchor.Position, nil
+}
+
+// placeFirst gives a new todo the position ahead of all other todos of its
+// user, matching the newest-first order todos were listed in before
+// positions existed
+func (s *TodoService) placeFirst(todo *model.Todo) error {
+	position, err := s.rankWithRenumber(todo.UserID, func() (string, string, error) {
+		first, err := s.todoRepo.FindBoundaryPosition(todo.UserID, false)
+		return "", first, err
+	})
+	if err != nil {
+		return err
+	}
+	todo.Position = position
+	return nil
+}
+
+// rankWithRenumber returns a rank between the positions returned by bounds.
+// When the positions leave no room, are missing or the rank grows too long,
+// the user's todos are renumbered once and bounds is asked again.
+func (s *TodoService) rankWithRenumber(userID uint, bounds func() (string, string, error)) (string, error) {
+	for attempt := 0; ; attempt++ {
+		before, after, err := bounds()
+		if err != nil && !errors.Is(err, ErrRankOrder) {
+			return "", err
+		}
+		var rank string
+		if err == nil {
+			rank, err = RankBetween(before, after)
+		}
+		if err == nil && (len(rank) <= maxRankLength || attempt > 0) {
+			return rank, nil
+		}
+		if attempt > 0 {
+			return "", err
+		}
+
+		ids, err := s.todoRepo.FindIDsByPosition(userID)
+		if err != nil {
+			return "", err
+		}
+		if err := s.todoRepo.SetPositions(ids, SequentialRanks(len(ids))); err != nil {
+			return "", err
+		}
+	}
+}
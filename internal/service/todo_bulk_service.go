@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// Bulk operation kinds
+const (
+	BulkOpCreate      = "create"
+	BulkOpUpdate      = "update"
+	BulkOpDelete      = "delete"
+	BulkOpUpdateWhere = "update_where"
+	BulkOpDeleteWhere = "delete_where"
+)
+
+// Bulk execution modes
+const (
+	// BulkModeTransaction runs all operations in one transaction; any failure rolls back everything
+	BulkModeTransaction = "transaction"
+	// BulkModeBestEffort runs every operation independently and keeps the ones that succeed
+	BulkModeBestEffort = "best_effort"
+)
+
+var (
+	// ErrInvalidBulkOperation is returned when a bulk operation is unknown or misses required fields
+	ErrInvalidBulkOperation = errors.New("invalid bulk operation")
+	// ErrBulkRolledBack is reported for operations undone because another operation in the transaction failed
+	ErrBulkRolledBack = errors.New("operation rolled back because another operation failed")
+
+	errBulkAborted = errors.New("bulk transaction aborted")
+)
+
+// BulkResult is the outcome of a single bulk operation
+type BulkResult struct {
+	Op       string
+	Todo     *model.Todo
+	Affected int64
+	Err      error
+}
+
+// BulkTodos executes a batch of todo operations for a user.
+// Per-operation failures are reported in the results; the returned error is
+// only set when the batch itself could not be executed.
+func (s *TodoService) BulkTodos(userID uint, req dto.BulkTodoRequest) ([]BulkResult, error) {
+	results := make([]BulkResult, len(req.Operations))
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each best-effort operation is applied in its own transaction
+	if req.Mode == BulkModeBestEffort {
+		for i, op := range req.Operations {
+			err := s.inTransaction(func(txService *TodoService) error {
+				results[i] = txService.runBulkOperation(userID, op, settings)
+				return results[i].Err
+			})
+			if err != nil && results[i].Err == nil {
+				results[i] = BulkResult{Op: op.Op, Err: err}
+			}
+		}
+		return results, nil
+	}
+
+	failedIndex := -1
+	err = s.inTransaction(func(txService *TodoService) error {
+		for i, op := range req.Operations {
+			results[i] = txService.runBulkOperation(userID, op, settings)
+			if results[i].Err != nil {
+				failedIndex = i
+				return errBulkAborted
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAborted) {
+		return nil, err
+	}
+
+	if failedIndex >= 0 {
+		for i, op := range req.Operations {
+			if i != failedIndex {
+				results[i] = BulkResult{Op: op.Op, Err: ErrBulkRolledBack}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// runBulkOperation executes one bulk operation
+func (s *TodoService) runBulkOperation(userID uint, op dto.BulkTodoOperation, settings *todoSettings) BulkResult {
+	result := BulkResult{Op: op.Op}
+
+	switch op.Op {
+	case BulkOpCreate:
+		if op.Create == nil {
+			result.Err = ErrInvalidBulkOperation
+			break
+		}
+		result.Todo, result.Err = s.createTodo(userID, *op.Create, settings)
+
+	case BulkOpUpdate:
+		if op.ID == 0 || op.Update == nil {
+			result.Err = ErrInvalidBulkOperation
+			break
+		}
+		result.Todo, result.Err = s.updateTodo(op.ID, userID, *op.Update, settings)
+
+	case BulkOpDelete:
+		if op.ID == 0 {
+			result.Err = ErrInvalidBulkOperation
+			break
+		}
+		result.Err = s.deleteTodo(op.ID, userID)
+
+	case BulkOpUpdateWhere:
+		if op.Filter == nil || op.Update == nil {
+			result.Err = ErrInvalidBulkOperation
+			break
+		}
+		result.Affected, result.Err = s.updateWhere(userID, *op.Filter, *op.Update, settings)
+
+	case BulkOpDeleteWhere:
+		if op.Filter == nil {
+			result.Err = ErrInvalidBulkOperation
+			break
+		}
+		result.Affected, result.Err = s.deleteWhere(userID, *op.Filter, settings)
+
+	default:
+		result.Err = ErrInvalidBulkOperation
+	}
+
+	if result.Err == nil && (op.Op == BulkOpCreate || op.Op == BulkOpUpdate || op.Op == BulkOpDelete) {
+		result.Affected = 1
+	}
+
+	return result
+}
+
+// updateWhere applies the same update to every todo matching the filter
+func (s *TodoService) updateWhere(userID uint, filter dto.BulkTodoFilter, req dto.UpdateTodoRequest, settings *todoSettings) (int64, error) {
+	todos, err := s.findByBulkFilter(userID, filter, settings)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range todos {
+		wasCompleted := todos[i].CompletedAt != nil
+		if err := applyTodoUpdate(&todos[i], req, settings); err != nil {
+			return 0, err
+		}
+		if err := s.saveTodoUpdate(&todos[i], req.Tags != nil, req.CustomFields != nil, wasCompleted, settings); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(todos)), nil
+}
+
+// deleteWhere deletes every todo matching the filter
+func (s *TodoService) deleteWhere(userID uint, filter dto.BulkTodoFilter, settings *todoSettings) (int64, error) {
+	todos, err := s.findByBulkFilter(userID, filter, settings)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]uint, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+
+	deleted, err := s.todoRepo.DeleteByUserIDAndIDs(userID, ids)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := s.publishDeleted(userID, id); err != nil {
+			return 0, err
+		}
+	}
+	return deleted, nil
+}
+
+func (s *TodoService) findByBulkFilter(userID uint, filter dto.BulkTodoFilter, settings *todoSettings) ([]model.Todo, error) {
+	if filter.Status != "" && !settings.workflow.HasStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	if filter.Priority != "" && !isValidPriority(filter.Priority) {
+		return nil, ErrInvalidPriority
+	}
+
+	return s.findTodos(userID, repository.TodoFilter{
+		Status:   filter.Status,
+		Priority: filter.Priority,
+		Overdue:  filter.Overdue,
+		Cutoff:   DueCutoff(time.Now(), settings.loc),
+	})
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"rest-api/internal/dto"
+	"rest-api/internal/event"
 	"rest-api/internal/model"
 	"rest-api/internal/repository"
 
@@ -20,58 +25,215 @@ var (
 	ErrInvalidStatus = errors.New("invalid status value")
 	// ErrInvalidPriority is returned when priority value is invalid
 	ErrInvalidPriority = errors.New("invalid priority value")
+	// ErrInvalidTitle is returned when title is empty or too long
+	ErrInvalidTitle = errors.New("title is required and must be at most 200 characters")
+	// ErrInvalidDueDate is returned when due date cannot be parsed
+	ErrInvalidDueDate = errors.New("invalid due date, use YYYY-MM-DD or RFC 3339 date-time")
+	// ErrInvalidTag is returned when a tag is empty, too long or contains spaces
+	ErrInvalidTag = errors.New("tags must be 1-50 characters without spaces, at most 20 per todo")
+	// ErrInvalidEstimate is returned when an estimate is negative or too large
+	ErrInvalidEstimate = errors.New("estimate must be between 1 and 100000 minutes")
+	// ErrInvalidSort is returned when the list is sorted by an unknown column
+	ErrInvalidSort = errors.New("invalid sort, use position, created_at, updated_at, due_date, title, priority or cf.<key>")
+	// ErrInvalidDueFilter is returned when the list is filtered by an unknown due range
+	ErrInvalidDueFilter = errors.New("invalid due filter, use overdue, today, tomorrow, this_week, next_7_days, next_30_days or none")
 )
 
+// todoStreamBatchSize is the number of streamed todos loaded at once with
+// their tags, custom fields and blocked state
+const todoStreamBatchSize = 100
+
 // TodoService handles todo business logic
 type TodoService struct {
-	todoRepo *repository.TodoRepository
+	todoRepo     *repository.TodoRepository
+	userRepo     *repository.UserRepository
+	workflowRepo *repository.WorkflowRepository
+	fieldRepo    *repository.CustomFieldRepository
+	depRepo      *repository.DependencyRepository
+	outbox       *OutboxRelay
 }
 
-// NewTodoService creates a new todo service instance
-func NewTodoService(todoRepo *repository.TodoRepository) *TodoService {
+// NewTodoService creates a new todo service instance. Created, updated and
+// deleted todos are written to the outbox relayed by outbox, which may be
+// nil to not record them.
+func NewTodoService(todoRepo *repository.TodoRepository, userRepo *repository.UserRepository, workflowRepo *repository.WorkflowRepository, fieldRepo *repository.CustomFieldRepository, depRepo *repository.DependencyRepository, outbox *OutboxRelay) *TodoService {
 	return &TodoService{
-		todoRepo: todoRepo,
+		todoRepo:     todoRepo,
+		userRepo:     userRepo,
+		workflowRepo: workflowRepo,
+		fieldRepo:    fieldRepo,
+		depRepo:      depRepo,
+		outbox:       outbox,
+	}
+}
+
+// inTransaction runs fn with a todo service bound to a transaction. All its
+// repositories use the transaction, so reads see the changes made so far.
+// The events of the changes are written to the outbox in the same
+// transaction and relayed once it commits.
+func (s *TodoService) inTransaction(fn func(txService *TodoService) error) error {
+	err := s.todoRepo.Transaction(func(txRepo *repository.TodoRepository) error {
+		return fn(&TodoService{
+			todoRepo:     txRepo,
+			userRepo:     txRepo.Users(),
+			workflowRepo: txRepo.Workflows(),
+			fieldRepo:    txRepo.CustomFields(),
+			depRepo:      txRepo.Dependencies(),
+			outbox:       s.outbox,
+		})
+	})
+	if err == nil {
+		s.outbox.Notify()
+	}
+	return err
+}
+
+// publish writes the event of a created or updated todo to the outbox. It
+// is called within the transaction of the change with the todo as the change
+// left it, which the message holds, and the time zone of its user.
+func (s *TodoService) publish(eventType string, todo *model.Todo, loc *time.Location) error {
+	if s.outbox == nil {
+		return nil
+	}
+	response := ToTodoResponse(todo, loc)
+	return s.writeEvent(TodoEventMessage{Event: newTodoEvent(eventType, todo.UserID, todo.ID), Todo: &response})
+}
+
+// publishDeleted writes the event of a deleted todo to the outbox, within
+// the transaction of the deletion
+func (s *TodoService) publishDeleted(userID, todoID uint) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.writeEvent(TodoEventMessage{Event: newTodoEvent(event.TodoDeleted, userID, todoID)})
+}
+
+// writeEvent writes a todo event message to the outbox
+func (s *TodoService) writeEvent(message TodoEventMessage) error {
+	return writeOutbox(s.todoRepo.Outbox(), TopicTodoEvent, "todo.event:"+message.ID, message)
+}
+
+// newTodoEvent returns a new event of a todo change happening now
+func newTodoEvent(eventType string, userID, todoID uint) event.Event {
+	at := time.Now().UTC()
+	return event.Event{ID: event.NewID(at), Type: eventType, UserID: userID, TodoID: todoID, At: at}
+}
+
+// todoSettings are the per-user settings the todo rules depend on
+type todoSettings struct {
+	loc      *time.Location
+	workflow *Workflow
+	fields   []model.CustomField
+}
+
+// settings loads the time zone, workflow and custom fields of a user
+func (s *TodoService) settings(userID uint) (*todoSettings, error) {
+	workflow, _, err := loadWorkflow(s.workflowRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.fieldRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &todoSettings{loc: s.UserLocation(userID), workflow: workflow, fields: fields}, nil
+}
+
+// UserLocation returns the time zone of a user, UTC when unknown
+func (s *TodoService) UserLocation(userID uint) *time.Location {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return time.UTC
 	}
+	return LoadLocation(user.TimeZone)
 }
 
 // CreateTodo creates a new todo for a user
 func (s *TodoService) CreateTodo(userID uint, req dto.CreateTodoRequest) (*model.Todo, error) {
-	// Validate status
-	if !isValidStatus(req.Status) {
-		return nil, ErrInvalidStatus
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
 	}
+	return s.createTodoInTransaction(userID, req, settings)
+}
 
-	// Validate priority
-	if !isValidPriority(req.Priority) {
-		return nil, ErrInvalidPriority
+// createTodoInTransaction creates a todo in a transaction of its own
+func (s *TodoService) createTodoInTransaction(userID uint, req dto.CreateTodoRequest, settings *todoSettings) (*model.Todo, error) {
+	var todo *model.Todo
+	err := s.inTransaction(func(txService *TodoService) error {
+		var err error
+		todo, err = txService.createTodo(userID, req, settings)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return todo, nil
+}
 
-	// Parse due date if provided
-	var dueDate *time.Time
-	if req.DueDate != "" {
-		parsedDate, err := time.Parse("2006-01-02", req.DueDate)
-		if err != nil {
-			return nil, errors.New("invalid date format, use YYYY-MM-DD")
+// CreateTodos creates several todos for a user in one transaction, either
+// all of them or none. The todos keep their given order at the top of the
+// manual order.
+func (s *TodoService) CreateTodos(userID uint, reqs []dto.CreateTodoRequest) ([]*model.Todo, error) {
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]*model.Todo, len(reqs))
+	err = s.inTransaction(func(txService *TodoService) error {
+		// Every new todo is placed first, so the last one is created first
+		for i := len(reqs) - 1; i >= 0; i-- {
+			todo, err := txService.createTodo(userID, reqs[i], settings)
+			if err != nil {
+				return fmt.Errorf("todo %d: %w", i+1, err)
+			}
+			todos[i] = todo
 		}
-		dueDate = &parsedDate
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	todo := &model.Todo{
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      req.Status,
-		Priority:    req.Priority,
-		DueDate:     dueDate,
-		UserID:      userID,
+	return todos, nil
+}
+
+// createTodo creates a todo using the given user settings
+func (s *TodoService) createTodo(userID uint, req dto.CreateTodoRequest, settings *todoSettings) (*model.Todo, error) {
+	todo, err := newTodoFromRequest(userID, req, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.Tags, err = s.todoRepo.FindOrCreateTags(userID, tagNames(todo.Tags)); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFieldUsers(todo); err != nil {
+		return nil, err
+	}
+
+	if err := s.placeFirst(todo); err != nil {
+		return nil, err
 	}
 
 	if err := s.todoRepo.Create(todo); err != nil {
 		return nil, err
 	}
+	if err := s.publish(event.TodoCreated, todo, settings.loc); err != nil {
+		return nil, err
+	}
 
 	return todo, nil
 }
 
+// validateCreateTodo checks a create request against the CreateTodo rules without saving it
+func validateCreateTodo(req dto.CreateTodoRequest, settings *todoSettings) error {
+	_, err := newTodoFromRequest(0, req, settings)
+	return err
+}
+
 // GetTodoByID retrieves a todo by ID with authorization check
 func (s *TodoService) GetTodoByID(todoID, userID uint) (*model.Todo, error) {
 	todo, err := s.todoRepo.FindByID(todoID)
@@ -87,33 +249,512 @@ func (s *TodoService) GetTodoByID(todoID, userID uint) (*model.Todo, error) {
 		return nil, ErrUnauthorizedAccess
 	}
 
+	if err := s.markBlocked([]*model.Todo{todo}); err != nil {
+		return nil, err
+	}
+
 	return todo, nil
 }
 
+// markBlocked sets Blocked on the todos that have an open blocker
+func (s *TodoService) markBlocked(todos []*model.Todo) error {
+	ids := make([]uint, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+
+	blockedIDs, err := s.depRepo.FindBlockedTodoIDs(ids)
+	if err != nil {
+		return err
+	}
+	blocked := make(map[uint]bool, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+
+	for _, todo := range todos {
+		todo.Blocked = blocked[todo.ID]
+	}
+	return nil
+}
+
+// findTodos finds the todos matching filter and marks the blocked ones
+func (s *TodoService) findTodos(userID uint, filter repository.TodoFilter) ([]model.Todo, error) {
+	todos, err := s.todoRepo.FindByFilter(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]*model.Todo, len(todos))
+	for i := range todos {
+		refs[i] = &todos[i]
+	}
+	if err := s.markBlocked(refs); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
 // GetUserTodos retrieves all todos for a user with optional filters
 func (s *TodoService) GetUserTodos(userID uint, status, priority string) ([]model.Todo, error) {
+	return s.ListTodos(userID, dto.TodoListQuery{Status: status, Priority: priority})
+}
+
+// todoSortColumns are the todo columns the list can be sorted by
+var todoSortColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"due_date":   true,
+	"title":      true,
+	"priority":   true,
+	"position":   true,
+}
+
+// ListTodos retrieves the todos of a user filtered by status, priority, tags,
+// due range, text and custom field values. Sort is a todo column or cf.<key>,
+// prefixed with - for descending order, and defaults to the manual position.
+func (s *TodoService) ListTodos(userID uint, query dto.TodoListQuery) ([]model.Todo, error) {
+	filter, err := s.listFilter(userID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.findTodos(userID, filter)
+}
+
+// ListTodosPage retrieves one page of the todos ListTodos would return,
+// along with the number of todos on all pages
+func (s *TodoService) ListTodosPage(userID uint, query dto.TodoListQuery, limit, offset int) ([]model.Todo, int64, error) {
+	filter, err := s.listFilter(userID, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.todoRepo.CountByFilter(userID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Limit, filter.Offset = limit, offset
+	todos, err := s.findTodos(userID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return todos, total, nil
+}
+
+// ValidateListQuery checks a list query without running it
+func (s *TodoService) ValidateListQuery(userID uint, query dto.TodoListQuery) error {
+	_, err := s.listFilter(userID, query)
+	return err
+}
+
+// listFilter validates a list query and turns it into a filter. Due ranges
+// are resolved against the current day in the user's time zone.
+func (s *TodoService) listFilter(userID uint, query dto.TodoListQuery) (repository.TodoFilter, error) {
+	filter := repository.TodoFilter{Status: query.Status, Priority: query.Priority, Open: query.Open}
+
 	// Validate filters if provided
-	if status != "" && !isValidStatus(status) {
-		return nil, ErrInvalidStatus
+	if err := s.validateStatusFilter(userID, query.Status); err != nil {
+		return filter, err
 	}
 
-	if priority != "" && !isValidPriority(priority) {
-		return nil, ErrInvalidPriority
+	if query.Priority != "" && !isValidPriority(query.Priority) {
+		return filter, ErrInvalidPriority
+	}
+
+	if len(query.Tags) > 0 {
+		tags, err := newTags(userID, query.Tags)
+		if err != nil {
+			return filter, err
+		}
+		filter.Tags = tagNames(tags)
+	}
+
+	if query.Due != "" {
+		if err := applyDueFilter(&filter, query.Due, time.Now(), s.UserLocation(userID)); err != nil {
+			return filter, err
+		}
+	}
+
+	if text := strings.TrimSpace(query.Query); text != "" {
+		filter.Text = containsPattern(strings.ToLower(text))
+	}
+
+	if len(query.Fields) == 0 && query.Sort == "" {
+		return filter, nil
+	}
+
+	fields, err := s.fieldRepo.FindByUserID(userID)
+	if err != nil {
+		return filter, err
 	}
 
-	return s.todoRepo.FindByUserIDWithFilters(userID, status, priority)
+	for key, value := range query.Fields {
+		field := findField(fields, key)
+		if field == nil {
+			return filter, fmt.Errorf("%w: unknown field %s", ErrInvalidCustomField, key)
+		}
+		condition, err := fieldCondition(field, value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Fields = append(filter.Fields, condition)
+	}
+
+	if query.Sort != "" {
+		sort := strings.TrimPrefix(query.Sort, "-")
+		filter.Sort.Desc = sort != query.Sort
+		if key := strings.TrimPrefix(sort, "cf."); key != sort {
+			field := findField(fields, key)
+			if field == nil {
+				return filter, fmt.Errorf("%w: unknown field %s", ErrInvalidCustomField, key)
+			}
+			filter.Sort.FieldID = field.ID
+			filter.Sort.Column = fieldSortColumn(field)
+		} else if todoSortColumns[sort] {
+			filter.Sort.Column = sort
+		} else {
+			return filter, ErrInvalidSort
+		}
+	}
+
+	return filter, nil
+}
+
+// containsPattern returns a LIKE pattern matching text anywhere, with the
+// wildcards in text escaped
+func containsPattern(text string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + escaper.Replace(text) + "%"
+}
+
+// StreamUserTodos calls fn for every todo ListTodos would return, in the
+// same order, loading todoStreamBatchSize todos at a time
+func (s *TodoService) StreamUserTodos(userID uint, query dto.TodoListQuery, fn func(todo *model.Todo) error) error {
+	filter, err := s.listFilter(userID, query)
+	if err != nil {
+		return err
+	}
+
+	return s.todoRepo.StreamByFilter(userID, filter, todoStreamBatchSize, func(todos []model.Todo) error {
+		refs := make([]*model.Todo, len(todos))
+		for i := range todos {
+			refs[i] = &todos[i]
+		}
+		if err := s.markBlocked(refs); err != nil {
+			return err
+		}
+		for _, todo := range refs {
+			if err := fn(todo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// UpdateTodo updates a todo with authorization check
+// validateStatusFilter checks an optional status filter against the user's workflow
+func (s *TodoService) validateStatusFilter(userID uint, status string) error {
+	if status == "" {
+		return nil
+	}
+	workflow, _, err := loadWorkflow(s.workflowRepo, userID)
+	if err != nil {
+		return err
+	}
+	if !workflow.HasStatus(status) {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// UpdateTodo updates a todo with authorization check. Status changes must be
+// allowed by the user's workflow.
 func (s *TodoService) UpdateTodo(todoID, userID uint, req dto.UpdateTodoRequest) (*model.Todo, error) {
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var todo *model.Todo
+	err = s.inTransaction(func(txService *TodoService) error {
+		todo, err = txService.updateTodo(todoID, userID, req, settings)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// updateTodo updates a todo using the given user settings
+func (s *TodoService) updateTodo(todoID, userID uint, req dto.UpdateTodoRequest, settings *todoSettings) (*model.Todo, error) {
 	// Check if todo exists and user owns it
 	todo, err := s.GetTodoByID(todoID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update fields if provided
+	wasCompleted := todo.CompletedAt != nil
+	if err := applyTodoUpdate(todo, req, settings); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveTodoUpdate(todo, req.Tags != nil, req.CustomFields != nil, wasCompleted, settings); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// saveTodoUpdate persists an updated todo. Changed tags and custom field
+// values are replaced, and completing a recurring todo creates its next
+// occurrence.
+func (s *TodoService) saveTodoUpdate(todo *model.Todo, tagsChanged, fieldsChanged, wasCompleted bool, settings *todoSettings) error {
+	if tagsChanged {
+		tags, err := s.todoRepo.FindOrCreateTags(todo.UserID, tagNames(todo.Tags))
+		if err != nil {
+			return err
+		}
+		todo.Tags = tags
+	}
+
+	if fieldsChanged {
+		if err := s.checkFieldUsers(todo); err != nil {
+			return err
+		}
+	}
+
+	// The recurrence moves on to the next occurrence
+	var next *model.Todo
+	if !wasCompleted && todo.CompletedAt != nil && todo.Recurrence != "" {
+		next = nextOccurrence(todo, settings)
+		todo.Recurrence = ""
+	}
+
+	if err := s.todoRepo.Update(todo); err != nil {
+		return err
+	}
+
+	if tagsChanged {
+		if err := s.todoRepo.ReplaceTags(todo, todo.Tags); err != nil {
+			return err
+		}
+	}
+
+	if fieldsChanged {
+		if err := s.todoRepo.ReplaceFieldValues(todo); err != nil {
+			return err
+		}
+	}
+
+	if err := s.publish(event.TodoUpdated, todo, settings.loc); err != nil {
+		return err
+	}
+
+	if next != nil {
+		if err := s.placeFirst(next); err != nil {
+			return err
+		}
+		if err := s.todoRepo.Create(next); err != nil {
+			return err
+		}
+		if err := s.publish(event.TodoCreated, next, settings.loc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextOccurrence builds the pending todo following a completed recurring todo.
+// Todos without a due date recur from the current date.
+func nextOccurrence(todo *model.Todo, settings *todoSettings) *model.Todo {
+	recurrence, err := ParseRecurrence(todo.Recurrence)
+	if err != nil {
+		return nil
+	}
+
+	due, allDay := DueCutoff(time.Now(), settings.loc).Today, true
+	if todo.DueDate != nil {
+		due, allDay = *todo.DueDate, todo.DueAllDay
+	}
+	// The anchor keeps the day of month that clamping to a shorter month
+	// would lose from the due date
+	recurrence = recurrence.Anchored(due, allDay, settings.loc)
+	nextDue := recurrence.Next(due, allDay, settings.loc)
+
+	return &model.Todo{
+		Title:           todo.Title,
+		Description:     todo.Description,
+		Status:          settings.workflow.Initial,
+		Priority:        todo.Priority,
+		DueDate:         &nextDue,
+		DueAllDay:       allDay,
+		Recurrence:      recurrence.String(),
+		Tags:            todo.Tags,
+		UserID:          todo.UserID,
+		EstimateMinutes: todo.EstimateMinutes,
+	}
+}
+
+// checkFieldUsers returns ErrInvalidCustomField when a user field of the todo
+// refers to a user that does not exist
+func (s *TodoService) checkFieldUsers(todo *model.Todo) error {
+	for _, value := range todo.FieldValues {
+		if value.Field == nil || value.Field.Type != model.CustomFieldUser || value.NumberValue == nil {
+			continue
+		}
+		exists, err := s.userRepo.ExistsByID(uint(*value.NumberValue))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: user %d of %s does not exist", ErrInvalidCustomField, uint(*value.NumberValue), value.Field.Key)
+		}
+	}
+	return nil
+}
+
+// QuickAddTodo parses a quick-add line and creates the todo. With dryRun the
+// parsed todo is only validated and nothing is saved.
+func (s *TodoService) QuickAddTodo(userID uint, text string, dryRun bool) (*QuickAdd, *model.Todo, error) {
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := ParseQuickAdd(text, time.Now(), settings.loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := dto.CreateTodoRequest{
+		Title:      parsed.Title,
+		Priority:   parsed.Priority,
+		Tags:       parsed.Tags,
+		Recurrence: parsed.Recurrence,
+	}
+	if parsed.DueDate != nil {
+		req.DueDate = parsed.DueDate.Format(time.RFC3339)
+		if parsed.DueAllDay {
+			req.DueDate = parsed.DueDate.Format("2006-01-02")
+		}
+	}
+
+	if dryRun {
+		todo, err := newTodoFromRequest(userID, req, settings)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed.Tags = tagNames(todo.Tags)
+		return parsed, nil, nil
+	}
+
+	todo, err := s.createTodoInTransaction(userID, req, settings)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed.Tags = tagNames(todo.Tags)
+
+	return parsed, todo, nil
+}
+
+// DeleteTodo deletes a todo with authorization check
+func (s *TodoService) DeleteTodo(todoID, userID uint) error {
+	return s.inTransaction(func(txService *TodoService) error {
+		return txService.deleteTodo(todoID, userID)
+	})
+}
+
+// deleteTodo deletes a todo of a user
+func (s *TodoService) deleteTodo(todoID, userID uint) error {
+	// Check if todo exists and user owns it
+	_, err := s.GetTodoByID(todoID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.todoRepo.Delete(todoID); err != nil {
+		return err
+	}
+	return s.publishDeleted(userID, todoID)
+}
+
+// newTodoFromRequest validates a create request and builds the todo model.
+// An empty status starts the todo in the workflow's initial status.
+func newTodoFromRequest(userID uint, req dto.CreateTodoRequest, settings *todoSettings) (*model.Todo, error) {
+	// Validate title
+	if !isValidTitle(req.Title) {
+		return nil, ErrInvalidTitle
+	}
+
+	// Validate status
+	status := req.Status
+	if status == "" {
+		status = settings.workflow.Initial
+	}
+	if !settings.workflow.HasStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+
+	// Validate priority
+	if !isValidPriority(req.Priority) {
+		return nil, ErrInvalidPriority
+	}
+
+	// Parse due date if provided
+	var dueDate *time.Time
+	dueAllDay := false
+	if req.DueDate != "" {
+		parsedDate, allDay, err := ParseDueDate(req.DueDate, settings.loc)
+		if err != nil {
+			return nil, err
+		}
+		dueDate = &parsedDate
+		dueAllDay = allDay
+	}
+
+	tags, err := newTags(userID, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence, err := normalizeRecurrence(req.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EstimateMinutes != nil && !isValidEstimate(*req.EstimateMinutes) {
+		return nil, ErrInvalidEstimate
+	}
+
+	todo := &model.Todo{
+		Title:           req.Title,
+		Description:     req.Description,
+		Status:          status,
+		Priority:        req.Priority,
+		DueDate:         dueDate,
+		DueAllDay:       dueAllDay,
+		Recurrence:      recurrence,
+		Tags:            tags,
+		UserID:          userID,
+		EstimateMinutes: req.EstimateMinutes,
+	}
+	settings.workflow.stampStatus(todo, time.Now())
+
+	if err := applyFieldValues(todo, req.CustomFields, settings.fields, true); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// applyTodoUpdate copies the provided fields of req onto todo after validating them
+func applyTodoUpdate(todo *model.Todo, req dto.UpdateTodoRequest, settings *todoSettings) error {
 	if req.Title != nil {
+		if !isValidTitle(*req.Title) {
+			return ErrInvalidTitle
+		}
 		todo.Title = *req.Title
 	}
 
@@ -121,16 +762,25 @@ func (s *TodoService) UpdateTodo(todoID, userID uint, req dto.UpdateTodoRequest)
 		todo.Description = *req.Description
 	}
 
-	if req.Status != nil {
-		if !isValidStatus(*req.Status) {
-			return nil, ErrInvalidStatus
+	if req.Status != nil && *req.Status != todo.Status {
+		if !settings.workflow.HasStatus(*req.Status) {
+			return ErrInvalidStatus
+		}
+		if !settings.workflow.CanTransition(todo.Status, *req.Status) {
+			return ErrInvalidTransition
+		}
+		if todo.Blocked && settings.workflow.EnforceDependencies &&
+			settings.workflow.Category(*req.Status) == StatusCategoryInProgress &&
+			settings.workflow.Category(todo.Status) != StatusCategoryInProgress {
+			return ErrTodoBlocked
 		}
 		todo.Status = *req.Status
+		settings.workflow.stampStatus(todo, time.Now())
 	}
 
 	if req.Priority != nil {
 		if !isValidPriority(*req.Priority) {
-			return nil, ErrInvalidPriority
+			return ErrInvalidPriority
 		}
 		todo.Priority = *req.Priority
 	}
@@ -138,42 +788,107 @@ func (s *TodoService) UpdateTodo(todoID, userID uint, req dto.UpdateTodoRequest)
 	if req.DueDate != nil {
 		if *req.DueDate == "" {
 			todo.DueDate = nil
+			todo.DueAllDay = false
 		} else {
-			parsedDate, err := time.Parse("2006-01-02", *req.DueDate)
+			parsedDate, allDay, err := ParseDueDate(*req.DueDate, settings.loc)
 			if err != nil {
-				return nil, errors.New("invalid date format, use YYYY-MM-DD")
+				return err
 			}
 			todo.DueDate = &parsedDate
+			todo.DueAllDay = allDay
 		}
 	}
 
-	if err := s.todoRepo.Update(todo); err != nil {
-		return nil, err
+	if req.Tags != nil {
+		tags, err := newTags(todo.UserID, *req.Tags)
+		if err != nil {
+			return err
+		}
+		todo.Tags = tags
 	}
 
-	return todo, nil
+	if req.Recurrence != nil {
+		recurrence, err := normalizeRecurrence(*req.Recurrence)
+		if err != nil {
+			return err
+		}
+		todo.Recurrence = recurrence
+	}
+
+	if req.EstimateMinutes != nil {
+		switch {
+		case *req.EstimateMinutes == 0:
+			todo.EstimateMinutes = nil
+		case isValidEstimate(*req.EstimateMinutes):
+			estimate := *req.EstimateMinutes
+			todo.EstimateMinutes = &estimate
+		default:
+			return ErrInvalidEstimate
+		}
+	}
+
+	if req.CustomFields != nil {
+		if err := applyFieldValues(todo, req.CustomFields, settings.fields, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// DeleteTodo deletes a todo with authorization check
-func (s *TodoService) DeleteTodo(todoID, userID uint) error {
-	// Check if todo exists and user owns it
-	_, err := s.GetTodoByID(todoID, userID)
-	if err != nil {
-		return err
+// newTags validates tag names and builds unsaved tags. Names are lower-cased,
+// a leading # is dropped and duplicates are removed.
+func newTags(userID uint, names []string) ([]model.Tag, error) {
+	if len(names) > 20 {
+		return nil, ErrInvalidTag
 	}
 
-	return s.todoRepo.Delete(todoID)
+	tags := make([]model.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+		if name == "" || utf8.RuneCountInString(name) > 50 || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			return nil, ErrInvalidTag
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, model.Tag{UserID: userID, Name: name})
+	}
+
+	return tags, nil
 }
 
-// Helper functions for validation
+// tagNames returns the names of tags
+func tagNames(tags []model.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
 
-func isValidStatus(status string) bool {
-	validStatuses := map[string]bool{
-		"pending":     true,
-		"in_progress": true,
-		"completed":   true,
+// normalizeRecurrence validates an RRULE and returns its canonical form
+func normalizeRecurrence(rule string) (string, error) {
+	if strings.TrimSpace(rule) == "" {
+		return "", nil
+	}
+	recurrence, err := ParseRecurrence(rule)
+	if err != nil {
+		return "", err
 	}
-	return validStatuses[status]
+	return recurrence.String(), nil
+}
+
+// Helper functions for validation
+
+func isValidTitle(title string) bool {
+	return title != "" && utf8.RuneCountInString(title) <= 200
+}
+
+func isValidEstimate(minutes int) bool {
+	return minutes >= 1 && minutes <= 100000
 }
 
 func isValidPriority(priority string) bool {
@@ -184,3 +899,49 @@ func isValidPriority(priority string) bool {
 	}
 	return validPriorities[priority]
 }
+
+// ToTodoResponse converts a todo model to its response DTO, rendering
+// due dates in the user's time zone
+func ToTodoResponse(todo *model.Todo, loc *time.Location) dto.TodoResponse {
+	response := dto.TodoResponse{
+		ID:              todo.ID,
+		Title:           todo.Title,
+		Description:     todo.Description,
+		Status:          todo.Status,
+		Priority:        todo.Priority,
+		Tags:            make([]string, len(todo.Tags)),
+		Recurrence:      todo.Recurrence,
+		EstimateMinutes: todo.EstimateMinutes,
+		Position:        todo.Position,
+		StartedAt:       todo.StartedAt,
+		CompletedAt:     todo.CompletedAt,
+		Blocked:         todo.Blocked,
+		UserID:          todo.UserID,
+		CreatedAt:       todo.CreatedAt,
+		UpdatedAt:       todo.UpdatedAt,
+	}
+
+	for i, tag := range todo.Tags {
+		response.Tags[i] = tag.Name
+	}
+
+	if len(todo.FieldValues) > 0 {
+		response.CustomFields = make(map[string]interface{}, len(todo.FieldValues))
+		for i := range todo.FieldValues {
+			if field := todo.FieldValues[i].Field; field != nil {
+				response.CustomFields[field.Key] = FieldValueJSON(&todo.FieldValues[i])
+			}
+		}
+	}
+
+	if todo.DueDate != nil {
+		now := time.Now()
+		dueDate := DueDateIn(todo, loc)
+		response.DueDate = &dueDate
+		response.DueAllDay = todo.DueAllDay
+		response.DueToday = IsDueToday(todo, now, loc)
+		response.Overdue = IsOverdue(todo, now, loc)
+	}
+
+	return response
+}
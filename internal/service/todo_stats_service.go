@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"rest-api/internal/repository"
+)
+
+// maxStatsDays limits the range of the stats, which hold one entry per day
+const maxStatsDays = 366
+
+// ErrInvalidStatsRange is returned when the stats range is malformed or too long
+var ErrInvalidStatsRange = errors.New("invalid range, use YYYY-MM-DD dates at most 366 days apart")
+
+// TodoStats is the productivity overview of a user. The counts by status and
+// priority and the overdue count describe the todos as they are now,
+// completion, cycle time and the daily series the date range, and streaks the
+// whole history.
+type TodoStats struct {
+	From            string
+	To              string
+	Total           int64
+	ByStatus        map[string]int64
+	ByPriority      map[string]int64
+	Overdue         int64
+	Completion      repository.CompletionTotals
+	CycleTime       repository.CycleTime
+	CompletedPerDay []repository.DayCount
+	// CurrentStreak ends today or yesterday, nil when the user has no ongoing streak
+	CurrentStreak *repository.Streak
+	LongestStreak *repository.Streak
+}
+
+// GetStats computes the stats of a user over an inclusive YYYY-MM-DD range in
+// the user's time zone. The range defaults to the current week, a missing
+// bound to seven days from the other one.
+func (s *TodoService) GetStats(userID uint, from, to string) (*TodoStats, error) {
+	settings, err := s.settings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	first, last, err := statsRange(from, to, now.In(settings.loc))
+	if err != nil {
+		return nil, err
+	}
+	start, end := first.UTC(), last.AddDate(0, 0, 1).UTC()
+	stats := &TodoStats{
+		From:       first.Format("2006-01-02"),
+		To:         last.Format("2006-01-02"),
+		ByStatus:   make(map[string]int64),
+		ByPriority: map[string]int64{"low": 0, "medium": 0, "high": 0},
+	}
+
+	for _, status := range settings.workflow.Statuses {
+		stats.ByStatus[status.Key] = 0
+	}
+	byStatus, err := s.todoRepo.CountGroupedBy(userID, "status")
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range byStatus {
+		stats.ByStatus[group.Value] = group.Count
+		stats.Total += group.Count
+	}
+	byPriority, err := s.todoRepo.CountGroupedBy(userID, "priority")
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range byPriority {
+		stats.ByPriority[group.Value] = group.Count
+	}
+
+	overdue := repository.TodoFilter{Overdue: true, Cutoff: DueCutoff(now, settings.loc)}
+	if stats.Overdue, err = s.todoRepo.CountByFilter(userID, overdue); err != nil {
+		return nil, err
+	}
+	if stats.Completion, err = s.todoRepo.CountCompletion(userID, start, end); err != nil {
+		return nil, err
+	}
+	if stats.CycleTime, err = s.todoRepo.MeasureCycleTime(userID, start, end); err != nil {
+		return nil, err
+	}
+
+	timeZone := settings.loc.String()
+	stats.CompletedPerDay, err = s.todoRepo.CountCompletedPerDay(userID, timeZone, stats.From, stats.To, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.LongestStreak, err = s.todoRepo.FindCompletionStreak(userID, timeZone, true); err != nil {
+		return nil, err
+	}
+	latest, err := s.todoRepo.FindCompletionStreak(userID, timeZone, false)
+	if err != nil {
+		return nil, err
+	}
+	// A streak is still ongoing until a day passes without completions
+	yesterday := now.In(settings.loc).AddDate(0, 0, -1).Format("2006-01-02")
+	if latest != nil && latest.EndDay >= yesterday {
+		stats.CurrentStreak = latest
+	}
+
+	return stats, nil
+}
+
+// statsRange parses the bounds of a stats range, given as YYYY-MM-DD days,
+// into midnights in the time zone of now
+func statsRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	if from == "" && to == "" {
+		monday := startOfWeek(now)
+		return monday, monday.AddDate(0, 0, 6), nil
+	}
+
+	var first, last time.Time
+	var err error
+	if from != "" {
+		if first, err = time.ParseInLocation("2006-01-02", from, now.Location()); err != nil {
+			return first, last, ErrInvalidStatsRange
+		}
+	}
+	if to != "" {
+		if last, err = time.ParseInLocation("2006-01-02", to, now.Location()); err != nil {
+			return first, last, ErrInvalidStatsRange
+		}
+	}
+	if from == "" {
+		first = last.AddDate(0, 0, -6)
+	}
+	if to == "" {
+		last = first.AddDate(0, 0, 6)
+	}
+
+	if last.Before(first) || !last.Before(first.AddDate(0, 0, maxStatsDays)) {
+		return first, last, ErrInvalidStatsRange
+	}
+	return first, last, nil
+}
@@ -0,0 +1,600 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/event"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+const (
+	// maxWebhooks limits the number of webhooks of a user
+	maxWebhooks = 20
+	// webhookMaxAttempts is the number of attempts before a delivery is dead
+	webhookMaxAttempts = 10
+	// webhookRetryBase is the wait after the first failed attempt, doubling
+	// after each further one up to webhookRetryMax
+	webhookRetryBase = time.Minute
+	webhookRetryMax  = 6 * time.Hour
+	// webhookTimeout bounds an attempt, including reading the response
+	webhookTimeout = 10 * time.Second
+	// webhookLease is how long a claimed delivery is left to its worker
+	// before another worker may attempt it
+	webhookLease = time.Minute
+	// webhookBatchSize is the number of deliveries claimed at once
+	webhookBatchSize = 20
+	// webhookPollInterval is how often the worker looks for retries due
+	webhookPollInterval = 5 * time.Second
+	// webhookResponseLimit is the number of response bytes kept in the log
+	webhookResponseLimit = 1024
+	// webhookLogSize is the number of deliveries listed per webhook
+	webhookLogSize = 100
+)
+
+// WebhookEventPing is the event of test deliveries
+const WebhookEventPing = "ping"
+
+// webhookEvents are the events webhooks can subscribe to
+var webhookEvents = map[string]bool{
+	event.TodoCreated: true,
+	event.TodoUpdated: true,
+	event.TodoDeleted: true,
+}
+
+var (
+	// ErrWebhookNotFound is returned when a webhook is not found
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a webhook delivery is not found
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidWebhook is returned when a webhook is invalid
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrWebhookURLNotAllowed is returned when a webhook URL points to a private address
+	ErrWebhookURLNotAllowed = errors.New("webhook URL must not point to a loopback, private or link-local address")
+)
+
+// WebhookService handles webhooks and delivers todo events to them
+type WebhookService struct {
+	webhookRepo  *repository.WebhookRepository
+	todoService  *TodoService
+	client       *http.Client
+	allowPrivate bool
+	wake         chan struct{}
+}
+
+// NewWebhookService creates a new webhook service instance. Unless
+// allowPrivate is set, webhooks cannot reach loopback, private or
+// link-local addresses, so they cannot call services inside the network.
+func NewWebhookService(webhookRepo *repository.WebhookRepository, todoService *TodoService, allowPrivate bool) *WebhookService {
+	return &WebhookService{
+		webhookRepo:  webhookRepo,
+		todoService:  todoService,
+		client:       NewWebhookClient(allowPrivate),
+		allowPrivate: allowPrivate,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// ListWebhooks returns the webhooks of a user
+func (s *WebhookService) ListWebhooks(userID uint) ([]model.Webhook, error) {
+	return s.webhookRepo.FindByUserID(userID)
+}
+
+// GetWebhook returns a webhook of a user
+func (s *WebhookService) GetWebhook(webhookID, userID uint) (*model.Webhook, error) {
+	webhook, err := s.webhookRepo.FindByIDAndUserID(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// CreateWebhook registers a webhook for a user. Without a secret one is
+// generated.
+func (s *WebhookService) CreateWebhook(userID uint, req dto.CreateWebhookRequest) (*model.Webhook, error) {
+	count, err := s.webhookRepo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebhooks {
+		return nil, fmt.Errorf("%w: at most %d webhooks are allowed", ErrInvalidWebhook, maxWebhooks)
+	}
+
+	webhook := &model.Webhook{UserID: userID, Secret: req.Secret, Active: true}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	if err := s.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	webhook.URL = req.URL
+	if err := setWebhookEvents(webhook, req.Events); err != nil {
+		return nil, err
+	}
+	if webhook.Secret == "" {
+		if webhook.Secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook changes the URL, events, secret or state of a webhook
+func (s *WebhookService) UpdateWebhook(webhookID, userID uint, req dto.UpdateWebhookRequest) (*model.Webhook, error) {
+	webhook, err := s.GetWebhook(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := s.checkURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		if err := setWebhookEvents(webhook, *req.Events); err != nil {
+			return nil, err
+		}
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook with its delivery log
+func (s *WebhookService) DeleteWebhook(webhookID, userID uint) error {
+	webhook, err := s.GetWebhook(webhookID, userID)
+	if err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(webhook)
+}
+
+// ListDeliveries returns the latest deliveries of a webhook, newest first
+func (s *WebhookService) ListDeliveries(webhookID, userID uint) ([]model.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(webhookID, userID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.FindDeliveriesByWebhookID(webhookID, webhookLogSize)
+}
+
+// Redeliver queues a delivery again with the same event ID and payload, so
+// receivers can recognize an event they already processed
+func (s *WebhookService) Redeliver(webhookID, deliveryID, userID uint) (*model.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(webhookID, userID); err != nil {
+		return nil, err
+	}
+	previous, err := s.webhookRepo.FindDeliveryByID(deliveryID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now().UTC()
+	delivery := &model.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       previous.EventID,
+		Event:         previous.Event,
+		Payload:       previous.Payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+	if err := s.webhookRepo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	s.notify()
+	return delivery, nil
+}
+
+// Ping sends a ping event to a webhook right away and returns its logged
+// delivery. Failed pings are not retried.
+func (s *WebhookService) Ping(webhookID, userID uint) (*model.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	eventID := event.NewID(now)
+	payload, err := json.Marshal(dto.WebhookPayload{
+		ID:        eventID,
+		Type:      WebhookEventPing,
+		CreatedAt: now,
+		Data:      dto.WebhookPingData{WebhookID: webhook.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The lease keeps the worker away while the ping is sent here
+	leaseUntil := now.Add(webhookLease)
+	delivery := &model.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       eventID,
+		Event:         WebhookEventPing,
+		Payload:       string(payload),
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: &leaseUntil,
+	}
+	if err := s.webhookRepo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	s.attempt(webhook, delivery, now)
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Enqueue queues a delivery of a todo event for every active webhook of
+// its user subscribed to it. The payload holds todo, the todo as the change
+// left it, and stays the same for every attempt. Webhooks that already have
+// a delivery of the event are skipped, so an event relayed again is not
+// queued twice.
+func (s *WebhookService) Enqueue(e event.Event, todo *dto.TodoResponse) error {
+	webhooks, err := s.webhookRepo.FindActiveByUserID(e.UserID)
+	if err != nil {
+		return err
+	}
+	var subscribed []model.Webhook
+	for _, webhook := range webhooks {
+		if !webhookSubscribed(&webhook, e.Type) {
+			continue
+		}
+		queued, err := s.webhookRepo.HasDelivery(webhook.ID, e.ID)
+		if err != nil {
+			return err
+		}
+		if !queued {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	data := dto.WebhookTodoData{TodoID: e.TodoID, Todo: todo}
+	if todo == nil && e.Type != event.TodoDeleted {
+		// Outbox messages written before todos were kept in them: the
+		// current todo is the best left
+		current, err := s.todoService.GetTodoByID(e.TodoID, e.UserID)
+		if err != nil && !errors.Is(err, ErrTodoNotFound) {
+			return err
+		}
+		if current != nil {
+			response := ToTodoResponse(current, s.todoService.UserLocation(e.UserID))
+			data.Todo = &response
+		}
+	}
+	payload, err := json.Marshal(dto.WebhookPayload{ID: e.ID, Type: e.Type, CreatedAt: e.At, Data: data})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, webhook := range subscribed {
+		err := s.webhookRepo.CreateDelivery(&model.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       e.ID,
+			Event:         e.Type,
+			Payload:       string(payload),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	s.notify()
+	return nil
+}
+
+// notify wakes the worker up for new deliveries
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers queued deliveries until ctx is done. It wakes up for new
+// deliveries queued in this process and polls for retries and deliveries
+// queued by other replicas.
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := s.DeliverDue(time.Now().UTC())
+			if err != nil {
+				log.Printf("Failed to deliver webhooks: %v", err)
+			}
+			if err != nil || n < webhookBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// DeliverDue attempts a batch of the deliveries due at now and returns
+// their number
+func (s *WebhookService) DeliverDue(now time.Time) (int, error) {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(now, now.Add(webhookLease), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uint]*model.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = s.webhookRepo.FindByID(delivery.WebhookID); err != nil {
+				return i, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		s.attempt(webhook, delivery, now)
+		if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt sends a delivery once and records the outcome. Failed deliveries
+// are retried with exponential backoff until they run out of attempts and
+// are dead; redelivering them starts over.
+func (s *WebhookService) attempt(webhook *model.Webhook, delivery *model.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	var err error
+	switch {
+	case webhook == nil:
+		err = ErrWebhookNotFound
+	case !webhook.Active && delivery.Event != WebhookEventPing:
+		err = errors.New("webhook is inactive")
+	default:
+		err = s.send(webhook, delivery, now)
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = model.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case webhook == nil || !webhook.Active || delivery.Event == WebhookEventPing || delivery.Attempts >= webhookMaxAttempts:
+		delivery.Error = err.Error()
+		delivery.Status = model.WebhookDeliveryDead
+		delivery.NextAttemptAt = nil
+	default:
+		delivery.Error = err.Error()
+		next := now.Add(WebhookRetryDelay(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+}
+
+// send posts the payload of a delivery with its signature headers. Any
+// response other than 2xx, redirects included, is a failure.
+func (s *WebhookService) send(webhook *model.Webhook, delivery *model.WebhookDelivery, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-webhooks/1.0")
+	req.Header.Set("X-Webhook-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", SignWebhook(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+	response, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	delivery.ResponseBody = string(bytes.ToValidUTF8(response, nil))
+
+	if status < 200 || status > 299 {
+		return fmt.Errorf("webhook responded with status %d", status)
+	}
+	return nil
+}
+
+// SignWebhook returns the X-Webhook-Signature of a payload sent at
+// timestamp: sha256= and the hex HMAC-SHA256 of "<timestamp>.<payload>"
+// keyed with the webhook secret. Receivers compute the same value and
+// reject old timestamps to stop replays.
+func SignWebhook(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookRetryDelay returns the wait before retrying a delivery that failed
+// attempts times: a minute after the first failure, doubling up to 6 hours
+func WebhookRetryDelay(attempts int) time.Duration {
+	return retryBackoff(attempts, webhookRetryBase, webhookRetryMax)
+}
+
+// retryBackoff returns the wait after a number of failed attempts: base
+// after the first, doubling after each further one up to max
+func retryBackoff(attempts int, base, max time.Duration) time.Duration {
+	wait := base
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
+
+// webhookSubscribed reports whether a webhook is subscribed to an event type
+func webhookSubscribed(webhook *model.Webhook, eventType string) bool {
+	for _, name := range WebhookEvents(webhook) {
+		if name == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvents returns the event types a webhook is subscribed to
+func WebhookEvents(webhook *model.Webhook) []string {
+	var events []string
+	if err := json.Unmarshal([]byte(webhook.Events), &events); err != nil {
+		return nil
+	}
+	return events
+}
+
+// setWebhookEvents validates and stores the events of a webhook
+func setWebhookEvents(webhook *model.Webhook, events []string) error {
+	seen := make(map[string]bool, len(events))
+	unique := make([]string, 0, len(events))
+	for _, name := range events {
+		if !webhookEvents[name] {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	if len(unique) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+
+	encoded, err := json.Marshal(unique)
+	if err != nil {
+		return err
+	}
+	webhook.Events = string(encoded)
+	return nil
+}
+
+// checkURL accepts absolute http and https URLs. Hosts given as private
+// addresses are refused up front; names resolving to them fail when a
+// delivery connects.
+func (s *WebhookService) checkURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if s.allowPrivate {
+		return nil
+	}
+	if parsed.Hostname() == "localhost" {
+		return ErrWebhookURLNotAllowed
+	}
+	if ip := net.ParseIP(parsed.Hostname()); ip != nil && !isPublicIP(ip) {
+		return ErrWebhookURLNotAllowed
+	}
+	return nil
+}
+
+// NewWebhookClient creates the HTTP client of deliveries. Redirects are not
+// followed and, unless private addresses are allowed, connections are
+// checked after DNS resolution and no proxy is used.
+func NewWebhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return ErrWebhookURLNotAllowed
+			}
+			return nil
+		}
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP reports whether an address can be reached by webhooks
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsMulticast()
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
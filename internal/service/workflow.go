@@ -0,0 +1,182 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// Status categories decide how a status behaves: todos enter in_progress
+// statuses when work starts and done statuses when they are finished.
+const (
+	StatusCategoryTodo       = "todo"
+	StatusCategoryInProgress = "in_progress"
+	StatusCategoryDone       = "done"
+)
+
+var (
+	// ErrInvalidTransition is returned when the workflow does not allow a status change
+	ErrInvalidTransition = errors.New("status transition is not allowed by the workflow")
+	// ErrInvalidWorkflow is returned when a workflow definition is inconsistent
+	ErrInvalidWorkflow = errors.New("invalid workflow")
+	// ErrWorkflowStatusInUse is returned when a workflow drops a status that todos still have
+	ErrWorkflowStatusInUse = errors.New("workflow removes a status that is still used by todos")
+	// ErrTodoBlocked is returned when a todo with open blockers is started
+	ErrTodoBlocked = errors.New("todo is blocked by unfinished todos")
+)
+
+// statusKeyPattern limits status keys to what fits the todos.status column
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// WorkflowStatus is one status of a workflow
+type WorkflowStatus struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// Workflow defines the statuses a todo can have and the allowed transitions
+// between them. Transitions maps a status to the statuses it may move to.
+// With EnforceDependencies, todos cannot enter an in_progress status while
+// one of their blockers is open.
+type Workflow struct {
+	Statuses            []WorkflowStatus    `json:"statuses"`
+	Transitions         map[string][]string `json:"transitions"`
+	Initial             string              `json:"initial"`
+	EnforceDependencies bool                `json:"enforce_dependencies,omitempty"`
+}
+
+// DefaultWorkflow returns the built-in workflow where any of pending,
+// in_progress and completed may move to any other
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: []WorkflowStatus{
+			{Key: "pending", Name: "Pending", Category: StatusCategoryTodo},
+			{Key: "in_progress", Name: "In Progress", Category: StatusCategoryInProgress},
+			{Key: "completed", Name: "Completed", Category: StatusCategoryDone},
+		},
+		Transitions: map[string][]string{
+			"pending":     {"in_progress", "completed"},
+			"in_progress": {"pending", "completed"},
+			"completed":   {"pending", "in_progress"},
+		},
+		Initial: "pending",
+	}
+}
+
+// HasStatus reports whether key is a status of the workflow
+func (w *Workflow) HasStatus(key string) bool {
+	return w.Category(key) != ""
+}
+
+// Category returns the category of a status, or "" for unknown statuses
+func (w *Workflow) Category(key string) string {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status.Category
+		}
+	}
+	return ""
+}
+
+// CanTransition reports whether a todo may move from one status to another.
+// Todos in a status the workflow no longer knows may move anywhere.
+func (w *Workflow) CanTransition(from, to string) bool {
+	if from == to || !w.HasStatus(from) {
+		return true
+	}
+	for _, allowed := range w.Transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the workflow is consistent
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("%w: at least one status is required", ErrInvalidWorkflow)
+	}
+
+	seen := make(map[string]bool, len(w.Statuses))
+	hasDone := false
+	for _, status := range w.Statuses {
+		if !statusKeyPattern.MatchString(status.Key) {
+			return fmt.Errorf("%w: status key %q must be lower-case letters, digits or _ and at most 20 characters", ErrInvalidWorkflow, status.Key)
+		}
+		if seen[status.Key] {
+			return fmt.Errorf("%w: duplicate status %q", ErrInvalidWorkflow, status.Key)
+		}
+		seen[status.Key] = true
+
+		switch status.Category {
+		case StatusCategoryTodo, StatusCategoryInProgress:
+		case StatusCategoryDone:
+			hasDone = true
+		default:
+			return fmt.Errorf("%w: status %q has unknown category %q", ErrInvalidWorkflow, status.Key, status.Category)
+		}
+	}
+
+	if !hasDone {
+		return fmt.Errorf("%w: at least one status must be in the done category", ErrInvalidWorkflow)
+	}
+	if !seen[w.Initial] {
+		return fmt.Errorf("%w: initial status %q is not defined", ErrInvalidWorkflow, w.Initial)
+	}
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("%w: transition from unknown status %q", ErrInvalidWorkflow, from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("%w: transition to unknown status %q", ErrInvalidWorkflow, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stampStatus updates the started and completed timestamps of a todo after
+// its status changed
+func (w *Workflow) stampStatus(todo *model.Todo, now time.Time) {
+	switch w.Category(todo.Status) {
+	case StatusCategoryInProgress:
+		if todo.StartedAt == nil {
+			todo.StartedAt = &now
+		}
+		todo.CompletedAt = nil
+	case StatusCategoryDone:
+		if todo.CompletedAt == nil {
+			todo.CompletedAt = &now
+		}
+	default:
+		todo.StartedAt = nil
+		todo.CompletedAt = nil
+	}
+}
+
+// loadWorkflow returns the workflow of a user, the default workflow when the
+// user has none
+func loadWorkflow(repo *repository.WorkflowRepository, userID uint) (*Workflow, *model.Workflow, error) {
+	stored, err := repo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find workflow: %w", err)
+	}
+	if stored == nil {
+		return DefaultWorkflow(), nil, nil
+	}
+
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(stored.Definition), &workflow); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+	return &workflow, stored, nil
+}
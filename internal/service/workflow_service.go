@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+)
+
+// WorkflowService handles the status workflow of a user's workspace
+type WorkflowService struct {
+	workflowRepo *repository.WorkflowRepository
+	todoRepo     *repository.TodoRepository
+}
+
+// NewWorkflowService creates a new workflow service instance
+func NewWorkflowService(workflowRepo *repository.WorkflowRepository, todoRepo *repository.TodoRepository) *WorkflowService {
+	return &WorkflowService{
+		workflowRepo: workflowRepo,
+		todoRepo:     todoRepo,
+	}
+}
+
+// GetWorkflow returns the workflow of a user. The stored row is nil when the
+// user uses the default workflow.
+func (s *WorkflowService) GetWorkflow(userID uint) (*Workflow, *model.Workflow, error) {
+	return loadWorkflow(s.workflowRepo, userID)
+}
+
+// UpdateWorkflow replaces the workflow of a user. Statuses still used by the
+// user's todos cannot be removed.
+func (s *WorkflowService) UpdateWorkflow(userID uint, workflow *Workflow) (*model.Workflow, error) {
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkStatusesInUse(userID, workflow); err != nil {
+		return nil, err
+	}
+
+	definition, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workflow: %w", err)
+	}
+
+	stored, err := s.workflowRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow: %w", err)
+	}
+	if stored == nil {
+		stored = &model.Workflow{UserID: userID}
+	}
+	stored.Definition = string(definition)
+
+	if err := s.workflowRepo.Save(stored); err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+	return stored, nil
+}
+
+// ResetWorkflow switches a user back to the default workflow
+func (s *WorkflowService) ResetWorkflow(userID uint) error {
+	if err := s.checkStatusesInUse(userID, DefaultWorkflow()); err != nil {
+		return err
+	}
+	return s.workflowRepo.DeleteByUserID(userID)
+}
+
+// checkStatusesInUse returns ErrWorkflowStatusInUse when todos of the user
+// have a status the workflow does not define
+func (s *WorkflowService) checkStatusesInUse(userID uint, workflow *Workflow) error {
+	statuses, err := s.todoRepo.FindStatusesByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to find todo statuses: %w", err)
+	}
+
+	var missing []string
+	for _, status := range statuses {
+		if !workflow.HasStatus(status) {
+			missing = append(missing, status)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrWorkflowStatusInUse, strings.Join(missing, ", "))
+	}
+	return nil
+}
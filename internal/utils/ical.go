@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalLineLimit is the maximum line length in octets before folding (RFC 5545 3.1)
+const icalLineLimit = 75
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// ICalendar builds an RFC 5545 iCalendar document
+type ICalendar struct {
+	b strings.Builder
+}
+
+// NewICalendar starts a calendar with the given display name
+func NewICalendar(name string) *ICalendar {
+	c := &ICalendar{}
+	c.Begin("VCALENDAR")
+	c.Property("VERSION", "2.0")
+	c.Property("PRODID", "-//rest-api//Todo Calendar//EN")
+	c.Property("CALSCALE", "GREGORIAN")
+	c.Property("METHOD", "PUBLISH")
+	c.Text("X-WR-CALNAME", name)
+	return c
+}
+
+// Begin opens a component such as VEVENT or VTODO
+func (c *ICalendar) Begin(component string) {
+	c.line("BEGIN:" + component)
+}
+
+// End closes a component
+func (c *ICalendar) End(component string) {
+	c.line("END:" + component)
+}
+
+// Property writes a property whose value is already in iCalendar format
+func (c *ICalendar) Property(name, value string) {
+	c.line(name + ":" + value)
+}
+
+// Text writes a property with a TEXT value, escaping special characters
+func (c *ICalendar) Text(name, value string) {
+	c.line(name + ":" + icalTextEscaper.Replace(value))
+}
+
+// String closes the calendar and returns the document
+func (c *ICalendar) String() string {
+	c.End("VCALENDAR")
+	return c.b.String()
+}
+
+// line writes a content line folded at 75 octets without splitting UTF-8 characters
+func (c *ICalendar) line(content string) {
+	limit := icalLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		c.b.WriteString(content[:cut])
+		c.b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = icalLineLimit - 1
+	}
+	c.b.WriteString(content)
+	c.b.WriteString("\r\n")
+}
+
+// ICalDate formats a DATE value
+func ICalDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// ICalDateTime formats a DATE-TIME value in UTC
+func ICalDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
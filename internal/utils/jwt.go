@@ -27,7 +27,7 @@ func GenerateToken(UserID uint, username string) (string, error) {
 	}
 
 	// buat token dengan claim
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// sign (tandai)
 	cfg := config.LoadConfig()
@@ -0,0 +1,26 @@
+-- Migration: Create idempotency_keys table
+-- Version: 002
+-- Description: Stored responses for requests sent with an Idempotency-Key header
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL DEFAULT 0,
+    key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER,
+    headers TEXT,
+    body BYTEA,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE idempotency_keys IS 'Responses replayed for retried requests with the same Idempotency-Key';
+COMMENT ON COLUMN idempotency_keys.user_id IS 'Owner of the key, 0 for unauthenticated requests';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 of method, path and body of the original request';
+COMMENT ON COLUMN idempotency_keys.status_code IS 'Stored response status, NULL/0 while the request is in progress';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_user_key ON idempotency_keys(user_id, key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,19 @@
+-- Migration: Create calendar_feeds table
+-- Version: 004
+-- Description: Secret tokens of per-user iCalendar feed URLs
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_calendar_feeds_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON TABLE calendar_feeds IS 'iCalendar feed tokens, one per user';
+COMMENT ON COLUMN calendar_feeds.token IS 'Secret token in the feed URL, regenerating it revokes the old URL';
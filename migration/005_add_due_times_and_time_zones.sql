@@ -0,0 +1,34 @@
+-- Migration: Due date-times and user time zones
+-- Version: 005
+-- Description: Store due dates as UTC instants with an all-day flag, and a time zone per user
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+COMMENT ON COLUMN users.time_zone IS 'IANA time zone used to render due dates and compute overdue/today';
+
+-- Existing due dates were plain dates, keep them as all-day at midnight UTC.
+-- Only converted while due_date is still a DATE, so running the migration
+-- again neither shifts the instants nor marks timed todos as all-day.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema()
+          AND table_name = 'todos' AND column_name = 'due_date' AND data_type = 'date'
+    ) THEN
+        ALTER TABLE todos
+            ALTER COLUMN due_date TYPE TIMESTAMPTZ USING (due_date::timestamp AT TIME ZONE 'UTC');
+
+        ALTER TABLE todos
+            ADD COLUMN IF NOT EXISTS due_all_day BOOLEAN NOT NULL DEFAULT false;
+
+        UPDATE todos SET due_all_day = true WHERE due_date IS NOT NULL;
+    END IF;
+END $$;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS due_all_day BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN todos.due_date IS 'Optional due instant in UTC, midnight UTC of the calendar date when all-day';
+COMMENT ON COLUMN todos.due_all_day IS 'True when the todo is due on a date without a time';
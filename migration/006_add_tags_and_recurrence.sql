@@ -0,0 +1,44 @@
+-- Migration: Tags and recurrence
+-- Version: 006
+-- Description: Per-user tags attached to todos, and RRULE recurrence on todos
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_tags_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name ON tags(user_id, name);
+
+COMMENT ON TABLE tags IS 'Labels attached to todos, unique per user';
+COMMENT ON COLUMN tags.name IS 'Lower-case tag name without the leading #';
+
+CREATE TABLE IF NOT EXISTS todo_tags (
+    todo_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+
+    PRIMARY KEY (todo_id, tag_id),
+
+    CONSTRAINT fk_todo_tags_todo
+        FOREIGN KEY (todo_id)
+        REFERENCES todos(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_todo_tags_tag
+        FOREIGN KEY (tag_id)
+        REFERENCES tags(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_tags_tag_id ON todo_tags(tag_id);
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS recurrence VARCHAR(100);
+
+COMMENT ON COLUMN todos.recurrence IS 'RFC 5545 RRULE (FREQ, INTERVAL, BYDAY); completing the todo creates the next occurrence';
@@ -0,0 +1,36 @@
+-- Migration: Configurable status workflows
+-- Version: 007
+-- Description: Per-workspace status workflows, and started/completed timestamps on todos
+
+CREATE TABLE IF NOT EXISTS workflows (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    definition TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_workflows_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+COMMENT ON TABLE workflows IS 'Custom todo status workflow of a workspace, users without a row use pending/in_progress/completed';
+COMMENT ON COLUMN workflows.definition IS 'JSON with statuses (key, name, category todo|in_progress|done), transitions and initial status';
+
+-- Statuses are validated against the workflow by the application
+ALTER TABLE todos DROP CONSTRAINT IF EXISTS check_todos_status;
+
+COMMENT ON COLUMN todos.status IS 'Status key from the workspace workflow';
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN todos.started_at IS 'When the todo entered an in_progress category status';
+COMMENT ON COLUMN todos.completed_at IS 'When the todo entered a done category status, NULL while open';
+
+UPDATE todos SET started_at = updated_at WHERE status = 'in_progress' AND started_at IS NULL;
+UPDATE todos SET completed_at = updated_at WHERE status = 'completed' AND completed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_todos_completed_at ON todos(completed_at);
@@ -0,0 +1,57 @@
+-- Migration: Custom fields on todos
+-- Version: 008
+-- Description: Typed custom field definitions per workspace and their values on todos
+
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    key VARCHAR(30) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options TEXT,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_custom_fields_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT check_custom_fields_type
+        CHECK (type IN ('text', 'number', 'date', 'select', 'multi_select', 'url', 'user'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_fields_user_key ON custom_fields(user_id, key);
+
+COMMENT ON TABLE custom_fields IS 'Typed fields the todos of a workspace can fill in';
+COMMENT ON COLUMN custom_fields.key IS 'Identifier used in custom_fields and cf.<key> list filters';
+COMMENT ON COLUMN custom_fields.options IS 'JSON array of choices for select and multi_select fields';
+
+CREATE TABLE IF NOT EXISTS todo_field_values (
+    id SERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL,
+    field_id INTEGER NOT NULL,
+    text_value TEXT,
+    number_value DOUBLE PRECISION,
+    date_value TIMESTAMPTZ,
+
+    CONSTRAINT fk_todo_field_values_todo
+        FOREIGN KEY (todo_id)
+        REFERENCES todos(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_todo_field_values_field
+        FOREIGN KEY (field_id)
+        REFERENCES custom_fields(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_todo_field_values_todo_field ON todo_field_values(todo_id, field_id);
+CREATE INDEX IF NOT EXISTS idx_todo_field_values_field_id ON todo_field_values(field_id);
+
+COMMENT ON TABLE todo_field_values IS 'Custom field values of todos, one typed column is set per value';
+COMMENT ON COLUMN todo_field_values.text_value IS 'text, url and select values, multi_select as a JSON array';
+COMMENT ON COLUMN todo_field_values.number_value IS 'number values and user IDs';
+COMMENT ON COLUMN todo_field_values.date_value IS 'date values at UTC midnight';
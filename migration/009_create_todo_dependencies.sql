@@ -0,0 +1,35 @@
+-- Migration: Todo dependencies
+-- Version: 009
+-- Description: Blocks / blocked-by links between todos
+
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    todo_id INTEGER NOT NULL,
+    blocked_by_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_todo_dependencies_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_todo_dependencies_todo
+        FOREIGN KEY (todo_id)
+        REFERENCES todos(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_todo_dependencies_blocked_by
+        FOREIGN KEY (blocked_by_id)
+        REFERENCES todos(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT check_todo_dependencies_self
+        CHECK (todo_id <> blocked_by_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_todo_dependencies_pair ON todo_dependencies(todo_id, blocked_by_id);
+CREATE INDEX IF NOT EXISTS idx_todo_dependencies_blocked_by_id ON todo_dependencies(blocked_by_id);
+CREATE INDEX IF NOT EXISTS idx_todo_dependencies_user_id ON todo_dependencies(user_id);
+
+COMMENT ON TABLE todo_dependencies IS 'todo_id cannot start before blocked_by_id is finished, cycles are rejected by the application';
@@ -0,0 +1,19 @@
+-- Migration: Manual todo ordering
+-- Version: 010
+-- Description: Lexicographic position of todos for drag-and-drop ordering
+
+-- Positions are compared byte-wise, independent of the database locale
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS position VARCHAR(64) COLLATE "C";
+
+COMMENT ON COLUMN todos.position IS 'Manual order rank of base-36 digits never ending in 0, a todo moves by taking a rank between its new neighbours';
+
+-- Keep the previous newest-first order: equal-width ranks ending in i
+UPDATE todos t
+SET position = ranked.position
+FROM (
+    SELECT id, lpad(row_number() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC)::text, 10, '0') || 'i' AS position
+    FROM todos
+) ranked
+WHERE t.id = ranked.id AND t.position IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_position ON todos(user_id, position);
@@ -0,0 +1,43 @@
+-- Migration: Time tracking
+-- Version: 011
+-- Description: Effort estimates on todos and time entries from timers or manual input
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER;
+
+COMMENT ON COLUMN todos.estimate_minutes IS 'Planned effort in minutes, NULL when not estimated';
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    todo_id INTEGER NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    duration_seconds BIGINT NOT NULL DEFAULT 0,
+    day VARCHAR(10) NOT NULL,
+    note VARCHAR(200),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_time_entries_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_time_entries_todo
+        FOREIGN KEY (todo_id)
+        REFERENCES todos(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT check_time_entries_duration
+        CHECK (duration_seconds >= 0)
+);
+
+-- At most one running timer per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries(user_id) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_id ON time_entries(user_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_todo_id ON time_entries(todo_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_day ON time_entries(day);
+
+COMMENT ON TABLE time_entries IS 'Time spent on todos, a row without ended_at is a running timer';
+COMMENT ON COLUMN time_entries.duration_seconds IS 'Tracked seconds, set when the timer stops';
+COMMENT ON COLUMN time_entries.day IS 'YYYY-MM-DD the entry started on in the user time zone, used for daily totals';
@@ -0,0 +1,22 @@
+-- Migration: Saved views
+-- Version: 012
+-- Description: Named todo list filters evaluated server-side
+
+CREATE TABLE IF NOT EXISTS saved_views (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_saved_views_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_views_user_name ON saved_views(user_id, name);
+
+COMMENT ON TABLE saved_views IS 'Saved todo list filters, the built-in Today, Upcoming and Overdue views are not stored';
+COMMENT ON COLUMN saved_views.filter IS 'JSON encoded list query: status, priority, tags, due, q, open, sort and custom_fields';
@@ -0,0 +1,23 @@
+-- Migration: Todo templates
+-- Version: 013
+-- Description: Reusable checklists of todos with placeholders and relative due dates
+
+CREATE TABLE IF NOT EXISTS todo_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    items TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_todo_templates_user
+        FOREIGN KEY (user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_todo_templates_user_name ON todo_templates(user_id, name);
+
+COMMENT ON TABLE todo_templates IS 'Todo checklists created together by POST /api/v1/templates/:id/instantiate';
+COMMENT ON COLUMN todo_templates.items IS 'JSON encoded items: title and description with {{placeholders}}, priority, tags, due_offset_days, due_time, recurrence and estimate_minutes';
@@ -0,0 +1,8 @@
+-- Migration: User locales
+-- Version: 014
+-- Description: Store the preferred language of response messages per user
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN users.locale IS 'Language of response messages (en or id), empty to follow Accept-Language';
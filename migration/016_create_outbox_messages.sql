@@ -0,0 +1,31 @@
+-- Migration: Outbox messages
+-- Version: 016
+-- Description: Transactional outbox for emails and todo events, published by a relay worker
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id SERIAL PRIMARY KEY,
+    topic VARCHAR(50) NOT NULL,
+    dedup_key VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    error TEXT,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_outbox_messages_status
+        CHECK (status IN ('pending', 'published', 'dead'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_dedup_key ON outbox_messages(dedup_key);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_due ON outbox_messages(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages(published_at);
+
+-- Webhooks look up the deliveries of an event to skip events relayed again
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
+
+COMMENT ON COLUMN outbox_messages.topic IS 'Kind of side effect: todo.event or email.password_reset';
+COMMENT ON COLUMN outbox_messages.dedup_key IS 'Identifies the side effect; a message with a key already in the outbox is not written again';
+COMMENT ON COLUMN outbox_messages.status IS 'pending until published or out of attempts (dead)';
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"rest-api/internal/config"
 	"rest-api/internal/model"
@@ -78,6 +79,7 @@ func main() {
 	db.Where("username = ?", "admin").First(&adminUser)
 	db.Where("username = ?", "aditya_prayoga").First(&adityaUser)
 
+	completedAt := time.Now()
 	todos := []model.Todo{
 		{
 			Title:       "Complete project documentation",
@@ -98,6 +100,7 @@ func main() {
 			Description: "Resolve token expiration issue",
 			Status:      "completed",
 			Priority:    "high",
+			CompletedAt: &completedAt,
 			UserID:      adminUser.ID,
 		},
 		{
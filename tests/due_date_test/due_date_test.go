@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"rest-api/internal/model"
+	"rest-api/internal/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func utc(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func date(value string) *time.Time {
+	t := utc(value)
+	return &t
+}
+
+func TestParseDueDate(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		value  string
+		loc    *time.Location
+		want   time.Time
+		allDay bool
+	}{
+		// Dates are all-day at midnight UTC whatever the user's zone
+		{"2026-03-09", jakarta, utc("2026-03-09T00:00:00Z"), true},
+		{" 2026-03-09 ", newYork, utc("2026-03-09T00:00:00Z"), true},
+		// Offsets win over the user's zone
+		{"2026-03-09T10:00:00+07:00", newYork, utc("2026-03-09T03:00:00Z"), false},
+		{"2026-03-09T10:00:00Z", jakarta, utc("2026-03-09T10:00:00Z"), false},
+		// Local date-times are read in the user's zone
+		{"2026-03-09T10:00", jakarta, utc("2026-03-09T03:00:00Z"), false},
+		{"2026-03-09 10:00:30", jakarta, utc("2026-03-09T03:00:30Z"), false},
+		{"2026-03-09T10:00", time.UTC, utc("2026-03-09T10:00:00Z"), false},
+		// Around the DST changes of New York: EST is -5, EDT -4
+		{"2026-03-07T12:00", newYork, utc("2026-03-07T17:00:00Z"), false},
+		{"2026-03-08T12:00", newYork, utc("2026-03-08T16:00:00Z"), false},
+		{"2026-03-08T01:59", newYork, utc("2026-03-08T06:59:00Z"), false},
+		{"2026-03-08T03:00", newYork, utc("2026-03-08T07:00:00Z"), false},
+	}
+
+	for _, tt := range tests {
+		got, allDay, err := service.ParseDueDate(tt.value, tt.loc)
+		require.NoError(t, err, tt.value)
+		assert.True(t, tt.want.Equal(got), "%s in %s: got %s", tt.value, tt.loc, got)
+		assert.Equal(t, time.UTC, got.Location(), tt.value)
+		assert.Equal(t, tt.allDay, allDay, tt.value)
+	}
+
+	for _, value := range []string{"", "tomorrow", "2026-02-30", "09/03/2026", "2026-03-09T25:00"} {
+		_, _, err := service.ParseDueDate(value, jakarta)
+		assert.Equal(t, service.ErrInvalidDueDate, err, value)
+	}
+}
+
+func TestDueCutoff(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+
+	tests := []struct {
+		now   time.Time
+		loc   *time.Location
+		today time.Time
+	}{
+		{utc("2026-03-09T16:59:59Z"), jakarta, utc("2026-03-09T00:00:00Z")},
+		// Local midnight in Jakarta is 17:00 UTC of the day before
+		{utc("2026-03-09T17:00:00Z"), jakarta, utc("2026-03-10T00:00:00Z")},
+		{utc("2026-03-09T17:00:00Z"), time.UTC, utc("2026-03-09T00:00:00Z")},
+	}
+
+	for _, tt := range tests {
+		cutoff := service.DueCutoff(tt.now, tt.loc)
+		assert.Equal(t, tt.now, cutoff.Now, tt.now.String())
+		assert.Equal(t, tt.today, cutoff.Today, tt.now.String())
+	}
+}
+
+func TestIsOverdueAndDueToday(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	newYork := mustLoad(t, "America/New_York")
+	completed := utc("2026-03-09T12:00:00Z")
+
+	allDay := &model.Todo{DueDate: date("2026-03-09T00:00:00Z"), DueAllDay: true}
+	timed := &model.Todo{DueDate: date("2026-03-09T18:00:00Z")}
+	// Due on the day New York springs forward, which has 23 hours
+	dstDay := &model.Todo{DueDate: date("2026-03-08T00:00:00Z"), DueAllDay: true}
+
+	tests := []struct {
+		name     string
+		todo     *model.Todo
+		now      string
+		loc      *time.Location
+		overdue  bool
+		dueToday bool
+	}{
+		{"all-day before local midnight", allDay, "2026-03-09T16:59:59Z", jakarta, false, true},
+		{"all-day at local midnight", allDay, "2026-03-09T17:00:00Z", jakarta, true, false},
+		{"all-day in UTC", allDay, "2026-03-09T17:00:00Z", time.UTC, false, true},
+		{"all-day the day before", allDay, "2026-03-08T16:59:59Z", jakarta, false, false},
+		// 18:00 UTC is 01:00 on March 10 in Jakarta
+		{"timed before", timed, "2026-03-09T17:59:59Z", jakarta, false, true},
+		{"timed after", timed, "2026-03-09T18:00:01Z", jakarta, true, true},
+		{"timed on the UTC date", timed, "2026-03-09T16:00:00Z", jakarta, false, false},
+		{"timed in UTC", timed, "2026-03-09T16:00:00Z", time.UTC, false, true},
+		{"DST day before local midnight", dstDay, "2026-03-09T03:59:59Z", newYork, false, true},
+		{"DST day at local midnight", dstDay, "2026-03-09T04:00:00Z", newYork, true, false},
+		{"DST day start", dstDay, "2026-03-08T05:00:00Z", newYork, false, true},
+		{"completed", &model.Todo{DueDate: date("2026-03-01T00:00:00Z"), DueAllDay: true, CompletedAt: &completed}, "2026-03-09T00:00:00Z", jakarta, false, false},
+		{"no due date", &model.Todo{}, "2026-03-09T00:00:00Z", jakarta, false, false},
+	}
+
+	for _, tt := range tests {
+		now := utc(tt.now)
+		assert.Equal(t, tt.overdue, service.IsOverdue(tt.todo, now, tt.loc), "%s: overdue", tt.name)
+		assert.Equal(t, tt.dueToday, service.IsDueToday(tt.todo, now, tt.loc), "%s: due today", tt.name)
+	}
+}
+
+func TestDueDateIn(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		todo *model.Todo
+		loc  *time.Location
+		want string
+	}{
+		// All-day dates keep their calendar date in every zone
+		{&model.Todo{DueDate: date("2026-03-09T00:00:00Z"), DueAllDay: true}, jakarta, "2026-03-09T00:00:00+07:00"},
+		{&model.Todo{DueDate: date("2026-03-09T00:00:00Z"), DueAllDay: true}, newYork, "2026-03-09T00:00:00-04:00"},
+		{&model.Todo{DueDate: date("2026-03-08T00:00:00Z"), DueAllDay: true}, newYork, "2026-03-08T00:00:00-05:00"},
+		// Timed due dates are the same instant
+		{&model.Todo{DueDate: date("2026-03-09T18:00:00Z")}, jakarta, "2026-03-10T01:00:00+07:00"},
+		{&model.Todo{DueDate: date("2026-03-08T07:30:00Z")}, newYork, "2026-03-08T03:30:00-04:00"},
+		{&model.Todo{DueDate: date("2026-03-08T06:30:00Z")}, newYork, "2026-03-08T01:30:00-05:00"},
+	}
+
+	for _, tt := range tests {
+		got := service.DueDateIn(tt.todo, tt.loc)
+		assert.Equal(t, tt.want, got.Format(time.RFC3339))
+		assert.Equal(t, tt.loc, got.Location())
+	}
+}
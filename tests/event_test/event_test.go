@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"rest-api/internal/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publish publishes one event per todo ID and returns the events delivered
+// to a subscription of the user
+func publish(t *testing.T, bus *event.Bus, userID uint, todoIDs ...uint) []event.Event {
+	sub, _, _ := bus.Subscribe(userID, "")
+	defer sub.Close()
+
+	events := make([]event.Event, len(todoIDs))
+	for i, todoID := range todoIDs {
+		bus.Publish(event.Event{Type: event.TodoUpdated, UserID: userID, TodoID: todoID})
+		events[i] = <-sub.Events()
+	}
+	return events
+}
+
+func TestEventsOfOtherUsersAreNotDelivered(t *testing.T) {
+	bus := event.NewBus(10)
+	sub, _, _ := bus.Subscribe(1, "")
+	defer sub.Close()
+
+	bus.Publish(event.Event{Type: event.TodoCreated, UserID: 2, TodoID: 7})
+	bus.Publish(event.Event{Type: event.TodoCreated, UserID: 1, TodoID: 8})
+
+	e := <-sub.Events()
+	assert.Equal(t, uint(8), e.TodoID)
+	assert.NotEmpty(t, e.ID)
+	assert.False(t, e.At.IsZero())
+	assert.Empty(t, sub.Events())
+}
+
+func TestResumeReplaysMissedEvents(t *testing.T) {
+	bus := event.NewBus(10)
+	events := publish(t, bus, 1, 1, 2, 3)
+	publish(t, bus, 2, 4)
+
+	sub, replay, resumed := bus.Subscribe(1, events[0].ID)
+	defer sub.Close()
+
+	require.True(t, resumed)
+	assert.Equal(t, events[1:], replay)
+}
+
+func TestNewSubscriptionsReplayNothing(t *testing.T) {
+	bus := event.NewBus(10)
+	publish(t, bus, 1, 1, 2)
+
+	sub, replay, resumed := bus.Subscribe(1, "")
+	defer sub.Close()
+
+	assert.True(t, resumed)
+	assert.Empty(t, replay)
+}
+
+func TestResumeFailsOnceEventsLeftTheBuffer(t *testing.T) {
+	bus := event.NewBus(3)
+	events := publish(t, bus, 1, 1, 2, 3, 4)
+
+	_, replay, resumed := bus.Subscribe(1, events[0].ID)
+	assert.False(t, resumed)
+	assert.Empty(t, replay)
+
+	_, replay, resumed = bus.Subscribe(1, events[1].ID)
+	assert.True(t, resumed)
+	assert.Equal(t, events[2:], replay)
+
+	_, _, resumed = bus.Subscribe(1, "unknown")
+	assert.False(t, resumed)
+}
+
+func TestSlowSubscriptionsAreClosed(t *testing.T) {
+	bus := event.NewBus(10)
+	sub, _, _ := bus.Subscribe(1, "")
+
+	for i := 0; i < 100; i++ {
+		bus.Publish(event.Event{Type: event.TodoUpdated, UserID: 1, TodoID: 1})
+	}
+
+	received := 0
+	for range sub.Events() {
+		received++
+	}
+	assert.Less(t, received, 100)
+	sub.Close()
+}
+
+// failingTransport cannot reach the other replicas
+type failingTransport struct{ sent int }
+
+func (f *failingTransport) Send(e event.Event) error {
+	f.sent++
+	return assert.AnError
+}
+
+func TestEventsAreDeliveredLocallyWhenTheTransportFails(t *testing.T) {
+	bus := event.NewBus(10)
+	transport := &failingTransport{}
+	bus.UseTransport(transport)
+	sub, _, _ := bus.Subscribe(1, "")
+	defer sub.Close()
+
+	bus.Publish(event.Event{Type: event.TodoDeleted, UserID: 1, TodoID: 3})
+
+	assert.Equal(t, 1, transport.sent)
+	assert.Equal(t, uint(3), (<-sub.Events()).TodoID)
+}
+
+func TestRepublishedEventsAreDeliveredOnce(t *testing.T) {
+	bus := event.NewBus(2)
+	sub, _, _ := bus.Subscribe(1, "")
+	defer sub.Close()
+
+	relayed := event.Event{ID: "relayed", Type: event.TodoCreated, UserID: 1, TodoID: 1, At: time.Now().UTC()}
+	bus.Publish(relayed)
+	bus.Publish(relayed)
+	assert.Equal(t, relayed, <-sub.Events())
+	assert.Empty(t, sub.Events())
+
+	// Once evicted from the replay buffer an ID is delivered again
+	publish(t, bus, 2, 2, 3)
+	bus.Publish(relayed)
+	assert.Equal(t, "relayed", (<-sub.Events()).ID)
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"rest-api/internal/dto"
+	"rest-api/internal/graph"
+	"rest-api/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSchema creates a schema without services, enough for requests that are
+// rejected or fail before a resolver reaches the database
+func newSchema(t *testing.T, limits graph.Limits) *graph.Schema {
+	schema, err := graph.NewSchema(nil, nil, nil, limits)
+	require.NoError(t, err)
+	return schema
+}
+
+func TestLoaderBatchesKeys(t *testing.T) {
+	var batches [][]uint
+	loader := graph.NewLoader(func(keys []uint) (map[uint]string, error) {
+		batches = append(batches, keys)
+		values := make(map[uint]string, len(keys))
+		for _, key := range keys {
+			values[key] = fmt.Sprintf("user%d", key)
+		}
+		return values, nil
+	})
+
+	first, second, again := loader.Load(1), loader.Load(2), loader.Load(1)
+	for _, load := range []func() (string, error){second, first, again} {
+		_, err := load()
+		require.NoError(t, err)
+	}
+	value, _ := first()
+	assert.Equal(t, "user1", value)
+	assert.Equal(t, [][]uint{{1, 2}}, batches)
+
+	// Loaded keys are cached, new keys start a new batch
+	value, _ = loader.Load(2)()
+	assert.Equal(t, "user2", value)
+	value, _ = loader.Load(3)()
+	assert.Equal(t, "user3", value)
+	assert.Equal(t, [][]uint{{1, 2}, {3}}, batches)
+}
+
+func TestQueryTooDeep(t *testing.T) {
+	schema := newSchema(t, graph.Limits{MaxDepth: 4})
+
+	response := schema.Execute(context.Background(), 0, i18n.English, dto.GraphQLRequest{
+		Query: `{ todos { nodes { owner { todos { totalCount } } } } }`,
+	})
+
+	assert.Nil(t, response.Data)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "Query is nested 5 levels deep, at most 4 are allowed", response.Errors[0].Message)
+	assert.Equal(t, graph.CodeQueryTooDeep, response.Errors[0].Extensions["code"])
+}
+
+func TestQueryTooComplex(t *testing.T) {
+	schema := newSchema(t, graph.Limits{MaxComplexity: 1000})
+
+	// todos: 1 + 100 × (nodes: 1 + blockedBy: 1 + 10 × title: 1)
+	response := schema.Execute(context.Background(), 0, i18n.Indonesian, dto.GraphQLRequest{
+		Query:     `query List($limit: Int) { todos(limit: $limit) { nodes { ...blockers } } } fragment blockers on Todo { blockedBy { title } }`,
+		Variables: map[string]interface{}{"limit": float64(100)},
+	})
+
+	assert.Nil(t, response.Data)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "Kompleksitas query 1201, maksimal 1000", response.Errors[0].Message)
+	assert.Equal(t, graph.CodeQueryTooComplex, response.Errors[0].Extensions["code"])
+
+	// The same query fits when fewer todos are requested
+	response = schema.Execute(context.Background(), 0, i18n.English, dto.GraphQLRequest{
+		Query:     `query List($limit: Int) { todos(limit: $limit) { nodes { ...blockers } } } fragment blockers on Todo { blockedBy { title } }`,
+		Variables: map[string]interface{}{"limit": float64(50)},
+	})
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "unauthorized", response.Errors[0].Extensions["code"])
+}
+
+func TestFieldsRequireAUser(t *testing.T) {
+	schema := newSchema(t, graph.Limits{})
+
+	response := schema.Execute(context.Background(), 0, i18n.English, dto.GraphQLRequest{
+		Query: `{ me { id username } todo(id: "1") { title } }`,
+	})
+
+	assert.Equal(t, map[string]interface{}{"me": nil, "todo": nil}, response.Data)
+	require.Len(t, response.Errors, 2)
+	for _, err := range response.Errors {
+		assert.Equal(t, "unauthorized", err.Extensions["code"])
+		assert.Len(t, err.Path, 1)
+	}
+}
+
+func TestInvalidQueriesAreRejected(t *testing.T) {
+	schema := newSchema(t, graph.Limits{})
+
+	response := schema.Execute(context.Background(), 0, i18n.English, dto.GraphQLRequest{
+		Query: `{ todos { nodes { secret } } }`,
+	})
+
+	assert.Nil(t, response.Data)
+	require.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, `Cannot query field "secret" on type "Todo"`)
+	assert.Equal(t, []dto.GraphQLLocation{{Line: 1, Column: 19}}, response.Errors[0].Locations)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"rest-api/internal/rpc"
+	"rest-api/internal/rpc/todov1"
+	"rest-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves a server without services behind it, enough for calls that
+// fail before they reach the database, and connects to it in memory
+func dial(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := rpc.NewServer(nil, nil)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// reason returns the problem code in the ErrorInfo detail of an error
+func reason(t *testing.T, err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return ""
+}
+
+func TestTokenIsRequired(t *testing.T) {
+	client := todov1.NewAuthServiceClient(dial(t))
+	validToken, err := utils.GenerateToken(1, "bob")
+	require.NoError(t, err)
+
+	tests := []struct {
+		authorization string
+		reason        string
+		message       string
+	}{
+		{"", "missing_token", "Token tidak ditemukan"},
+		{"Token " + validToken, "malformed_token", "Format token tidak valid"},
+		{"Bearer nope", "invalid_token", "Token tidak valid atau expired"},
+	}
+
+	for _, tt := range tests {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "id")
+		if tt.authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+		}
+		_, err := client.GetProfile(ctx, &todov1.GetProfileRequest{})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), tt.authorization)
+		assert.Equal(t, tt.message, status.Convert(err).Message())
+		assert.Equal(t, tt.reason, reason(t, err))
+	}
+}
+
+func TestInvalidRequestsListFieldViolations(t *testing.T) {
+	client := todov1.NewAuthServiceClient(dial(t))
+
+	// Login is public, the request is checked before the service is called
+	_, err := client.Login(context.Background(), &todov1.LoginRequest{Username: "bob"})
+
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "validation_failed", reason(t, err))
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+	require.Len(t, violations, 1)
+	assert.Equal(t, "password", violations[0].GetField())
+	assert.Equal(t, "is required", violations[0].GetDescription())
+}
+
+func TestInvalidTodoID(t *testing.T) {
+	client := todov1.NewTodoServiceClient(dial(t))
+	token, err := utils.GenerateToken(1, "bob")
+	require.NoError(t, err)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	_, err = client.GetTodo(ctx, &todov1.GetTodoRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "invalid_id", reason(t, err))
+}
+
+func TestProblemStatusesMapToCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		code   string
+		want   codes.Code
+	}{
+		{http.StatusBadRequest, "invalid_status", codes.InvalidArgument},
+		{http.StatusUnauthorized, "invalid_credentials", codes.Unauthenticated},
+		{http.StatusForbidden, "forbidden", codes.PermissionDenied},
+		{http.StatusNotFound, "todo_not_found", codes.NotFound},
+		{http.StatusConflict, "user_exists", codes.AlreadyExists},
+		{http.StatusConflict, "todo_blocked", codes.FailedPrecondition},
+		{http.StatusFailedDependency, "bulk_rolled_back", codes.Aborted},
+		{http.StatusInternalServerError, "internal_error", codes.Internal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, rpc.CodeOf(tt.status, tt.code), tt.code)
+	}
+}
+
+func TestServerReflection(t *testing.T) {
+	client := reflectionpb.NewServerReflectionClient(dial(t))
+	stream, err := client.ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	response, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "todo.v1.AuthService")
+	assert.Contains(t, services, "todo.v1.TodoService")
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"rest-api/internal/dto"
+	"rest-api/internal/graph"
+	"rest-api/internal/i18n"
+	"rest-api/internal/problem"
+	"rest-api/internal/service"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for _, locale := range i18n.Locales() {
+		assert.Equal(t, i18n.Keys(i18n.Default), i18n.Keys(locale), "keys of %s", locale)
+	}
+}
+
+func TestEveryCodeHasMessages(t *testing.T) {
+	var keys []string
+	for _, code := range problem.Codes() {
+		keys = append(keys, "error."+code)
+	}
+	for _, code := range graph.Codes() {
+		keys = append(keys, "error."+code)
+	}
+	for _, code := range problem.FieldCodes() {
+		keys = append(keys, "field."+code)
+	}
+	for _, typ := range []string{"string", "integer", "number", "boolean", "array", "object", "null"} {
+		keys = append(keys, "types."+typ)
+	}
+	for _, format := range []string{"email", "date-time", "uri"} {
+		keys = append(keys, "format."+format)
+	}
+	for _, code := range successCodes(t) {
+		keys = append(keys, "success."+code)
+	}
+
+	for _, locale := range i18n.Locales() {
+		for _, key := range keys {
+			assert.True(t, i18n.Has(locale, key), "%s has no message for %s", locale, key)
+		}
+	}
+}
+
+// successCodes collects the codes the handlers pass to i18n.Success, either
+// as literals or through a code variable
+func successCodes(t *testing.T) []string {
+	files, err := filepath.Glob("../../internal/handler/*.go")
+	require.NoError(t, err)
+
+	var codes []string
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if sel, ok := n.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Success" && len(n.Args) == 3 {
+					if code, ok := stringLiteral(n.Args[1]); ok {
+						codes = append(codes, code)
+					}
+				}
+			case *ast.AssignStmt:
+				if ident, ok := n.Lhs[0].(*ast.Ident); ok && ident.Name == "code" && len(n.Rhs) == 1 {
+					if code, ok := stringLiteral(n.Rhs[0]); ok {
+						codes = append(codes, code)
+					}
+				}
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, codes)
+	return codes
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Locale
+	}{
+		{"", i18n.English},
+		{"id", i18n.Indonesian},
+		{"id-ID,id;q=0.9,en;q=0.8", i18n.Indonesian},
+		{"en-US,id;q=0.5", i18n.English},
+		{"fr-FR,id;q=0.7,en;q=0.3", i18n.Indonesian},
+		{"fr, de", i18n.English},
+		{"en;q=0.5, id;q=0.5", i18n.English},
+		{"id;q=abc, en", i18n.English},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, i18n.Negotiate(tt.header), tt.header)
+	}
+}
+
+func TestProblemsAreTranslated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/todos/:id", func(c *gin.Context) {
+		problem.Error(c, service.ErrTodoNotFound, "Failed to get todo")
+	})
+	router.POST("/api/v1/templates", func(c *gin.Context) {
+		var req dto.CreateTemplateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Bind(c, err)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos/7", nil)
+	req.Header.Set("Accept-Language", "id-ID")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response dto.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "todo_not_found", response.Code)
+	assert.Equal(t, "Tidak Ditemukan", response.Title)
+	assert.Equal(t, "todo tidak ditemukan", response.Detail)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/templates", nil)
+	req.Header.Set("Accept-Language", "id")
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	response = dto.Problem{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Data request tidak valid", response.Detail)
+	assert.Equal(t, []dto.FieldError{{In: "body", Code: "required", Message: "body request wajib diisi"}}, response.Errors)
+}
+
+func TestFieldMessages(t *testing.T) {
+	tests := []struct {
+		err    dto.FieldError
+		locale i18n.Locale
+		want   string
+	}{
+		{dto.FieldError{Field: "title", Code: "too_long", Params: map[string]interface{}{"max": 200}}, i18n.English, "must be at most 200 characters"},
+		{dto.FieldError{Field: "title", Code: "too_long", Params: map[string]interface{}{"max": 200}}, i18n.Indonesian, "maksimal 200 karakter"},
+		{dto.FieldError{Field: "due", Code: "invalid_type", Params: map[string]interface{}{"types": []string{"string", "null"}}}, i18n.English, "must be a string or null"},
+		{dto.FieldError{Field: "due", Code: "invalid_type", Params: map[string]interface{}{"types": []string{"integer", "null"}}}, i18n.Indonesian, "harus berupa bilangan bulat atau null"},
+		{dto.FieldError{Field: "email", Code: "invalid_format", Params: map[string]interface{}{"format": "email"}}, i18n.Indonesian, "harus berupa alamat email yang valid"},
+		{dto.FieldError{Field: "name", Code: "required"}, i18n.Indonesian, "wajib diisi"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, problem.Message(tt.locale, tt.err))
+	}
+}
+
+func TestResolvedLocaleWinsOverAcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Stands in for the locale middleware with a user who prefers Indonesian
+	router.Use(func(c *gin.Context) {
+		c.Set(i18n.ContextKey, i18n.Resolver(func(c *gin.Context) i18n.Locale { return i18n.Indonesian }))
+	})
+	router.GET("/api/v1/todos", func(c *gin.Context) {
+		c.JSON(http.StatusOK, i18n.Success(c, "todos_retrieved", []string{}))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+	req.Header.Set("Accept-Language", "en")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response dto.SuccessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "todos_retrieved", response.Code)
+	assert.Equal(t, "Todo berhasil diambil", response.Message)
+	assert.Equal(t, "id", w.Header().Get("Content-Language"))
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"errors"
+	"rest-api/internal/dto"
+	"rest-api/internal/service"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSVImport(t *testing.T) {
+	imports := service.NewImportService(nil, nil)
+
+	csv := "\ufeffTitle, Description,Status,Priority,Due_Date,Ignored\n" +
+		"Write report,\"First line\nsecond, line\",Done,HIGH,2026-03-09T00:00:00+07:00,x\n" +
+		"  Call plumber  ,,in progress,,2026-03-10T14:30:00Z\n" +
+		",missing title,todo,urgent,next week\n"
+
+	rows, err := imports.ParseImport(service.ImportFormatCSV, strings.NewReader(csv), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []service.ImportRow{
+		{Row: 1, Request: dto.CreateTodoRequest{
+			Title:       "Write report",
+			Description: "First line\nsecond, line",
+			Status:      "completed",
+			Priority:    "high",
+			DueDate:     "2026-03-09",
+		}},
+		{Row: 2, Request: dto.CreateTodoRequest{
+			Title:    "Call plumber",
+			Status:   "in_progress",
+			Priority: "medium",
+			DueDate:  "2026-03-10T14:30:00Z",
+		}},
+		// Invalid values are left for the row validation to report
+		{Row: 3, Request: dto.CreateTodoRequest{
+			Description: "missing title",
+			Status:      "pending",
+			Priority:    "urgent",
+			DueDate:     "next week",
+		}},
+	}, rows)
+}
+
+func TestParseCSVImportMapping(t *testing.T) {
+	imports := service.NewImportService(nil, nil)
+	csv := "Task,Notes,Deadline\nWrite report,Quarterly,2026-03-09\n"
+
+	rows, err := imports.ParseImport(service.ImportFormatCSV, strings.NewReader(csv), map[string]string{
+		"title":       "Task",
+		"description": "notes",
+		"due_date":    "Deadline",
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, dto.CreateTodoRequest{Title: "Write report", Description: "Quarterly", Priority: "medium", DueDate: "2026-03-09"}, rows[0].Request)
+
+	tests := []struct {
+		name    string
+		csv     string
+		mapping map[string]string
+		err     error
+	}{
+		{"unknown field", csv, map[string]string{"owner": "Task"}, service.ErrInvalidImportMapping},
+		{"missing column", csv, map[string]string{"title": "Name"}, service.ErrInvalidImportMapping},
+		{"no title column", csv, nil, service.ErrInvalidImportMapping},
+		{"empty file", "", nil, service.ErrInvalidImportFile},
+		{"malformed row", "title\n\"unterminated\n", nil, service.ErrInvalidImportFile},
+	}
+	for _, tt := range tests {
+		_, err := imports.ParseImport(service.ImportFormatCSV, strings.NewReader(tt.csv), tt.mapping)
+		assert.True(t, errors.Is(err, tt.err), "%s: %v", tt.name, err)
+	}
+}
+
+func TestParseJSONImport(t *testing.T) {
+	imports := service.NewImportService(nil, nil)
+
+	tests := []struct {
+		name   string
+		format string
+		json   string
+		want   []dto.CreateTodoRequest
+	}{
+		{
+			name:   "export",
+			format: service.ImportFormatJSON,
+			json:   `[{"id":4,"title":"Write report","status":"pending","priority":"high","due_date":"2026-03-09T00:00:00Z","tags":[]}]`,
+			want:   []dto.CreateTodoRequest{{Title: "Write report", Status: "pending", Priority: "high", DueDate: "2026-03-09"}},
+		},
+		{
+			name:   "export object",
+			format: service.ImportFormatJSON,
+			json:   `{"todos":[{"title":"Write report","status":"Doing"}]}`,
+			want:   []dto.CreateTodoRequest{{Title: "Write report", Status: "in_progress", Priority: "medium"}},
+		},
+		{
+			name:   "todoist detected",
+			format: service.ImportFormatJSON,
+			json:   `{"items":[{"content":"Call plumber","priority":4,"checked":true,"due":{"date":"2026-03-10"}},{"content":"Buy milk","priority":1}]}`,
+			want: []dto.CreateTodoRequest{
+				{Title: "Call plumber", Status: "completed", Priority: "high", DueDate: "2026-03-10"},
+				{Title: "Buy milk", Priority: "low"},
+			},
+		},
+		{
+			name:   "trello",
+			format: service.ImportFormatTrello,
+			json:   `{"cards":[{"name":"Plan trip","desc":"Book flights","due":"2026-03-11T09:00:00.000Z","dueComplete":false},{"name":"Archived","closed":true}]}`,
+			want:   []dto.CreateTodoRequest{{Title: "Plan trip", Description: "Book flights", Priority: "medium", DueDate: "2026-03-11T09:00:00.000Z"}},
+		},
+	}
+
+	for _, tt := range tests {
+		rows, err := imports.ParseImport(tt.format, strings.NewReader(tt.json), nil)
+		require.NoError(t, err, tt.name)
+		require.Len(t, rows, len(tt.want), tt.name)
+		for i, row := range rows {
+			assert.Equal(t, i+1, row.Row, tt.name)
+			assert.Equal(t, tt.want[i], row.Request, tt.name)
+		}
+	}
+
+	for _, invalid := range []string{`{"title":`, `"todos"`, `{"lists":[]}`, `{"todos":{"title":"x"}}`} {
+		_, err := imports.ParseImport(service.ImportFormatJSON, strings.NewReader(invalid), nil)
+		assert.True(t, errors.Is(err, service.ErrInvalidImportFile), "%s: %v", invalid, err)
+	}
+
+	_, err := imports.ParseImport("xlsx", strings.NewReader(""), nil)
+	assert.Equal(t, service.ErrUnsupportedImportFormat, err)
+}
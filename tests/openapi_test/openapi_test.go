@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"rest-api/internal/handler"
+	"rest-api/internal/openapi"
+	"rest-api/internal/openapi/annotation"
+	"rest-api/internal/route"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRouter registers every route with handlers that are never called,
+// except for the docs handler, after the given middleware
+func newRouter(middleware ...func(router *gin.Engine) gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, m := range middleware {
+		router.Use(m(router))
+	}
+	route.SetupRoutes(router,
+		&handler.UserHandler{},
+		&handler.HealthHandler{},
+		&handler.TodoHandler{},
+		&handler.ImportHandler{},
+		&handler.CalendarHandler{},
+		&handler.WorkflowHandler{},
+		&handler.CustomFieldHandler{},
+		&handler.DependencyHandler{},
+		&handler.TimeEntryHandler{},
+		&handler.SavedViewHandler{},
+		&handler.TemplateHandler{},
+		&handler.GraphQLHandler{},
+		&handler.EventHandler{},
+		&handler.WebhookHandler{},
+		handler.NewDocsHandler(router),
+	)
+	return router
+}
+
+// TestSpecCoversRoutes fails when a route is registered without annotations
+func TestSpecCoversRoutes(t *testing.T) {
+	router := newRouter()
+	doc := openapi.Build(router.Routes())
+
+	for _, r := range router.Routes() {
+		item := doc.Paths[openapi.Path(r.Path)]
+		assert.NotNil(t, item[strings.ToLower(r.Method)], "%s %s (%s) is missing from the OpenAPI document, annotate the handler and run go generate ./internal/openapi", r.Method, r.Path, r.Handler)
+	}
+}
+
+// TestAnnotationsMatchRoutes fails when an annotation names a route that does not exist
+func TestAnnotationsMatchRoutes(t *testing.T) {
+	routes := make(map[string]bool)
+	for _, r := range newRouter().Routes() {
+		routes[r.Method+" "+openapi.Path(r.Path)] = true
+	}
+
+	for _, op := range openapi.Operations() {
+		assert.True(t, routes[op.Method+" "+op.Path], "%s is annotated with %s %s, which is not a registered route", op.Handler, op.Method, op.Path)
+	}
+}
+
+// TestOperationsUpToDate fails when annotations changed without regenerating the operation table
+func TestOperationsUpToDate(t *testing.T) {
+	operations, err := annotation.Parse("../../internal/handler", "../../internal/dto")
+	require.NoError(t, err)
+	src, err := annotation.Render(operations)
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile("../../internal/openapi/operations_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(src), string(generated), "operations_gen.go is stale, run go generate ./internal/openapi")
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	router := newRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                          `json:"required"`
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.Contains(t, doc.Paths["/api/v1/todos/{id}"], "put")
+
+	// Schemas follow the json and binding tags of the DTOs
+	register := doc.Components.Schemas["RegisterRequest"]
+	assert.ElementsMatch(t, []string{"username", "email", "password", "fullname"}, register.Required)
+	assert.Equal(t, "email", register.Properties["email"]["format"])
+	assert.EqualValues(t, 50, register.Properties["username"]["maxLength"])
+	create := doc.Components.Schemas["CreateTodoRequest"]
+	assert.Equal(t, []interface{}{"low", "medium", "high"}, create.Properties["priority"]["enum"])
+	todo := doc.Components.Schemas["TodoResponse"]
+	assert.Equal(t, []interface{}{"string", "null"}, todo.Properties["due_date"]["type"])
+}
+
+func TestServeDocs(t *testing.T) {
+	router := newRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/docs/", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/middleware"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+func validateRequests(router *gin.Engine) gin.HandlerFunc {
+	return middleware.ValidationMiddleware(router, false)
+}
+
+func serve(router *gin.Engine, method, url, contentType, body string) (*httptest.ResponseRecorder, dto.Problem) {
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response dto.Problem
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+// params are field error params as decoded from JSON
+type params = map[string]interface{}
+
+func TestValidateRequestBody(t *testing.T) {
+	router := newRouter(validateRequests)
+
+	w, response := serve(router, http.MethodPost, "/api/v1/todos", "application/json",
+		`{"title": 5, "priority": "urgent", "tags": ["a", 1], "estimate_minutes": 0}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "validation_failed", response.Code)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+	assert.Equal(t, "/api/v1/todos", response.Instance)
+	assert.ElementsMatch(t, []dto.FieldError{
+		{In: "body", Field: "title", Code: "invalid_type", Params: params{"types": []interface{}{"string"}}, Message: "must be a string"},
+		{In: "body", Field: "priority", Code: "invalid_choice", Params: params{"options": "low, medium, high"}, Message: "must be one of low, medium, high"},
+		{In: "body", Field: "tags[1]", Code: "invalid_type", Params: params{"types": []interface{}{"string"}}, Message: "must be a string"},
+		{In: "body", Field: "estimate_minutes", Code: "too_small", Params: params{"min": 1.0}, Message: "must be at least 1"},
+	}, response.Errors)
+
+	_, response = serve(router, http.MethodPost, "/api/v1/templates", "application/json",
+		`{"name": "Release", "items": [{"priority": "high"}]}`)
+	assert.Equal(t, []dto.FieldError{{In: "body", Field: "items[0].title", Code: "required", Message: "is required"}}, response.Errors)
+
+	_, response = serve(router, http.MethodPost, "/api/v1/todos", "application/json", "")
+	assert.Equal(t, []dto.FieldError{{In: "body", Code: "required", Message: "request body is required"}}, response.Errors)
+
+	_, response = serve(router, http.MethodPost, "/api/v1/auth/register", "application/json", `{"username": "bob"`)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "invalid_json", response.Errors[0].Code)
+
+	w, response = serve(router, http.MethodPost, "/api/v1/todos", "text/plain", `{"title": "Write docs", "priority": "low"}`)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Equal(t, "unsupported_media_type", response.Code)
+
+	// Valid requests reach the handler, which rejects them without a user
+	w, response = serve(router, http.MethodPost, "/api/v1/todos", "application/json", `{"title": "Write docs", "priority": "low"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "unauthorized", response.Code)
+
+	// An empty string skips the oneof rule of an omitempty field, as in gin
+	w, _ = serve(router, http.MethodPost, "/api/v1/todos/bulk", "application/json", `{"mode": "", "operations": [{"op": "delete", "id": 1}]}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestValidateRequestParams(t *testing.T) {
+	router := newRouter(validateRequests)
+
+	w, response := serve(router, http.MethodGet, "/api/v1/todos/abc", "", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []dto.FieldError{{In: "path", Field: "id", Code: "invalid_type", Params: params{"types": []interface{}{"integer"}}, Message: "must be an integer"}}, response.Errors)
+
+	_, response = serve(router, http.MethodGet, "/api/v1/todos?open=maybe", "", "")
+	assert.Equal(t, []dto.FieldError{{In: "query", Field: "open", Code: "invalid_type", Params: params{"types": []interface{}{"boolean"}}, Message: "must be a boolean"}}, response.Errors)
+
+	w, _ = serve(router, http.MethodGet, "/api/v1/todos?open=true&cf.size=m", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestValidateResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ValidationMiddleware(router, true))
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/api/v1/board", func(c *gin.Context) {
+		c.JSON(http.StatusOK, dto.SuccessResponse{Success: true, Message: "Board", Data: gin.H{"columns": "none"}})
+	})
+	router.GET("/api/v1/stats", func(c *gin.Context) {
+		c.JSON(http.StatusTeapot, dto.Problem{Status: http.StatusTeapot, Code: "teapot"})
+	})
+
+	w, _ := serve(router, http.MethodGet, "/health", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+
+	w, response := serve(router, http.MethodGet, "/api/v1/board", "", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, []dto.FieldError{{In: "response", Field: "data.columns", Code: "invalid_type", Params: params{"types": []interface{}{"array", "null"}}, Message: "must be an array or null"}}, response.Errors)
+
+	w, response = serve(router, http.MethodGet, "/api/v1/stats", "", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "response_mismatch", response.Code)
+	assert.Equal(t, []dto.FieldError{{In: "response", Code: "undocumented_status", Params: params{"status": 418.0}, Message: "status 418 is not documented"}}, response.Errors)
+}
+
+func TestValidateRequestFormats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(validateRequests(router))
+	router.POST("/api/v1/todos", func(c *gin.Context) {
+		var req dto.CreateTodoRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.String(http.StatusTeapot, err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	estimate := 30
+	want := dto.CreateTodoRequest{Title: "Write docs", Priority: "low", Tags: []string{"docs", "api"}, EstimateMinutes: &estimate}
+	body := map[string]interface{}{"title": "Write docs", "priority": "low", "tags": []string{"docs", "api"}, "estimate_minutes": 30}
+
+	for _, handle := range []codec.Handle{&codec.MsgpackHandle{WriteExt: true}, &codec.CborHandle{}} {
+		var encoded []byte
+		require.NoError(t, codec.NewEncoderBytes(&encoded, handle).Encode(body))
+		w, _ := serve(router, http.MethodPost, "/api/v1/todos", "application/"+handle.Name(), string(encoded))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var got dto.CreateTodoRequest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, want, got, handle.Name())
+	}
+
+	w, _ := serve(router, http.MethodPost, "/api/v1/todos", "application/xml",
+		`<todo><title>Write docs</title><priority>low</priority><tags><i>docs</i><i>api</i></tags><estimate_minutes>30</estimate_minutes></todo>`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got dto.CreateTodoRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, want, got)
+
+	// XML text is typed by the schema, so it is checked like JSON
+	_, response := serve(router, http.MethodPost, "/api/v1/todos", "application/xml",
+		`<todo><title>Write docs</title><priority>low</priority><estimate_minutes>soon</estimate_minutes></todo>`)
+	assert.Equal(t, []dto.FieldError{{In: "body", Field: "estimate_minutes", Code: "invalid_type", Params: params{"types": []interface{}{"integer", "null"}}, Message: "must be an integer or null"}}, response.Errors)
+
+	_, response = serve(router, http.MethodPost, "/api/v1/todos", "application/msgpack", "\xc1")
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "invalid_body", response.Errors[0].Code)
+	assert.Equal(t, "MessagePack", response.Errors[0].Params["format"])
+}
+
+func TestNegotiation(t *testing.T) {
+	router := newRouter(middleware.NegotiationMiddleware, validateRequests)
+
+	get := func(url, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/todos", "text/html")
+	require.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept")
+	var response dto.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "not_acceptable", response.Code)
+
+	// Problems come in the accepted format
+	w = get("/api/v1/todos", "application/cbor")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "application/problem+cbor", w.Header().Get("Content-Type"))
+	var decoded map[string]interface{}
+	require.NoError(t, codec.NewDecoderBytes(w.Body.Bytes(), &codec.CborHandle{}).Decode(&decoded))
+	assert.Equal(t, "unauthorized", decoded["code"])
+
+	w = get("/api/v1/todos", "text/html, application/xml;q=0.5")
+	assert.Equal(t, "application/problem+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`)
+	assert.Contains(t, w.Body.String(), `<code>unauthorized</code>`)
+
+	// Media types an operation produces besides the formats are acceptable
+	w = get("/api/v1/todos/export", "text/csv")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+}
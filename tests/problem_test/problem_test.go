@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/problem"
+	"rest-api/internal/service"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// params are field error params as decoded from JSON
+type params = map[string]interface{}
+
+func serve(handler gin.HandlerFunc, body string) (*httptest.ResponseRecorder, dto.Problem) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/things", handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/things", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response dto.Problem
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func TestServiceErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{service.ErrTodoNotFound, http.StatusNotFound, "todo_not_found", "todo not found"},
+		{fmt.Errorf("%w: unknown status", service.ErrInvalidStatus), http.StatusBadRequest, "invalid_status", "invalid status value: unknown status"},
+		{service.ErrUserExists, http.StatusConflict, "user_exists", "username already exists"},
+		{service.ErrTodoBlocked, http.StatusConflict, "todo_blocked", "todo is blocked by unfinished todos"},
+		// Unknown errors do not leak their text
+		{errors.New("pq: connection refused"), http.StatusInternalServerError, "internal_error", "Something went wrong, please try again later"},
+	}
+
+	for _, tt := range tests {
+		w, response := serve(func(c *gin.Context) {
+			problem.Error(c, tt.err, "Failed to save thing")
+		}, "")
+
+		assert.Equal(t, tt.status, w.Code, tt.err)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, dto.Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(tt.status),
+			Status:   tt.status,
+			Detail:   tt.detail,
+			Instance: "/api/v1/things",
+			Code:     tt.code,
+		}, response)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	bind := func(c *gin.Context) {
+		var req dto.CreateTemplateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Bind(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+
+	w, response := serve(bind, `{"items": [{"title": "Ship"}, {"priority": "urgent", "title": "`+strings.Repeat("x", 201)+`"}]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "validation_failed", response.Code)
+	// Fields are named by their JSON path, not the Go struct fields
+	assert.Equal(t, []dto.FieldError{
+		{In: "body", Field: "name", Code: "required", Message: "is required"},
+		{In: "body", Field: "items[1].title", Code: "too_long", Params: params{"max": 200.0}, Message: "must be at most 200 characters"},
+		{In: "body", Field: "items[1].priority", Code: "invalid_choice", Params: params{"options": "low, medium, high"}, Message: "must be one of low, medium, high"},
+	}, response.Errors)
+
+	_, response = serve(bind, `{"name": 5}`)
+	assert.Equal(t, []dto.FieldError{{In: "body", Field: "name", Code: "invalid_type", Params: params{"types": []interface{}{"string"}}, Message: "must be a string"}}, response.Errors)
+
+	_, response = serve(bind, `{"name": "Release"`)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "invalid_json", response.Errors[0].Code)
+
+	_, response = serve(bind, "")
+	assert.Equal(t, []dto.FieldError{{In: "body", Code: "required", Message: "request body is required"}}, response.Errors)
+
+	w, _ = serve(bind, `{"name": "Release", "items": [{"title": "Ship"}]}`)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
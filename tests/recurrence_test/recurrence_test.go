@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"rest-api/internal/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"rrule:freq=weekly;byday=fr,mo;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{"FREQ=MONTHLY;BYMONTHDAY=31", "FREQ=MONTHLY;BYMONTHDAY=31"},
+		{"FREQ=YEARLY;BYMONTHDAY=29;BYMONTH=2", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29"},
+	}
+	for _, tt := range tests {
+		recurrence, err := service.ParseRecurrence(tt.rule)
+		require.NoError(t, err, tt.rule)
+		assert.Equal(t, tt.want, recurrence.String(), tt.rule)
+	}
+
+	for _, rule := range []string{
+		"", "FREQ=HOURLY", "FREQ=DAILY;INTERVAL=0", "FREQ=DAILY;BYDAY=MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0", "FREQ=MONTHLY;BYMONTHDAY=32", "FREQ=MONTHLY;BYMONTHDAY=1,15",
+		"FREQ=WEEKLY;BYMONTHDAY=1", "FREQ=MONTHLY;BYMONTH=2", "FREQ=YEARLY;BYMONTH=13",
+	} {
+		_, err := service.ParseRecurrence(rule)
+		assert.Equal(t, service.ErrInvalidRecurrence, err, rule)
+	}
+}
+
+// occurrences completes a recurring todo count times, the way the todo
+// service creates the next todo: anchored on the due date, with the anchored
+// rule stored on the next todo
+func occurrences(t *testing.T, rule string, due time.Time, allDay bool, loc *time.Location, count int) []string {
+	var dates []string
+	for i := 0; i < count; i++ {
+		recurrence, err := service.ParseRecurrence(rule)
+		require.NoError(t, err, rule)
+		recurrence = recurrence.Anchored(due, allDay, loc)
+		due = recurrence.Next(due, allDay, loc)
+		rule = recurrence.String()
+		if allDay {
+			dates = append(dates, due.Format("2006-01-02"))
+		} else {
+			dates = append(dates, due.In(loc).Format(time.RFC3339))
+		}
+	}
+	return dates
+}
+
+func TestRecurrenceKeepsDayOfMonth(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	allDay := func(value string) time.Time {
+		date, err := time.Parse("2006-01-02", value)
+		require.NoError(t, err)
+		return date
+	}
+
+	// Clamped to the end of shorter months, back to the 31st after them
+	assert.Equal(t,
+		[]string{"2027-02-28", "2027-03-31", "2027-04-30", "2027-05-31"},
+		occurrences(t, "FREQ=MONTHLY", allDay("2027-01-31"), true, time.UTC, 4))
+	assert.Equal(t,
+		[]string{"2027-03-31", "2027-05-31", "2027-07-31"},
+		occurrences(t, "FREQ=MONTHLY;INTERVAL=2", allDay("2027-01-31"), true, time.UTC, 3))
+	assert.Equal(t,
+		[]string{"2027-12-30", "2028-01-30", "2028-02-29", "2028-03-30"},
+		occurrences(t, "FREQ=MONTHLY", allDay("2027-11-30"), true, time.UTC, 4))
+
+	// Leap days fall on February 28 in other years
+	assert.Equal(t,
+		[]string{"2029-02-28", "2030-02-28", "2031-02-28", "2032-02-29"},
+		occurrences(t, "FREQ=YEARLY", allDay("2028-02-29"), true, time.UTC, 4))
+
+	// Timed todos keep their wall-clock time in the user's zone
+	due := time.Date(2027, time.January, 31, 9, 0, 0, 0, jakarta).UTC()
+	assert.Equal(t,
+		[]string{"2027-02-28T09:00:00+07:00", "2027-03-31T09:00:00+07:00"},
+		occurrences(t, "FREQ=MONTHLY", due, false, jakarta, 2))
+
+	// An explicit month day is used from the due date on
+	assert.Equal(t,
+		[]string{"2027-03-20", "2027-04-20"},
+		occurrences(t, "FREQ=MONTHLY;BYMONTHDAY=20", allDay("2027-03-05"), true, time.UTC, 2))
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/render"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		format *render.Format
+		ok     bool
+	}{
+		{"", render.JSON, true},
+		{"*/*", render.JSON, true},
+		{"application/*", render.JSON, true},
+		{"application/msgpack", render.MsgPack, true},
+		{"application/x-msgpack", render.MsgPack, true},
+		{"application/cbor, application/json;q=0.9", render.CBOR, true},
+		{"application/json;q=0.5, application/xml", render.XML, true},
+		{"text/xml", render.XML, true},
+		{"application/problem+json", render.JSON, true},
+		// Equal qualities go to the preferred format
+		{"application/xml, application/cbor", render.CBOR, true},
+		// A more specific range overrides a wildcard
+		{"*/*, application/json;q=0", render.MsgPack, true},
+		{"text/html", render.JSON, false},
+		{"application/json;q=0", render.JSON, false},
+	}
+
+	for _, tt := range tests {
+		format, ok := render.Negotiate(tt.accept)
+		assert.Equal(t, tt.format.Name, format.Name, tt.accept)
+		assert.Equal(t, tt.ok, ok, tt.accept)
+	}
+
+	assert.True(t, render.Acceptable("text/*", "text/csv"))
+	assert.False(t, render.Acceptable("application/json", "text/csv"))
+}
+
+func TestRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		format, _ := render.Negotiate(c.GetHeader("Accept"))
+		render.Use(c, format)
+	})
+	router.GET("/todo", func(c *gin.Context) {
+		render.Render(c, http.StatusOK, dto.SuccessResponse{Success: true, Message: "Todo", Data: gin.H{
+			"id":            7,
+			"title":         "Write <docs>",
+			"progress":      0.5,
+			"tags":          []string{"docs", "api"},
+			"due_date":      nil,
+			"custom_fields": gin.H{"story points": 3},
+		}})
+	})
+
+	serve := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+
+	w := serve("application/json")
+	var want map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &want))
+
+	for _, format := range []*render.Format{render.MsgPack, render.CBOR} {
+		w := serve(format.MediaType)
+		assert.Equal(t, format.MediaType, w.Header().Get("Content-Type"))
+		got, err := format.Decode(w.Body.Bytes())
+		require.NoError(t, err, format.Name)
+		// Decoded like JSON, with exact numbers
+		encoded, err := json.Marshal(got)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		assert.Equal(t, want, decoded, format.Name)
+	}
+
+	// Whole numbers are integers in the binary formats
+	var decoded map[string]interface{}
+	require.NoError(t, codec.NewDecoderBytes(serve("application/msgpack").Body.Bytes(), &codec.MsgpackHandle{}).Decode(&decoded))
+	assert.IsType(t, int64(0), decoded["data"].(map[interface{}]interface{})["id"])
+
+	w = serve("application/xml")
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<response><success>true</success><message>Todo</message><data>`+
+		`<custom_fields><entry key="story points">3</entry></custom_fields><due_date nil="true"></due_date>`+
+		`<id>7</id><progress>0.5</progress><tags><i>docs</i><i>api</i></tags><title>Write &lt;docs&gt;</title>`+
+		`</data></response>`, w.Body.String())
+}
+
+func TestParseXML(t *testing.T) {
+	root, err := render.ParseXML([]byte(`<todo><title>Write docs</title><entry key="story points">3</entry><due_date nil="true"/></todo>`))
+	require.NoError(t, err)
+	assert.Equal(t, &render.Element{Name: "todo", Children: []*render.Element{
+		{Name: "title", Text: "Write docs"},
+		{Name: "story points", Text: "3"},
+		{Name: "due_date", Nil: true},
+	}}, root)
+
+	_, err = render.ParseXML([]byte(`<todo/><todo/>`))
+	assert.Error(t, err)
+	_, err = render.ParseXML([]byte(`<todo>`))
+	assert.Error(t, err)
+}
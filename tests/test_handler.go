@@ -30,7 +30,7 @@ func main() {
 
 	// Wire repository -> service -> handler
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo)
+	authService := service.NewAuthService(userRepo, nil)
 	userHandler := handler.NewUserHandler(authService)
 
 	router.POST("/register", userHandler.Register)
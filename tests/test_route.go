@@ -21,9 +21,21 @@ func main() {
 	userHandler := &handler.UserHandler{}
 	todoHandler := &handler.TodoHandler{}
 	healthHandler := &handler.HealthHandler{}
+	importHandler := &handler.ImportHandler{}
+	calendarHandler := &handler.CalendarHandler{}
+	workflowHandler := &handler.WorkflowHandler{}
+	customFieldHandler := &handler.CustomFieldHandler{}
+	dependencyHandler := &handler.DependencyHandler{}
+	timeEntryHandler := &handler.TimeEntryHandler{}
+	savedViewHandler := &handler.SavedViewHandler{}
+	templateHandler := &handler.TemplateHandler{}
+	graphqlHandler := &handler.GraphQLHandler{}
+	eventHandler := &handler.EventHandler{}
+	webhookHandler := &handler.WebhookHandler{}
+	docsHandler := &handler.DocsHandler{}
 
 	// Setup routes
-	route.SetupRoutes(router, userHandler, healthHandler, todoHandler)
+	route.SetupRoutes(router, userHandler, healthHandler, todoHandler, importHandler, calendarHandler, workflowHandler, customFieldHandler, dependencyHandler, timeEntryHandler, savedViewHandler, templateHandler, graphqlHandler, eventHandler, webhookHandler, docsHandler)
 
 	// List all routes
 	fmt.Println("📍 Registered Routes:")
@@ -24,8 +24,8 @@ func main() {
 	todoRepo := repository.NewTodoRepository(db)
 
 	// Create services
-	authService := service.NewAuthService(userRepo)
-	todoService := service.NewTodoService(todoRepo)
+	authService := service.NewAuthService(userRepo, nil)
+	todoService := service.NewTodoService(todoRepo, userRepo, repository.NewWorkflowRepository(db), repository.NewCustomFieldRepository(db), repository.NewDependencyRepository(db), nil)
 
 	// Test registration
 	registerReq := dto.RegisterRequest{
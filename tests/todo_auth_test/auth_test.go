@@ -3,6 +3,7 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"rest-api/internal/config"
@@ -14,6 +15,7 @@ import (
 	"rest-api/internal/route"
 	"rest-api/internal/service"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,10 @@ type AuthTestSuite struct {
 	suite.Suite
 	db     *gorm.DB
 	router *gin.Engine
+	outbox *service.OutboxRelay
+	// mailErr fails the emails of the relay, sent ones are collected in mails
+	mailErr error
+	mails   []string
 }
 
 // SetupSuite runs once before all tests
@@ -43,7 +49,7 @@ func (suite *AuthTestSuite) SetupSuite() {
 		" password=" + cfg.DBPassword +
 		" dbname=" + cfg.DBName +
 		" port=" + cfg.DBPort +
-		" sslmode=disable TimeZone=Asia/Jakarta"
+		" sslmode=disable TimeZone=UTC"
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	suite.Require().NoError(err, "Failed to connect to test database")
@@ -51,25 +57,49 @@ func (suite *AuthTestSuite) SetupSuite() {
 	suite.db = db
 
 	// Auto-migrate models
-	err = db.AutoMigrate(&model.User{}, &model.Todo{})
+	err = db.AutoMigrate(&model.User{}, &model.Tag{}, &model.Todo{}, &model.Workflow{}, &model.CustomField{}, &model.TodoFieldValue{}, &model.TodoDependency{}, &model.TimeEntry{}, &model.SavedView{}, &model.TodoTemplate{}, &model.OutboxMessage{})
 	suite.Require().NoError(err, "Failed to migrate test database")
 
 	// Initialize dependencies
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo)
+	suite.outbox = service.NewOutboxRelay(repository.NewOutboxRepository(db))
+	suite.outbox.Handle(service.TopicPasswordResetEmail, service.RelayPasswordResetEmails(func(toEmail, resetLink string) error {
+		if suite.mailErr != nil {
+			return suite.mailErr
+		}
+		suite.mails = append(suite.mails, toEmail+" "+resetLink)
+		return nil
+	}))
+	authService := service.NewAuthService(userRepo, suite.outbox)
 	userHandler := handler.NewUserHandler(authService)
 	healthHandler := handler.NewHealthHandler(db)
 
 	// Dummy handlers for routes that won't be tested
 	todoRepo := repository.NewTodoRepository(db)
-	todoService := service.NewTodoService(todoRepo)
+	workflowRepo := repository.NewWorkflowRepository(db)
+	todoService := service.NewTodoService(todoRepo, userRepo, workflowRepo, repository.NewCustomFieldRepository(db), repository.NewDependencyRepository(db), nil)
 	todoHandler := handler.NewTodoHandler(todoService)
+	importHandler := &handler.ImportHandler{}
+	calendarHandler := &handler.CalendarHandler{}
+	workflowHandler := &handler.WorkflowHandler{}
+	customFieldHandler := &handler.CustomFieldHandler{}
+	dependencyHandler := &handler.DependencyHandler{}
+	timeEntryHandler := &handler.TimeEntryHandler{}
+	savedViewHandler := &handler.SavedViewHandler{}
+	templateHandler := &handler.TemplateHandler{}
+	graphqlHandler := &handler.GraphQLHandler{}
+	eventHandler := &handler.EventHandler{}
+	webhookHandler := &handler.WebhookHandler{}
+	docsHandler := &handler.DocsHandler{}
 
 	// Setup router
 	router := gin.New()
 	router.Use(middleware.LoggerMiddleware())
 	router.Use(middleware.CORSMiddleware())
-	route.SetupRoutes(router, userHandler, healthHandler, todoHandler)
+	router.Use(middleware.LocaleMiddleware(userRepo))
+	router.Use(middleware.NegotiationMiddleware(router))
+	router.Use(middleware.ValidationMiddleware(router, true))
+	route.SetupRoutes(router, userHandler, healthHandler, todoHandler, importHandler, calendarHandler, workflowHandler, customFieldHandler, dependencyHandler, timeEntryHandler, savedViewHandler, templateHandler, graphqlHandler, eventHandler, webhookHandler, docsHandler)
 
 	suite.router = router
 }
@@ -84,8 +114,11 @@ func (suite *AuthTestSuite) TearDownSuite() {
 // SetupTest runs before each test
 func (suite *AuthTestSuite) SetupTest() {
 	// Clean tables before each test
+	suite.db.Exec("DELETE FROM outbox_messages")
 	suite.db.Exec("DELETE FROM todos")
 	suite.db.Exec("DELETE FROM users")
+	suite.mailErr = nil
+	suite.mails = nil
 }
 
 // TestRegisterSuccess tests successful user registration
@@ -102,6 +135,7 @@ func (suite *AuthTestSuite) TestRegisterSuccess() {
 	// Create HTTP request
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
 
 	// Record response
 	w := httptest.NewRecorder()
@@ -109,11 +143,13 @@ func (suite *AuthTestSuite) TestRegisterSuccess() {
 
 	// Assert response
 	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Equal(suite.T(), "id", w.Header().Get("Content-Language"))
 
 	var response dto.SuccessResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), "user_registered", response.Code)
 	assert.Contains(suite.T(), response.Message, "berhasil")
 
 	// Verify user data in response
@@ -155,10 +191,10 @@ func (suite *AuthTestSuite) TestRegisterDuplicateUsername() {
 	// Assert error response
 	assert.Equal(suite.T(), http.StatusConflict, w2.Code)
 
-	var response dto.ErrorResponse
+	var response dto.Problem
 	err := json.Unmarshal(w2.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
-	assert.False(suite.T(), response.Success)
+	assert.Equal(suite.T(), "user_exists", response.Code)
 }
 
 // TestLoginSuccess tests successful login
@@ -231,10 +267,10 @@ func (suite *AuthTestSuite) TestLoginInvalidCredentials() {
 	// Assert error response
 	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
 
-	var response dto.ErrorResponse
+	var response dto.Problem
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
-	assert.False(suite.T(), response.Success)
+	assert.Equal(suite.T(), "invalid_credentials", response.Code)
 }
 
 // TestGetProfile tests getting user profile with authentication
@@ -287,6 +323,58 @@ func (suite *AuthTestSuite) TestGetProfile() {
 	assert.Equal(suite.T(), "testuser", userData["username"])
 }
 
+// TestPasswordResetEmailIsRelayed tests that the reset email is written to
+// the outbox with the token and retried until the mailer accepts it
+func (suite *AuthTestSuite) TestPasswordResetEmailIsRelayed() {
+	regBody, _ := json.Marshal(dto.RegisterRequest{
+		Username: "resetuser",
+		Email:    "reset@example.com",
+		Password: "password123",
+		FullName: "Reset User",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewBuffer(regBody))
+	req.Header.Set("Content-Type", "application/json")
+	suite.router.ServeHTTP(httptest.NewRecorder(), req)
+
+	suite.mailErr = errors.New("mail server unavailable")
+	jsonBody, _ := json.Marshal(dto.ResetPasswordRequest{Email: "reset@example.com"})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/reset-password", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var message model.OutboxMessage
+	err := suite.db.Where("topic = ?", service.TopicPasswordResetEmail).First(&message).Error
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), model.OutboxPending, message.Status)
+
+	// The failed attempt is retried later
+	now := time.Now().UTC()
+	n, err := suite.outbox.RelayDue(now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, n)
+	assert.Empty(suite.T(), suite.mails)
+
+	suite.mailErr = nil
+	n, err = suite.outbox.RelayDue(now.Add(time.Minute))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, n)
+
+	var user model.User
+	err = suite.db.Where("email = ?", "reset@example.com").First(&user).Error
+	suite.Require().NoError(err)
+	suite.Require().Len(suite.mails, 1)
+	assert.Contains(suite.T(), suite.mails[0], "reset@example.com")
+	assert.Contains(suite.T(), suite.mails[0], "token="+user.ResetPasswordToken)
+
+	err = suite.db.First(&message, message.ID).Error
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), model.OutboxPublished, message.Status)
+	assert.Equal(suite.T(), 2, message.Attempts)
+}
+
 // Run the test suite
 func TestAuthTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthTestSuite))
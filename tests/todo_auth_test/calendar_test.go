@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalendarFeedGolden tests the VEVENT and VTODO output of the feed
+func (suite *TodoTestSuite) TestCalendarFeedGolden() {
+	ids := suite.createGoldenTodos()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/calendar/feed", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var feed struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &feed))
+
+	for _, calendarType := range []string{"event", "both"} {
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/calendar/"+feed.Data.Token+".ics?type="+calendarType, nil)
+		w = httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusOK, w.Code, calendarType)
+		assert.Equal(suite.T(), "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		suite.assertGolden("calendar."+calendarType+".ics.golden", ids, w.Body.String())
+	}
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// addDependency makes todoID wait for blockedByID
+func (suite *TodoTestSuite) addDependency(todoID, blockedByID uint) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(dto.AddDependencyRequest{BlockedByID: blockedByID})
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/dependencies", todoID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// nextTitles returns the titles of GET /api/v1/todos/next in order
+func (suite *TodoTestSuite) nextTitles(query string) []string {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos/next"+query, nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Data []dto.TodoResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	titles := make([]string, len(response.Data))
+	for i, todo := range response.Data {
+		titles[i] = todo.Title
+	}
+	return titles
+}
+
+// TestDependencyCycles tests that links closing a cycle are rejected
+func (suite *TodoTestSuite) TestDependencyCycles() {
+	a := suite.createTestTodo("A", "", "medium")
+	b := suite.createTestTodo("B", "", "medium")
+	c := suite.createTestTodo("C", "", "medium")
+
+	w := suite.addDependency(a, a)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"dependency_cycle"`)
+
+	// A waits for B, B waits for C
+	assert.Equal(suite.T(), http.StatusCreated, suite.addDependency(a, b).Code)
+	assert.Equal(suite.T(), http.StatusCreated, suite.addDependency(b, c).Code)
+
+	w = suite.addDependency(a, b)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"dependency_exists"`)
+
+	for _, link := range [][2]uint{{b, a}, {c, a}, {c, b}} {
+		w = suite.addDependency(link[0], link[1])
+		assert.Equal(suite.T(), http.StatusConflict, w.Code, "%d -> %d", link[0], link[1])
+		assert.Contains(suite.T(), w.Body.String(), `"code":"dependency_cycle"`)
+	}
+
+	var count int64
+	suite.db.Model(&model.TodoDependency{}).Where("user_id = ?", suite.userID).Count(&count)
+	assert.Equal(suite.T(), int64(2), count)
+}
+
+// TestNextTodosOrder tests that todos come after their open blockers, with
+// priority breaking ties among the todos that are ready
+func (suite *TodoTestSuite) TestNextTodosOrder() {
+	deploy := suite.createTestTodo("Deploy", "", "high")
+	build := suite.createTestTodo("Build", "", "low")
+	test := suite.createTestTodo("Test", "", "medium")
+	suite.createTestTodo("Write docs", "", "medium")
+	suite.createTestTodo("Fix typo", "", "high")
+
+	suite.Require().Equal(http.StatusCreated, suite.addDependency(deploy, test).Code)
+	suite.Require().Equal(http.StatusCreated, suite.addDependency(test, build).Code)
+
+	assert.Equal(suite.T(), []string{"Fix typo", "Write docs", "Build", "Test", "Deploy"}, suite.nextTitles(""))
+	assert.Equal(suite.T(), []string{"Fix typo", "Write docs", "Build"}, suite.nextTitles("?ready=true"))
+
+	// A cycle recorded behind the service's back must not hide its todos
+	suite.Require().NoError(suite.db.Create(&model.TodoDependency{UserID: suite.userID, TodoID: build, BlockedByID: deploy}).Error)
+	assert.Equal(suite.T(), []string{"Fix typo", "Write docs", "Deploy", "Test", "Build"}, suite.nextTitles(""))
+}
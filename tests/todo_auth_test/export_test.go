@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"rest-api/internal/model"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// goldenIDs fill the IDs in golden files, which differ between runs
+type goldenIDs struct {
+	UserID uint
+	Todos  []uint
+}
+
+// createGoldenTodos stores todos with fixed timestamps: an all-day recurring
+// todo with text to escape, tags and a custom field, a completed timed todo
+// and one without a due date that the first one blocks
+func (suite *TodoTestSuite) createGoldenTodos() goldenIDs {
+	date := func(value string) *time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		suite.Require().NoError(err)
+		return &t
+	}
+
+	todos := []model.Todo{
+		{
+			Title:       "Pay rent, water; gas",
+			Description: "Line one\nLine two | \"quoted\" \\ done",
+			Status:      "pending",
+			Priority:    "high",
+			DueDate:     date("2099-03-09T00:00:00Z"),
+			DueAllDay:   true,
+			Recurrence:  "FREQ=MONTHLY;BYMONTHDAY=9",
+			Position:    "a0",
+			CreatedAt:   *date("2026-01-05T09:00:00Z"),
+			UpdatedAt:   *date("2026-01-06T10:30:00Z"),
+		},
+		{
+			Title:       "Call plumber",
+			Description: "Bring the spare key from the neighbour at number 12 and the old invoice",
+			Status:      "completed",
+			Priority:    "low",
+			DueDate:     date("2020-03-10T14:30:00Z"),
+			CompletedAt: date("2020-03-10T15:00:00Z"),
+			Position:    "a1",
+			CreatedAt:   *date("2026-01-04T08:00:00Z"),
+			UpdatedAt:   *date("2026-01-04T08:15:00Z"),
+		},
+		{
+			Title:     "Someday",
+			Status:    "pending",
+			Priority:  "medium",
+			Position:  "a2",
+			CreatedAt: *date("2026-01-03T07:00:00Z"),
+			UpdatedAt: *date("2026-01-03T07:00:00Z"),
+		},
+	}
+
+	for _, name := range []string{"bills", "home"} {
+		tag := model.Tag{UserID: suite.userID, Name: name}
+		suite.Require().NoError(suite.db.Where(tag).FirstOrCreate(&tag).Error)
+		todos[0].Tags = append(todos[0].Tags, tag)
+	}
+
+	ids := goldenIDs{UserID: suite.userID}
+	for i := range todos {
+		todos[i].UserID = suite.userID
+		suite.Require().NoError(suite.db.Create(&todos[i]).Error)
+		ids.Todos = append(ids.Todos, todos[i].ID)
+	}
+
+	field := model.CustomField{UserID: suite.userID, Key: "points", Name: "Points", Type: model.CustomFieldNumber}
+	suite.Require().NoError(suite.db.Create(&field).Error)
+	points := 3.0
+	suite.Require().NoError(suite.db.Create(&model.TodoFieldValue{TodoID: ids.Todos[0], FieldID: field.ID, NumberValue: &points}).Error)
+	suite.Require().NoError(suite.db.Create(&model.TodoDependency{UserID: suite.userID, TodoID: ids.Todos[2], BlockedByID: ids.Todos[0]}).Error)
+	return ids
+}
+
+// assertGolden compares output with testdata/<name>, a template of ids
+func (suite *TodoTestSuite) assertGolden(name string, ids goldenIDs, output string) {
+	golden, err := template.ParseFiles(filepath.Join("testdata", name))
+	suite.Require().NoError(err)
+	var want strings.Builder
+	suite.Require().NoError(golden.Execute(&want, ids))
+	assert.Equal(suite.T(), want.String(), output, name)
+}
+
+// TestExportGolden tests the CSV, JSON and Markdown exports byte for byte
+func (suite *TodoTestSuite) TestExportGolden() {
+	ids := suite.createGoldenTodos()
+
+	for _, format := range []string{"csv", "json", "md"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos/export?format="+format, nil)
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusOK, w.Code, format)
+		assert.Contains(suite.T(), w.Header().Get("Content-Disposition"), "."+format+`"`)
+		suite.assertGolden("export."+format+".golden", ids, w.Body.String())
+	}
+
+	// The export takes the filters and the order of the list
+	for query, titles := range map[string][]string{
+		"priority=low":                   {"Call plumber"},
+		"tags=home":                      {"Pay rent, water; gas"},
+		"open=true&sort=-created_at":     {"Pay rent, water; gas", "Someday"},
+		"q=plumber":                      {"Call plumber"},
+		"due=none":                       {"Someday"},
+		"cf.points=3":                    {"Pay rent, water; gas"},
+		"sort=title":                     {"Call plumber", "Pay rent, water; gas", "Someday"},
+		"status=pending&sort=-cf.points": {"Pay rent, water; gas", "Someday"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos/export?format=json&"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code, query)
+
+		var exported []map[string]interface{}
+		assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &exported), query)
+		var got []string
+		for _, todo := range exported {
+			got = append(got, todo["title"].(string))
+		}
+		assert.Equal(suite.T(), titles, got, query)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos/export?format=json&due=someday", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/middleware"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+	"rest-api/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// idempotentRouter serves POST /orders behind the idempotency middleware.
+// The handler answers with the number of times it ran and the status in
+// statuses, 201 when they run out; a "panic" body makes it panic.
+func (suite *TodoTestSuite) idempotentRouter(statuses ...int) (*gin.Engine, *int) {
+	calls := 0
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(middleware.IdempotencyMiddleware(repository.NewIdempotencyRepository(suite.db), time.Hour))
+	router.POST("/orders", func(c *gin.Context) {
+		calls++
+		var body map[string]interface{}
+		c.ShouldBindJSON(&body)
+		if body["item"] == "panic" {
+			panic("handler failed")
+		}
+		status := http.StatusCreated
+		if calls <= len(statuses) {
+			status = statuses[calls-1]
+		}
+		c.JSON(status, gin.H{"call": calls})
+	})
+	return router, &calls
+}
+
+// postIdempotent sends POST /orders with an Idempotency-Key
+func postIdempotent(router *gin.Engine, token, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestIdempotencyReplay tests that a retry replays the stored response
+func (suite *TodoTestSuite) TestIdempotencyReplay() {
+	router, calls := suite.idempotentRouter()
+
+	first := postIdempotent(router, suite.token, "order-1", `{"item":"book"}`)
+	assert.Equal(suite.T(), http.StatusCreated, first.Code)
+
+	retry := postIdempotent(router, suite.token, "order-1", `{"item":"book"}`)
+	assert.Equal(suite.T(), http.StatusCreated, retry.Code)
+	assert.Equal(suite.T(), "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(suite.T(), first.Body.String(), retry.Body.String())
+	assert.Equal(suite.T(), 1, *calls)
+
+	// The key is reused with another body
+	w := postIdempotent(router, suite.token, "order-1", `{"item":"pen"}`)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(suite.T(), 1, *calls)
+}
+
+// TestIdempotencyScopedPerUser tests that users do not share keys
+func (suite *TodoTestSuite) TestIdempotencyScopedPerUser() {
+	router, calls := suite.idempotentRouter()
+	otherToken, err := utils.GenerateToken(suite.userID+1000, "other")
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), http.StatusCreated, postIdempotent(router, suite.token, "order-1", `{"item":"book"}`).Code)
+	w := postIdempotent(router, otherToken, "order-1", `{"item":"book"}`)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Empty(suite.T(), w.Header().Get("Idempotent-Replayed"))
+	assert.Equal(suite.T(), 2, *calls)
+}
+
+// TestIdempotencyInProgress tests that a retry sent while the original
+// request is running is rejected, and that an abandoned one is taken over
+func (suite *TodoTestSuite) TestIdempotencyInProgress() {
+	router := gin.New()
+	router.Use(middleware.IdempotencyMiddleware(repository.NewIdempotencyRepository(suite.db), time.Hour))
+	var concurrent int
+	router.POST("/orders", func(c *gin.Context) {
+		if concurrent == 0 {
+			concurrent = postIdempotent(router, suite.token, "order-1", `{"item":"book"}`).Code
+		}
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	assert.Equal(suite.T(), http.StatusCreated, postIdempotent(router, suite.token, "order-1", `{"item":"book"}`).Code)
+	assert.Equal(suite.T(), http.StatusConflict, concurrent)
+
+	// The process handling the original request died before storing a response
+	err := suite.db.Create(&model.IdempotencyKey{
+		UserID:      suite.userID,
+		Key:         "order-2",
+		Method:      http.MethodPost,
+		Path:        "/orders",
+		RequestHash: "abandoned",
+		ExpiresAt:   time.Now().Add(time.Hour),
+		CreatedAt:   time.Now().Add(-10 * time.Minute),
+	}).Error
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), http.StatusCreated, postIdempotent(router, suite.token, "order-2", `{"item":"book"}`).Code)
+}
+
+// TestIdempotencyServerErrors tests that failed requests can be retried
+// with the same key
+func (suite *TodoTestSuite) TestIdempotencyServerErrors() {
+	router, calls := suite.idempotentRouter(http.StatusServiceUnavailable)
+
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, postIdempotent(router, suite.token, "order-1", `{"item":"book"}`).Code)
+	w := postIdempotent(router, suite.token, "order-1", `{"item":"book"}`)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Empty(suite.T(), w.Header().Get("Idempotent-Replayed"))
+	assert.Equal(suite.T(), 2, *calls)
+
+	// A panicking handler releases the key too
+	assert.Equal(suite.T(), http.StatusInternalServerError, postIdempotent(router, suite.token, "order-2", `{"item":"panic"}`).Code)
+	var count int64
+	suite.db.Model(&model.IdempotencyKey{}).Where("user_id = ? AND key = ?", suite.userID, "order-2").Count(&count)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
+// TestIdempotencyBodyLimit tests that oversized bodies are rejected
+func (suite *TodoTestSuite) TestIdempotencyBodyLimit() {
+	router, calls := suite.idempotentRouter()
+
+	body, _ := json.Marshal(map[string]string{"item": string(bytes.Repeat([]byte("a"), 11<<20))})
+	w := postIdempotent(router, suite.token, "order-1", string(body))
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(suite.T(), 0, *calls)
+}
+
+// TestIdempotencySkipsAnonymousAndAuth tests that anonymous clients do not
+// share keys and that responses of the auth routes, which hold tokens, are
+// never stored
+func (suite *TodoTestSuite) TestIdempotencySkipsAnonymousAndAuth() {
+	router, calls := suite.idempotentRouter()
+	logins := 0
+	router.POST("/api/v1/auth/login", func(c *gin.Context) {
+		logins++
+		c.JSON(http.StatusOK, gin.H{"token": "secret"})
+	})
+
+	for i := 0; i < 2; i++ {
+		w := postIdempotent(router, "", "order-1", `{"item":"book"}`)
+		assert.Equal(suite.T(), http.StatusCreated, w.Code)
+		assert.Empty(suite.T(), w.Header().Get("Idempotent-Replayed"))
+	}
+	assert.Equal(suite.T(), 2, *calls)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"a@example.com"}`))
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		req.Header.Set(middleware.IdempotencyKeyHeader, "login-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(suite.T(), http.StatusOK, w.Code)
+	}
+	assert.Equal(suite.T(), 2, logins)
+
+	var count int64
+	suite.db.Model(&model.IdempotencyKey{}).Count(&count)
+	assert.Equal(suite.T(), int64(0), count)
+}
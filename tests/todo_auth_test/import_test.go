@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+	"rest-api/internal/service"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImportRowErrors tests that invalid rows are reported by row number
+// while the valid ones are imported
+func (suite *TodoTestSuite) TestImportRowErrors() {
+	csv := "title,status,priority,due_date\n" +
+		"Write report,pending,high,2026-03-09\n" +
+		",pending,low,\n" +
+		"Call plumber,archived,low,\n" +
+		"Buy milk,pending,urgent,\n" +
+		"Plan trip,,,next week\n" +
+		"Pay rent,done,,2026-04-01T09:00:00Z\n"
+
+	importCSV := func(dryRun bool) dto.ImportResultResponse {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/import?format=csv&dry_run=%t", dryRun), strings.NewReader(csv))
+		req.Header.Set("Content-Type", "text/csv")
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusOK, w.Code)
+		var response struct {
+			Data dto.ImportResultResponse `json:"data"`
+		}
+		assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	preview := importCSV(true)
+	assert.Equal(suite.T(), 6, preview.Total)
+	assert.Equal(suite.T(), 2, preview.Valid)
+	assert.Equal(suite.T(), 0, preview.Created)
+	assert.Equal(suite.T(), 4, preview.Failed)
+	assert.Len(suite.T(), preview.Preview, 2)
+
+	var count int64
+	suite.db.Model(&model.Todo{}).Where("user_id = ?", suite.userID).Count(&count)
+	assert.Equal(suite.T(), int64(0), count)
+
+	result := importCSV(false)
+	assert.Equal(suite.T(), 2, result.Created)
+	assert.Equal(suite.T(), 4, result.Failed)
+	rows := make([]int, len(result.Errors))
+	for i, rowError := range result.Errors {
+		rows[i] = rowError.Row
+		assert.NotEmpty(suite.T(), rowError.Error)
+	}
+	assert.Equal(suite.T(), []int{2, 3, 4, 5}, rows)
+
+	var titles []string
+	suite.db.Model(&model.Todo{}).Where("user_id = ?", suite.userID).Order("title").Pluck("title", &titles)
+	assert.Equal(suite.T(), []string{"Pay rent", "Write report"}, titles)
+}
+
+// TestFailInterruptedImportJobs tests that jobs left behind by a stopped
+// process are failed, and that jobs still making progress are not
+func (suite *TodoTestSuite) TestFailInterruptedImportJobs() {
+	now := time.Now()
+	jobs := []model.ImportJob{
+		{UserID: suite.userID, Format: "csv", Status: model.ImportJobRunning, Total: 500, Processed: 150, Errors: "[]", UpdatedAt: now.Add(-time.Hour)},
+		{UserID: suite.userID, Format: "csv", Status: model.ImportJobQueued, Total: 500, Errors: "[]", UpdatedAt: now.Add(-time.Hour)},
+		{UserID: suite.userID, Format: "csv", Status: model.ImportJobRunning, Total: 500, Processed: 50, Errors: "[]", UpdatedAt: now},
+		{UserID: suite.userID, Format: "csv", Status: model.ImportJobCompleted, Total: 10, Processed: 10, Errors: "[]", UpdatedAt: now.Add(-time.Hour)},
+	}
+	for i := range jobs {
+		suite.Require().NoError(suite.db.Create(&jobs[i]).Error)
+	}
+	defer suite.db.Exec("DELETE FROM import_jobs WHERE user_id = ?", suite.userID)
+
+	imports := service.NewImportService(nil, repository.NewImportJobRepository(suite.db))
+	count, err := imports.FailInterruptedJobs()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), count)
+
+	// Progress is kept so the client sees how far the import got
+	for i, status := range []string{model.ImportJobFailed, model.ImportJobFailed, model.ImportJobRunning, model.ImportJobCompleted} {
+		job, err := imports.GetImportJob(jobs[i].ID, suite.userID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), status, job.Status, "job %d", i)
+		assert.Equal(suite.T(), jobs[i].Processed, job.Processed, "job %d", i)
+		assert.Equal(suite.T(), status == model.ImportJobFailed, job.FinishedAt != nil, "job %d", i)
+	}
+}
+
+// TestImportJobSweep tests that the import worker fails stale jobs when it
+// starts, before waiting for the next sweep
+func (suite *TodoTestSuite) TestImportJobSweep() {
+	job := model.ImportJob{UserID: suite.userID, Format: "csv", Status: model.ImportJobRunning, Total: 500, Processed: 150, Errors: "[]", UpdatedAt: time.Now().Add(-time.Hour)}
+	suite.Require().NoError(suite.db.Create(&job).Error)
+	defer suite.db.Exec("DELETE FROM import_jobs WHERE user_id = ?", suite.userID)
+
+	imports := service.NewImportService(nil, repository.NewImportJobRepository(suite.db))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	imports.Run(ctx)
+
+	stored, err := imports.GetImportJob(job.ID, suite.userID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), model.ImportJobFailed, stored.Status)
+}
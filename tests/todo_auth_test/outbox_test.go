@@ -0,0 +1,272 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+	"rest-api/internal/repository"
+	"rest-api/internal/service"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWebhookPayloadSnapshot tests that webhook payloads hold the todo as
+// each change left it, even when the outbox is relayed after later changes
+func (suite *TodoTestSuite) TestWebhookPayloadSnapshot() {
+	webhook, err := suite.webhooks.CreateWebhook(suite.userID, dto.CreateWebhookRequest{
+		URL:    "http://127.0.0.1:9/hook",
+		Events: []string{"todo.created", "todo.updated", "todo.deleted"},
+	})
+	suite.Require().NoError(err)
+
+	todoID := suite.createTestTodo("Draft", "", "low")
+	jsonBody, _ := json.Marshal(map[string]interface{}{"title": "Final", "tags": []string{"release"}})
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/todos/%d", todoID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/todos/%d", todoID), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	n, err := suite.outbox.RelayDue(time.Now().UTC())
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 3, n)
+
+	var deliveries []model.WebhookDelivery
+	suite.db.Where("webhook_id = ?", webhook.ID).Order("id").Find(&deliveries)
+	suite.Require().Len(deliveries, 3)
+
+	titles := make(map[string]*string)
+	tags := make(map[string][]string)
+	for _, delivery := range deliveries {
+		var payload struct {
+			Data dto.WebhookTodoData `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal([]byte(delivery.Payload), &payload))
+		assert.Equal(suite.T(), todoID, payload.Data.TodoID)
+		titles[delivery.Event] = nil
+		if payload.Data.Todo != nil {
+			titles[delivery.Event] = &payload.Data.Todo.Title
+			tags[delivery.Event] = payload.Data.Todo.Tags
+		}
+	}
+	if assert.NotNil(suite.T(), titles["todo.created"]) {
+		assert.Equal(suite.T(), "Draft", *titles["todo.created"])
+	}
+	if assert.NotNil(suite.T(), titles["todo.updated"]) {
+		assert.Equal(suite.T(), "Final", *titles["todo.updated"])
+	}
+	assert.Empty(suite.T(), tags["todo.created"])
+	assert.Equal(suite.T(), []string{"release"}, tags["todo.updated"])
+	assert.Contains(suite.T(), titles, "todo.deleted")
+	assert.Nil(suite.T(), titles["todo.deleted"])
+}
+
+// TestOutboxRelayEventsOnce tests that a todo event relayed again, as after
+// a relay stopped before recording it, is not queued for webhooks twice
+func (suite *TodoTestSuite) TestOutboxRelayEventsOnce() {
+	_, err := suite.webhooks.CreateWebhook(suite.userID, dto.CreateWebhookRequest{
+		URL:    "http://127.0.0.1:9/hook",
+		Events: []string{"todo.created"},
+	})
+	suite.Require().NoError(err)
+	suite.createTestTodo("Ship release", "", "high")
+
+	now := time.Now().UTC()
+	n, err := suite.outbox.RelayDue(now)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, n)
+
+	suite.db.Model(&model.OutboxMessage{}).Where("1 = 1").Updates(map[string]interface{}{"status": model.OutboxPending, "next_attempt_at": now})
+	n, err = suite.outbox.RelayDue(now.Add(time.Second))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, n)
+
+	var count int64
+	suite.db.Model(&model.WebhookDelivery{}).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+// writeOutboxMessage writes a due test message to the outbox
+func (suite *TodoTestSuite) writeOutboxMessage(topic, dedupKey string, at time.Time) *model.OutboxMessage {
+	message := &model.OutboxMessage{Topic: topic, DedupKey: dedupKey, Payload: "{}", Status: model.OutboxPending, NextAttemptAt: &at}
+	written, err := repository.NewOutboxRepository(suite.db).Create(message)
+	suite.Require().NoError(err)
+	suite.Require().True(written)
+	return message
+}
+
+// outboxMessage reloads a message
+func (suite *TodoTestSuite) outboxMessage(id uint) model.OutboxMessage {
+	var message model.OutboxMessage
+	suite.Require().NoError(suite.db.First(&message, id).Error)
+	return message
+}
+
+// TestOutboxRelayRetries tests that failed messages are retried with
+// backoff and published once their handler succeeds
+func (suite *TodoTestSuite) TestOutboxRelayRetries() {
+	relay := service.NewOutboxRelay(repository.NewOutboxRepository(suite.db))
+	failures := 2
+	var handled []uint
+	relay.Handle("test.flaky", func(message *model.OutboxMessage) error {
+		handled = append(handled, message.ID)
+		if failures > 0 {
+			failures--
+			return errors.New("receiver unavailable")
+		}
+		return nil
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	message := suite.writeOutboxMessage("test.flaky", "flaky:1", now)
+
+	n, err := relay.RelayDue(now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, n)
+	stored := suite.outboxMessage(message.ID)
+	assert.Equal(suite.T(), model.OutboxPending, stored.Status)
+	assert.Equal(suite.T(), 1, stored.Attempts)
+	assert.Equal(suite.T(), "receiver unavailable", stored.Error)
+	suite.Require().NotNil(stored.NextAttemptAt)
+	assert.True(suite.T(), now.Add(5*time.Second).Equal(*stored.NextAttemptAt), "next attempt %s", stored.NextAttemptAt)
+
+	// Not due before the backoff, which doubles after the second failure
+	n, _ = relay.RelayDue(now.Add(4 * time.Second))
+	assert.Equal(suite.T(), 0, n)
+	n, _ = relay.RelayDue(now.Add(5 * time.Second))
+	assert.Equal(suite.T(), 1, n)
+	stored = suite.outboxMessage(message.ID)
+	suite.Require().NotNil(stored.NextAttemptAt)
+	assert.True(suite.T(), now.Add(15*time.Second).Equal(*stored.NextAttemptAt), "next attempt %s", stored.NextAttemptAt)
+
+	n, _ = relay.RelayDue(now.Add(15 * time.Second))
+	assert.Equal(suite.T(), 1, n)
+	stored = suite.outboxMessage(message.ID)
+	assert.Equal(suite.T(), model.OutboxPublished, stored.Status)
+	assert.Equal(suite.T(), 3, stored.Attempts)
+	assert.Empty(suite.T(), stored.Error)
+	assert.Nil(suite.T(), stored.NextAttemptAt)
+	assert.NotNil(suite.T(), stored.PublishedAt)
+
+	n, _ = relay.RelayDue(now.Add(time.Hour))
+	assert.Equal(suite.T(), 0, n)
+	assert.Equal(suite.T(), []uint{message.ID, message.ID, message.ID}, handled)
+}
+
+// TestOutboxRelayDeadLetters tests that messages failing every attempt, or
+// without a handler, end up dead instead of being retried forever
+func (suite *TodoTestSuite) TestOutboxRelayDeadLetters() {
+	relay := service.NewOutboxRelay(repository.NewOutboxRepository(suite.db))
+	relay.Handle("test.broken", func(*model.OutboxMessage) error {
+		return errors.New("always fails")
+	})
+
+	now := time.Now().UTC()
+	broken := suite.writeOutboxMessage("test.broken", "broken:1", now)
+	orphan := suite.writeOutboxMessage("test.unknown", "unknown:1", now)
+
+	// The backoff is capped at 30 minutes
+	for i := 0; i < 50; i++ {
+		_, err := relay.RelayDue(now)
+		suite.Require().NoError(err)
+		now = now.Add(31 * time.Minute)
+	}
+
+	stored := suite.outboxMessage(broken.ID)
+	assert.Equal(suite.T(), model.OutboxDead, stored.Status)
+	assert.Equal(suite.T(), 20, stored.Attempts)
+	assert.Equal(suite.T(), "always fails", stored.Error)
+	assert.Nil(suite.T(), stored.NextAttemptAt)
+	assert.Nil(suite.T(), stored.PublishedAt)
+
+	stored = suite.outboxMessage(orphan.ID)
+	assert.Equal(suite.T(), model.OutboxDead, stored.Status)
+	assert.Contains(suite.T(), stored.Error, `no handler for topic "test.unknown"`)
+}
+
+// TestOutboxRelayLease tests that a claimed message is left to its relay
+// until the lease runs out, then handled again by another one
+func (suite *TodoTestSuite) TestOutboxRelayLease() {
+	outboxRepo := repository.NewOutboxRepository(suite.db)
+	relay := service.NewOutboxRelay(outboxRepo)
+	handled := 0
+	relay.Handle("test.lease", func(*model.OutboxMessage) error {
+		handled++
+		return nil
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	message := suite.writeOutboxMessage("test.lease", "lease:1", now)
+
+	// Another relay claims the message and stops before recording it
+	claimed, err := outboxRepo.ClaimDue(now, now.Add(time.Minute), 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(claimed, 1)
+	claimed, err = outboxRepo.ClaimDue(now, now.Add(time.Minute), 10)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), claimed)
+
+	n, err := relay.RelayDue(now.Add(59 * time.Second))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 0, n)
+	assert.Equal(suite.T(), 0, handled)
+
+	n, err = relay.RelayDue(now.Add(time.Minute))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, n)
+	assert.Equal(suite.T(), 1, handled)
+	assert.Equal(suite.T(), model.OutboxPublished, suite.outboxMessage(message.ID).Status)
+}
+
+// TestOutboxDedupAndPurge tests that a side effect is written once per dedup
+// key and that only old published messages are purged
+func (suite *TodoTestSuite) TestOutboxDedupAndPurge() {
+	outboxRepo := repository.NewOutboxRepository(suite.db)
+	relay := service.NewOutboxRelay(outboxRepo)
+	relay.Handle("test.purge", func(*model.OutboxMessage) error { return nil })
+
+	now := time.Now().UTC()
+	published := suite.writeOutboxMessage("test.purge", "purge:1", now)
+	written, err := outboxRepo.Create(&model.OutboxMessage{Topic: "test.purge", DedupKey: "purge:1", Payload: "{}", Status: model.OutboxPending, NextAttemptAt: &now})
+	suite.Require().NoError(err)
+	assert.False(suite.T(), written)
+
+	n, err := relay.RelayDue(now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, n)
+
+	later := now.Add(time.Hour)
+	pending := suite.writeOutboxMessage("test.purge", "purge:2", later.Add(time.Hour))
+	dead := suite.writeOutboxMessage("test.purge", "purge:3", later)
+	suite.db.Model(&model.OutboxMessage{}).Where("id = ?", dead.ID).Update("status", model.OutboxDead)
+	recent := suite.writeOutboxMessage("test.purge", "purge:4", later)
+	_, err = relay.RelayDue(later)
+	suite.Require().NoError(err)
+
+	purged, err := relay.Purge(now.Add(time.Minute))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), purged)
+
+	var ids []uint
+	suite.db.Model(&model.OutboxMessage{}).Order("id").Pluck("id", &ids)
+	assert.NotContains(suite.T(), ids, published.ID)
+	assert.Equal(suite.T(), []uint{pending.ID, dead.ID, recent.ID}, ids)
+
+	// Once purged, the dedup key can be written again
+	written, err = outboxRepo.Create(&model.OutboxMessage{Topic: "test.purge", DedupKey: "purge:1", Payload: "{}", Status: model.OutboxPending, NextAttemptAt: &later})
+	suite.Require().NoError(err)
+	assert.True(suite.T(), written)
+}
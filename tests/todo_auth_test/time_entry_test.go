@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+	"rest-api/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timeRequest sends an authenticated time tracking request
+func (suite *TodoTestSuite) timeRequest(method, path string, body interface{}) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&payload).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// TestTimerStartStop tests that a user runs one timer at a time
+func (suite *TodoTestSuite) TestTimerStartStop() {
+	first := suite.createTestTodo("Write report", "", "high")
+	second := suite.createTestTodo("Review report", "", "medium")
+
+	w := suite.timeRequest(http.MethodPost, "/api/v1/time-entries/stop", nil)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"no_timer_running"`)
+
+	w = suite.timeRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/timer/start", first), nil)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	// Another timer, on the same or another todo, is rejected
+	for _, todoID := range []uint{first, second} {
+		w = suite.timeRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/timer/start", todoID), nil)
+		assert.Equal(suite.T(), http.StatusConflict, w.Code)
+		assert.Contains(suite.T(), w.Body.String(), `"code":"timer_running"`)
+	}
+
+	w = suite.timeRequest(http.MethodPost, "/api/v1/time-entries/stop", nil)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var response struct {
+		Data dto.TimeEntryResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), first, response.Data.TodoID)
+	assert.False(suite.T(), response.Data.Running)
+	assert.NotNil(suite.T(), response.Data.EndedAt)
+
+	w = suite.timeRequest(http.MethodPost, "/api/v1/time-entries/stop", nil)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// Once stopped, a new timer can start
+	w = suite.timeRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/timer/start", second), nil)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+}
+
+// TestTimeSummaryDays tests that entries are bucketed by their start date in
+// the user's time zone rather than in UTC
+func (suite *TodoTestSuite) TestTimeSummaryDays() {
+	suite.Require().NoError(suite.db.Model(&model.User{}).Where("id = ?", suite.userID).Update("time_zone", "Asia/Jakarta").Error)
+	defer suite.db.Model(&model.User{}).Where("id = ?", suite.userID).Update("time_zone", "UTC")
+
+	todoID := suite.createTestTodo("Write report", "", "high")
+	entries := []struct {
+		startedAt string
+		minutes   int
+		day       string
+	}{
+		// 23:30 in Jakarta
+		{"2026-03-09T16:30:00Z", 60, "2026-03-09"},
+		// 00:30 in Jakarta, still March 9 in UTC
+		{"2026-03-09T17:30:00Z", 30, "2026-03-10"},
+		{"2026-03-10T08:00:00+07:00", 15, "2026-03-10"},
+	}
+	for _, entry := range entries {
+		w := suite.timeRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/time-entries", todoID), dto.CreateTimeEntryRequest{
+			StartedAt:       entry.startedAt,
+			DurationMinutes: entry.minutes,
+		})
+		suite.Require().Equal(http.StatusCreated, w.Code)
+		var response struct {
+			Data dto.TimeEntryResponse `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(suite.T(), entry.day, response.Data.Day, entry.startedAt)
+	}
+
+	w := suite.timeRequest(http.MethodGet, "/api/v1/time-entries/summary?from=2026-03-09&to=2026-03-10", nil)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var response struct {
+		Data dto.TimeSummaryResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), int64(105*60), response.Data.TotalSeconds)
+	assert.Equal(suite.T(), []dto.DayTimeResponse{
+		{Day: "2026-03-09", Seconds: 60 * 60},
+		{Day: "2026-03-10", Seconds: 45 * 60},
+	}, response.Data.ByDay)
+
+	// The range is in local days too
+	w = suite.timeRequest(http.MethodGet, "/api/v1/time-entries/summary?from=2026-03-10&to=2026-03-10", nil)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), int64(45*60), response.Data.TotalSeconds)
+}
@@ -4,17 +4,23 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"rest-api/internal/config"
 	"rest-api/internal/dto"
+	"rest-api/internal/event"
+	"rest-api/internal/graph"
 	"rest-api/internal/handler"
 	"rest-api/internal/middleware"
 	"rest-api/internal/model"
 	"rest-api/internal/repository"
 	"rest-api/internal/route"
 	"rest-api/internal/service"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -26,10 +32,12 @@ import (
 // TodoTestSuite defines the test suite
 type TodoTestSuite struct {
 	suite.Suite
-	db     *gorm.DB
-	router *gin.Engine
-	token  string
-	userID uint
+	db       *gorm.DB
+	router   *gin.Engine
+	token    string
+	userID   uint
+	webhooks *service.WebhookService
+	outbox   *service.OutboxRelay
 }
 
 // SetupSuite runs once before all tests
@@ -43,29 +51,57 @@ func (suite *TodoTestSuite) SetupSuite() {
 		" password=" + cfg.DBPassword +
 		" dbname=" + cfg.DBName +
 		" port=" + cfg.DBPort +
-		" sslmode=disable TimeZone=Asia/Jakarta"
+		" sslmode=disable TimeZone=UTC"
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	suite.Require().NoError(err)
 
 	suite.db = db
 
-	err = db.AutoMigrate(&model.User{}, &model.Todo{})
+	err = db.AutoMigrate(&model.User{}, &model.Tag{}, &model.Todo{}, &model.IdempotencyKey{}, &model.ImportJob{}, &model.CalendarFeed{}, &model.Workflow{}, &model.CustomField{}, &model.TodoFieldValue{}, &model.TodoDependency{}, &model.TimeEntry{}, &model.SavedView{}, &model.TodoTemplate{}, &model.Webhook{}, &model.WebhookDelivery{}, &model.OutboxMessage{})
 	suite.Require().NoError(err)
 
 	// Initialize dependencies
 	userRepo := repository.NewUserRepository(db)
 	todoRepo := repository.NewTodoRepository(db)
-	authService := service.NewAuthService(userRepo)
-	todoService := service.NewTodoService(todoRepo)
+	importJobRepo := repository.NewImportJobRepository(db)
+	workflowRepo := repository.NewWorkflowRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	dependencyRepo := repository.NewDependencyRepository(db)
+	suite.outbox = service.NewOutboxRelay(repository.NewOutboxRepository(db))
+	authService := service.NewAuthService(userRepo, suite.outbox)
+	eventBus := event.NewBus(100)
+	todoService := service.NewTodoService(todoRepo, userRepo, workflowRepo, customFieldRepo, dependencyRepo, suite.outbox)
+	importService := service.NewImportService(todoService, importJobRepo)
+	dependencyService := service.NewDependencyService(dependencyRepo, todoService)
+	schema, err := graph.NewSchema(todoService, authService, dependencyService, graph.Limits{MaxDepth: 10, MaxComplexity: 2500})
+	suite.Require().NoError(err)
 	userHandler := handler.NewUserHandler(authService)
 	todoHandler := handler.NewTodoHandler(todoService)
+	importHandler := handler.NewImportHandler(importService)
+	calendarHandler := handler.NewCalendarHandler(service.NewCalendarService(repository.NewCalendarFeedRepository(db), todoRepo, workflowRepo))
+	workflowHandler := handler.NewWorkflowHandler(service.NewWorkflowService(workflowRepo, todoRepo))
+	customFieldHandler := handler.NewCustomFieldHandler(service.NewCustomFieldService(customFieldRepo))
+	dependencyHandler := handler.NewDependencyHandler(dependencyService, todoService)
+	timeEntryHandler := handler.NewTimeEntryHandler(service.NewTimeEntryService(repository.NewTimeEntryRepository(db), todoService))
+	savedViewHandler := handler.NewSavedViewHandler(service.NewSavedViewService(repository.NewSavedViewRepository(db), todoService), todoService)
+	templateHandler := handler.NewTemplateHandler(service.NewTemplateService(repository.NewTemplateRepository(db), todoService), todoService)
+	graphqlHandler := handler.NewGraphQLHandler(schema)
+	eventHandler := handler.NewEventHandler(eventBus, todoService)
+	// Webhooks of the tests call httptest receivers on the loopback address
+	suite.webhooks = service.NewWebhookService(repository.NewWebhookRepository(db), todoService, true)
+	suite.outbox.Handle(service.TopicTodoEvent, service.RelayTodoEvents(suite.webhooks, eventBus))
+	webhookHandler := handler.NewWebhookHandler(suite.webhooks)
 	healthHandler := handler.NewHealthHandler(db)
 
 	router := gin.New()
+	docsHandler := handler.NewDocsHandler(router)
 	router.Use(middleware.LoggerMiddleware())
 	router.Use(middleware.CORSMiddleware())
-	route.SetupRoutes(router, userHandler, healthHandler, todoHandler)
+	router.Use(middleware.LocaleMiddleware(userRepo))
+	router.Use(middleware.NegotiationMiddleware(router))
+	router.Use(middleware.ValidationMiddleware(router, true))
+	route.SetupRoutes(router, userHandler, healthHandler, todoHandler, importHandler, calendarHandler, workflowHandler, customFieldHandler, dependencyHandler, timeEntryHandler, savedViewHandler, templateHandler, graphqlHandler, eventHandler, webhookHandler, docsHandler)
 
 	suite.router = router
 
@@ -118,6 +154,17 @@ func (suite *TodoTestSuite) TearDownSuite() {
 
 // SetupTest runs before each test
 func (suite *TodoTestSuite) SetupTest() {
+	suite.db.Exec("DELETE FROM todo_field_values")
+	suite.db.Exec("DELETE FROM time_entries WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM todo_dependencies WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM custom_fields WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM saved_views WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM calendar_feeds WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM todo_templates WHERE user_id = ?", suite.userID)
+	suite.db.Exec("DELETE FROM idempotency_keys")
+	suite.db.Exec("DELETE FROM outbox_messages")
+	suite.db.Exec("DELETE FROM webhook_deliveries")
+	suite.db.Exec("DELETE FROM webhooks WHERE user_id = ?", suite.userID)
 	suite.db.Exec("DELETE FROM todos WHERE user_id = ?", suite.userID)
 }
 
@@ -245,6 +292,378 @@ func (suite *TodoTestSuite) TestDeleteTodo() {
 	assert.Error(suite.T(), err) // Should not find (soft deleted)
 }
 
+// TestBulkTodos tests running several operations in one request
+func (suite *TodoTestSuite) TestBulkTodos() {
+	todoID := suite.createTestTodo("Bulk Existing", "pending", "low")
+
+	reqBody := dto.BulkTodoRequest{
+		Mode: "best_effort",
+		Operations: []dto.BulkTodoOperation{
+			{Op: "create", Create: &dto.CreateTodoRequest{Title: "Bulk New", Status: "pending", Priority: "high"}},
+			{Op: "delete", ID: todoID},
+			{Op: "delete", ID: todoID},
+		},
+	}
+	jsonBody, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos/bulk", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusMultiStatus, w.Code)
+
+	var response struct {
+		Data dto.BulkTodoResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, response.Data.Succeeded)
+	assert.Equal(suite.T(), 1, response.Data.Failed)
+	assert.Equal(suite.T(), http.StatusCreated, response.Data.Results[0].Status)
+	assert.Equal(suite.T(), http.StatusNotFound, response.Data.Results[2].Status)
+}
+
+// TestQuickAddTodo tests previewing a todo parsed from one line of text
+func (suite *TodoTestSuite) TestQuickAddTodo() {
+	jsonBody, _ := json.Marshal(dto.QuickAddTodoRequest{Text: "Send invoice tomorrow 5pm !high #finance every month"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos/quick?dry_run=true", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.QuickAddTodoResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Send invoice", response.Data.Parsed.Title)
+	assert.Equal(suite.T(), "high", response.Data.Parsed.Priority)
+	assert.Equal(suite.T(), []string{"finance"}, response.Data.Parsed.Tags)
+	assert.Equal(suite.T(), "FREQ=MONTHLY", response.Data.Parsed.Recurrence)
+	assert.NotNil(suite.T(), response.Data.Parsed.DueDate)
+	assert.Nil(suite.T(), response.Data.Todo)
+}
+
+// TestCustomFields tests setting, filtering and sorting by typed custom fields
+func (suite *TodoTestSuite) TestCustomFields() {
+	jsonBody, _ := json.Marshal(dto.CreateCustomFieldRequest{Key: "size", Name: "Size", Type: "select", Options: []string{"s", "m", "l"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/custom-fields", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	for _, size := range []string{"s", "l", "x"} {
+		jsonBody, _ := json.Marshal(dto.CreateTodoRequest{
+			Title:        "Size " + size,
+			Priority:     "medium",
+			CustomFields: map[string]interface{}{"size": size},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+suite.token)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		if size == "x" {
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+		} else {
+			assert.Equal(suite.T(), http.StatusCreated, w.Code)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/todos?cf.size=l&sort=-cf.size", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []dto.TodoResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "l", response.Data[0].CustomFields["size"])
+}
+
+// TestMoveTodo tests moving a todo to another board column and position
+func (suite *TodoTestSuite) TestMoveTodo() {
+	firstID := suite.createTestTodo("First", "pending", "low")
+	secondID := suite.createTestTodo("Second", "pending", "low")
+
+	status := "in_progress"
+	jsonBody, _ := json.Marshal(dto.MoveTodoRequest{AfterID: &firstID, Status: &status})
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/todos/%d/move", secondID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/board", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.BoardResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	for _, column := range response.Data.Columns {
+		switch column.Key {
+		case "pending":
+			assert.Len(suite.T(), column.Todos, 1)
+		case "in_progress":
+			assert.Len(suite.T(), column.Todos, 1)
+			assert.Equal(suite.T(), secondID, column.Todos[0].ID)
+		}
+	}
+}
+
+func (suite *TodoTestSuite) TestStats() {
+	suite.createTestTodo("Open", "pending", "high")
+	suite.createTestTodo("Done", "completed", "low")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data dto.StatsResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), response.Data.Total)
+	assert.Equal(suite.T(), int64(1), response.Data.ByStatus["completed"])
+	assert.Equal(suite.T(), int64(1), response.Data.ByPriority["high"])
+	assert.Equal(suite.T(), 0.5, response.Data.Completion.Rate)
+	assert.Len(suite.T(), response.Data.CompletedPerDay, 7)
+	assert.Equal(suite.T(), int64(1), response.Data.Streaks.Current.Days)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/stats?from=2024-01-10&to=2024-01-01", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *TodoTestSuite) TestSavedViews() {
+	suite.createTestTodo("Write report", "pending", "high")
+	suite.createTestTodo("Buy milk", "pending", "low")
+
+	jsonBody, _ := json.Marshal(dto.CreateSavedViewRequest{Name: "Urgent", Filter: dto.TodoListQuery{Priority: "high", Open: true}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/views", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var created struct {
+		Data dto.SavedViewResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &created)
+	assert.NoError(suite.T(), err)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/views/%d/todos", created.Data.ID), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response struct {
+		Data []dto.TodoResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Data, 1)
+	assert.Equal(suite.T(), "Write report", response.Data[0].Title)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/views/overdue/todos", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *TodoTestSuite) TestInstantiateTemplate() {
+	offset := 1
+	jsonBody, _ := json.Marshal(dto.CreateTemplateRequest{
+		Name: "Release",
+		Items: []dto.TemplateItem{
+			{Title: "Freeze {{version}}"},
+			{Title: "Publish {{version}}", DueOffsetDays: &offset},
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/templates", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var created struct {
+		Data dto.TemplateResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &created)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"version"}, created.Data.Placeholders)
+
+	jsonBody, _ = json.Marshal(dto.InstantiateTemplateRequest{BaseDate: "2030-01-10", Values: map[string]string{"version": "2.0"}})
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/templates/%d/instantiate", created.Data.ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var response struct {
+		Data []dto.TodoResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Data, 2)
+	assert.Equal(suite.T(), "Freeze 2.0", response.Data[0].Title)
+	assert.Equal(suite.T(), "2030-01-11", response.Data[1].DueDate.Format("2006-01-02"))
+}
+
+func (suite *TodoTestSuite) TestWebhooks() {
+	var mu sync.Mutex
+	var received []*http.Request
+	var bodies [][]byte
+	status := http.StatusInternalServerError
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	secret := "test-secret-0123456789"
+	jsonBody, _ := json.Marshal(dto.CreateWebhookRequest{URL: receiver.URL, Events: []string{"todo.created"}, Secret: secret})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var created struct {
+		Data dto.WebhookResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &created)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), secret, created.Data.Secret)
+
+	// The event is queued for the webhook when the outbox is relayed
+	todoID := suite.createTestTodo("Ship release", "pending", "high")
+	n, err := suite.outbox.RelayDue(time.Now().UTC())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, n)
+
+	// The receiver fails the first attempt, which is retried after a minute
+	now := time.Now().UTC()
+	n, err = suite.webhooks.DeliverDue(now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, n)
+	n, err = suite.webhooks.DeliverDue(now.Add(30 * time.Second))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, n)
+
+	mu.Lock()
+	status = http.StatusNoContent
+	mu.Unlock()
+	n, err = suite.webhooks.DeliverDue(now.Add(2 * time.Minute))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, n)
+
+	mu.Lock()
+	requests, payloads := received, bodies
+	mu.Unlock()
+	suite.Require().Len(requests, 2)
+	first, retry := requests[0], requests[1]
+	assert.Equal(suite.T(), "todo.created", retry.Header.Get("X-Webhook-Event"))
+	assert.Equal(suite.T(), first.Header.Get("X-Webhook-ID"), retry.Header.Get("X-Webhook-ID"))
+	timestamp, err := strconv.ParseInt(retry.Header.Get("X-Webhook-Timestamp"), 10, 64)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), service.SignWebhook(secret, timestamp, payloads[1]), retry.Header.Get("X-Webhook-Signature"))
+
+	var payload struct {
+		Type string              `json:"type"`
+		Data dto.WebhookTodoData `json:"data"`
+	}
+	err = json.Unmarshal(payloads[1], &payload)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "todo.created", payload.Type)
+	assert.Equal(suite.T(), todoID, payload.Data.TodoID)
+	assert.Equal(suite.T(), "Ship release", payload.Data.Todo.Title)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/webhooks/%d/deliveries", created.Data.ID), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var deliveries struct {
+		Data []dto.WebhookDeliveryResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &deliveries)
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(deliveries.Data, 1)
+	assert.Equal(suite.T(), "succeeded", deliveries.Data[0].Status)
+	assert.Equal(suite.T(), 2, deliveries.Data[0].Attempts)
+	assert.Equal(suite.T(), http.StatusNoContent, *deliveries.Data[0].ResponseStatus)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/webhooks/%d/deliveries/%d/redeliver", created.Data.ID, deliveries.Data[0].ID), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/webhooks/%d/ping", created.Data.ID), nil)
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var ping struct {
+		Data dto.WebhookDeliveryResponse `json:"data"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &ping)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ping", ping.Data.Event)
+	assert.Equal(suite.T(), "succeeded", ping.Data.Status)
+}
+
 // Helper function to create test todo
 func (suite *TodoTestSuite) createTestTodo(title, status, priority string) uint {
 	reqBody := dto.CreateTodoRequest{
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"rest-api/internal/dto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// putWorkflow replaces the workflow of the test user
+func (suite *TodoTestSuite) putWorkflow(workflow dto.WorkflowRequest) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(workflow)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/workflow", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// setStatus moves a todo to a status and returns the response
+func (suite *TodoTestSuite) setStatus(todoID uint, status string) (*httptest.ResponseRecorder, dto.TodoResponse) {
+	jsonBody, _ := json.Marshal(map[string]string{"status": status})
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/todos/%d", todoID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+suite.token)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	var response struct {
+		Data dto.TodoResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response.Data
+}
+
+// TestWorkflowTransitions tests that a custom workflow limits status changes
+// and stamps when work on a todo started and completed
+func (suite *TodoTestSuite) TestWorkflowTransitions() {
+	defer suite.db.Exec("DELETE FROM workflows WHERE user_id = ?", suite.userID)
+
+	workflow := dto.WorkflowRequest{
+		Statuses: []dto.WorkflowStatus{
+			{Key: "backlog", Name: "Backlog", Category: "todo"},
+			{Key: "doing", Name: "Doing", Category: "in_progress"},
+			{Key: "review", Name: "Review", Category: "in_progress"},
+			{Key: "done", Name: "Done", Category: "done"},
+		},
+		Transitions: map[string][]string{
+			"backlog": {"doing"},
+			"doing":   {"review", "backlog"},
+			"review":  {"done", "doing"},
+			"done":    {"backlog"},
+		},
+		Initial: "backlog",
+	}
+
+	invalid := workflow
+	invalid.Initial = "todo"
+	w := suite.putWorkflow(invalid)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"invalid_workflow"`)
+
+	w = suite.putWorkflow(workflow)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	todoID := suite.createTestTodo("Ship release", "", "high")
+
+	// Skipping the review step is rejected
+	w, _ = suite.setStatus(todoID, "done")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"invalid_transition"`)
+	w, _ = suite.setStatus(todoID, "pending")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	w, todo := suite.setStatus(todoID, "doing")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NotNil(todo.StartedAt)
+	assert.Nil(suite.T(), todo.CompletedAt)
+	started := *todo.StartedAt
+
+	// Moving between in_progress statuses keeps the start
+	w, todo = suite.setStatus(todoID, "review")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NotNil(todo.StartedAt)
+	assert.True(suite.T(), started.Equal(*todo.StartedAt))
+
+	w, todo = suite.setStatus(todoID, "done")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NotNil(todo.CompletedAt)
+	assert.True(suite.T(), started.Equal(*todo.StartedAt))
+
+	// Reopening clears both
+	w, todo = suite.setStatus(todoID, "backlog")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	assert.Nil(suite.T(), todo.StartedAt)
+	assert.Nil(suite.T(), todo.CompletedAt)
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rest-api/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignWebhook checks the signature receivers are documented to compute
+func TestSignWebhook(t *testing.T) {
+	payload := []byte(`{"id":"1","type":"ping"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, service.SignWebhook("secret", 1700000000, payload))
+	assert.NotEqual(t, expected, service.SignWebhook("secret", 1700000001, payload))
+	assert.NotEqual(t, expected, service.SignWebhook("other", 1700000000, payload))
+}
+
+// TestWebhookClientRejectsPrivateAddresses checks that deliveries cannot
+// reach the local network, also through names resolving to it
+func TestWebhookClientRejectsPrivateAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	port := receiver.URL[strings.LastIndex(receiver.URL, ":"):]
+
+	client := service.NewWebhookClient(false)
+	for _, url := range []string{receiver.URL, "http://localhost" + port} {
+		_, err := client.Post(url, "application/json", strings.NewReader("{}"))
+		assert.True(t, errors.Is(err, service.ErrWebhookURLNotAllowed), "%s: %v", url, err)
+	}
+
+	// Allowed when private addresses are
+	resp, err := service.NewWebhookClient(true).Post(receiver.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+// TestWebhookClientDoesNotFollowRedirects checks that a receiver cannot
+// send deliveries on to another address
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer receiver.Close()
+
+	resp, err := service.NewWebhookClient(true).Post(receiver.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+	assert.False(t, followed)
+}
+
+// TestWebhookRetryDelay checks the retry schedule of failed deliveries
+func TestWebhookRetryDelay(t *testing.T) {
+	want := []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute,
+		32 * time.Minute, 64 * time.Minute, 128 * time.Minute, 256 * time.Minute,
+		// Capped at 6 hours
+		6 * time.Hour, 6 * time.Hour,
+	}
+	for i, delay := range want {
+		assert.Equal(t, delay, service.WebhookRetryDelay(i+1), "after %d failed attempts", i+1)
+	}
+}
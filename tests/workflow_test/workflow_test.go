@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"errors"
+	"rest-api/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reviewWorkflow has a review step todos must pass before they are done
+func reviewWorkflow() *service.Workflow {
+	return &service.Workflow{
+		Statuses: []service.WorkflowStatus{
+			{Key: "backlog", Name: "Backlog", Category: service.StatusCategoryTodo},
+			{Key: "doing", Name: "Doing", Category: service.StatusCategoryInProgress},
+			{Key: "review", Name: "Review", Category: service.StatusCategoryInProgress},
+			{Key: "done", Name: "Done", Category: service.StatusCategoryDone},
+		},
+		Transitions: map[string][]string{
+			"backlog": {"doing"},
+			"doing":   {"review", "backlog"},
+			"review":  {"done", "doing"},
+		},
+		Initial: "backlog",
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	assert.NoError(t, service.DefaultWorkflow().Validate())
+	assert.NoError(t, reviewWorkflow().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(w *service.Workflow)
+	}{
+		{"no statuses", func(w *service.Workflow) { w.Statuses = nil }},
+		{"upper-case key", func(w *service.Workflow) { w.Statuses[0].Key = "Backlog" }},
+		{"key too long", func(w *service.Workflow) { w.Statuses[0].Key = "a_status_key_over_twenty" }},
+		{"key starting with a digit", func(w *service.Workflow) { w.Statuses[0].Key = "1st" }},
+		{"duplicate key", func(w *service.Workflow) { w.Statuses[2].Key = "doing" }},
+		{"unknown category", func(w *service.Workflow) { w.Statuses[1].Category = "blocked" }},
+		{"no done status", func(w *service.Workflow) { w.Statuses[3].Category = service.StatusCategoryTodo }},
+		{"unknown initial", func(w *service.Workflow) { w.Initial = "todo" }},
+		{"transition from unknown", func(w *service.Workflow) { w.Transitions["archived"] = []string{"backlog"} }},
+		{"transition to unknown", func(w *service.Workflow) { w.Transitions["done"] = []string{"archived"} }},
+	}
+
+	for _, tt := range tests {
+		workflow := reviewWorkflow()
+		tt.modify(workflow)
+		err := workflow.Validate()
+		assert.True(t, errors.Is(err, service.ErrInvalidWorkflow), "%s: %v", tt.name, err)
+	}
+}
+
+func TestWorkflowCanTransition(t *testing.T) {
+	workflow := reviewWorkflow()
+
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{"backlog", "doing", true},
+		{"doing", "review", true},
+		{"review", "done", true},
+		{"review", "doing", true},
+		{"backlog", "done", false},
+		{"doing", "done", false},
+		// Done is final: it has no transitions
+		{"done", "backlog", false},
+		// Staying in a status is always allowed
+		{"done", "done", true},
+		// Todos in a status the workflow dropped may move anywhere
+		{"pending", "done", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, workflow.CanTransition(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+
+	assert.Equal(t, service.StatusCategoryInProgress, workflow.Category("review"))
+	assert.Equal(t, "", workflow.Category("pending"))
+	assert.False(t, workflow.HasStatus("pending"))
+}